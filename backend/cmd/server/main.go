@@ -1,10 +1,27 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"strings"
+	"time"
 	"voting-dapp/backend/internal/api"
+	"voting-dapp/backend/internal/auth"
 	"voting-dapp/backend/internal/blockchain"
+	"voting-dapp/backend/internal/cache"
 	"voting-dapp/backend/internal/config"
+	"voting-dapp/backend/internal/indexer"
+	"voting-dapp/backend/internal/jobs"
+	"voting-dapp/backend/internal/service"
+	"voting-dapp/backend/internal/store"
+	"voting-dapp/backend/internal/stream"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 func main() {
@@ -13,28 +30,320 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	simulated := flag.Bool("simulated", config.AppConfig.Simulated, "run against an in-process simulated chain")
+	flag.Parse()
+
 	log.Printf("Starting Voting DApp Backend...")
-	log.Printf("RPC URL: %s", config.AppConfig.EthRPCUrl)
-	log.Printf("Contract: %s", config.AppConfig.ContractAddr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	// Initialize blockchain client
-	ethClient, err := blockchain.NewClient(
-		config.AppConfig.EthRPCUrl,
-		config.AppConfig.ContractAddr,
-		config.AppConfig.AdminPrivKey,
-	)
-	if err != nil {
-		log.Fatalf("Failed to connect to blockchain: %v", err)
+	var ethClient *blockchain.Client
+	var voting service.VotingService
+	if *simulated {
+		sim, err := service.NewSimulated(blockchain.SimulatedOptions{
+			Voters:      config.AppConfig.SimVoters,
+			VotingPower: uint64(config.AppConfig.SimVotingPower),
+			Operators:   config.AppConfig.SimOperators,
+		})
+		if err != nil {
+			log.Fatalf("Failed to start simulated chain: %v", err)
+		}
+		go sim.Run(ctx, time.Duration(config.AppConfig.SimBlockInterval)*time.Second)
+
+		log.Printf("Simulated chain running, contract: %s", sim.Contract.Hex())
+		log.Printf("Admin: %s (key %x)", sim.Admin.Address.Hex(), crypto.FromECDSA(sim.Admin.Key))
+		for _, v := range sim.Voters {
+			log.Printf("Voter: %s (key %x)", v.Address.Hex(), crypto.FromECDSA(v.Key))
+		}
+		for _, op := range sim.Simulation.Operators {
+			log.Printf("Operator: %s (key %x)", op.Address.Hex(), crypto.FromECDSA(op.Key))
+		}
+		ethClient, voting = sim.Client, sim
+	} else {
+		log.Printf("Contract: %s", config.AppConfig.ContractAddr)
+
+		signer, err := newSigner()
+		if err != nil {
+			log.Fatalf("Failed to set up transaction signer: %v", err)
+		}
+		if signer != nil {
+			log.Printf("Signing transactions as %s (%s signer)", signer.Address().Hex(), config.AppConfig.SignerType)
+			if remote, ok := signer.(*blockchain.RemoteSigner); ok {
+				defer remote.Close()
+			}
+		} else {
+			log.Printf("No signer configured, write endpoints are disabled")
+		}
+		operators, err := newOperatorSigners()
+		if err != nil {
+			log.Fatalf("Failed to set up operator signers: %v", err)
+		}
+		for _, op := range operators {
+			if remote, ok := op.(*blockchain.RemoteSigner); ok {
+				defer remote.Close()
+			}
+		}
+
+		var client *blockchain.Client
+		if urls := config.AppConfig.EthRPCUrls; len(urls) > 1 || config.AppConfig.RPCQuorum > 1 {
+			log.Printf("RPC endpoints: %d (quorum %d)", len(urls), config.AppConfig.RPCQuorum)
+			client, err = blockchain.NewPoolClient(
+				urls,
+				config.AppConfig.ContractAddr,
+				signer,
+				blockchain.PoolConfig{
+					HealthInterval: time.Duration(config.AppConfig.RPCHealthCheck) * time.Second,
+					MaxLag:         uint64(config.AppConfig.RPCMaxLag),
+					Quorum:         config.AppConfig.RPCQuorum,
+				},
+			)
+		} else {
+			log.Printf("RPC URL: %s", urls[0])
+			client, err = blockchain.NewClient(
+				urls[0],
+				config.AppConfig.ContractAddr,
+				signer,
+			)
+		}
+		if err != nil {
+			log.Fatalf("Failed to connect to blockchain: %v", err)
+		}
+		chainID := client.ChainID()
+		log.Printf("Connected to %s (chain ID %s)", blockchain.NetworkName(chainID), chainID)
+		if expected := config.AppConfig.EthChainID; expected != 0 {
+			if err := client.ExpectChainID(uint64(expected)); err != nil {
+				client.Close()
+				log.Fatalf("Wrong network: %v", err)
+			}
+		}
+		if len(operators) > 0 {
+			if err := client.AddOperators(operators...); err != nil {
+				client.Close()
+				log.Fatalf("Failed to add operators: %v", err)
+			}
+			if err := client.VerifyOperators(ctx); err != nil {
+				client.Close()
+				log.Fatalf("Unauthorized operator: %v", err)
+			}
+			log.Printf("Dispatching transactions across %d accounts", len(operators)+1)
+		}
+		if addr := config.AppConfig.MulticallAddr; addr != "" {
+			if err := client.SetMulticall(addr); err != nil {
+				client.Close()
+				log.Fatalf("Failed to set up multicall: %v", err)
+			}
+			log.Printf("Batching reads through multicall contract %s", addr)
+		}
+		ethClient, voting = client, service.NewChain(client)
 	}
 	defer ethClient.Close()
+	ethClient.SetTxTimeout(time.Duration(config.AppConfig.TxTimeout) * time.Second)
+
+	// Price transactions from recent fees, refusing above the caps
+	fees := blockchain.FeePolicy{
+		HistoryBlocks:     uint64(config.AppConfig.FeeHistoryBlocks),
+		RewardPercentile:  config.AppConfig.FeeRewardPercentile,
+		BaseFeeMultiplier: config.AppConfig.FeeBaseMultiplier,
+		GasMultiplier:     config.AppConfig.GasLimitMultiplier,
+	}
+	if config.AppConfig.MaxFeeGwei > 0 {
+		fees.MaxFeePerGas = blockchain.GweiToWei(config.AppConfig.MaxFeeGwei)
+	}
+	if config.AppConfig.MaxPriorityFeeGwei > 0 {
+		fees.MaxTipPerGas = blockchain.GweiToWei(config.AppConfig.MaxPriorityFeeGwei)
+	}
+	ethClient.SetFeePolicy(fees)
+
+	// Read vote history from the first block of interest in bounded queries
+	ethClient.SetLogRange(blockchain.LogRange{
+		StartBlock: uint64(config.AppConfig.IndexerStartBlock),
+		BatchSize:  uint64(config.AppConfig.IndexerBatchSize),
+	})
+
+	// Follow the chain head, reconnecting if the node connection drops
+	ethClient.SetConnConfig(blockchain.ConnConfig{
+		MinBackoff:   time.Duration(config.AppConfig.RPCReconnectMin) * time.Second,
+		MaxBackoff:   time.Duration(config.AppConfig.RPCReconnectMax) * time.Second,
+		PollInterval: time.Duration(config.AppConfig.RPCPollInterval) * time.Second,
+	})
+	go ethClient.Run(ctx)
+
+	// Open local database
+	db, err := store.Open(config.AppConfig.DatabasePath)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	// Track asynchronously submitted transactions
+	jobManager := jobs.NewManager(ethClient, db, time.Duration(config.AppConfig.JobInterval)*time.Second)
+	jobManager.SetStuckPolicy(jobs.StuckPolicy{
+		After:       time.Duration(config.AppConfig.TxStuckAfter) * time.Second,
+		MaxSpeedups: config.AppConfig.TxMaxSpeedups,
+	})
+	go func() {
+		if err := jobManager.Run(ctx); err != nil {
+			log.Printf("Transaction job manager stopped: %v", err)
+		}
+	}()
+
+	// Index contract events and serve reads from the database
+	var ix *indexer.Indexer
+	if config.AppConfig.IndexerEnabled {
+		ix = indexer.New(ethClient, db, indexer.Config{
+			StartBlock:    uint64(config.AppConfig.IndexerStartBlock),
+			BatchSize:     uint64(config.AppConfig.IndexerBatchSize),
+			Interval:      time.Duration(config.AppConfig.IndexerInterval) * time.Second,
+			Confirmations: uint64(config.AppConfig.Confirmations),
+		})
+
+		log.Printf("Backfilling event index...")
+		if err := ix.Backfill(ctx); err != nil {
+			log.Fatalf("Failed to backfill event index: %v", err)
+		}
+
+		voting = service.NewIndexed(voting, db)
+	}
+
+	// Push contract events and poll status changes to stream subscribers
+	events := stream.NewBroker(stream.Config{
+		BufferSize: config.AppConfig.StreamBufferSize,
+		Heartbeat:  time.Duration(config.AppConfig.StreamHeartbeat) * time.Second,
+	})
+	go stream.NewWatcher(ethClient, events, time.Duration(config.AppConfig.StreamInterval)*time.Second).Run(ctx)
+
+	// Cache reads, dropping entries as events change them. Reads served
+	// from the index are dropped once the indexer stores the change, so
+	// that a read between the event and the write is not cached.
+	if size := config.AppConfig.CacheSize; size > 0 {
+		cached := service.NewCached(voting, cache.NewLRU(size))
+		if ix != nil {
+			ix.OnCommit(cached.Commit)
+		} else {
+			go events.Follow(ctx, cached.Apply)
+		}
+		voting = cached
+		log.Printf("Caching up to %d reads", size)
+	}
+	if ix != nil {
+		go ix.Run(ctx)
+	}
 
-	log.Printf("Connected to blockchain successfully")
+	// Sign-In with Ethereum sessions guard the admin routes
+	secret := []byte(config.AppConfig.AuthSecret)
+	if len(secret) == 0 {
+		log.Printf("AUTH_JWT_SECRET not set, generating one; sessions will not survive a restart")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			log.Fatalf("Failed to generate auth secret: %v", err)
+		}
+	}
+	authChainID := uint64(config.AppConfig.AuthChainID)
+	if authChainID == 0 {
+		authChainID = voting.ChainID().Uint64()
+	}
+	authService, err := auth.NewService(auth.Config{
+		Domain:   config.AppConfig.AuthDomain,
+		ChainID:  authChainID,
+		Secret:   secret,
+		TokenTTL: time.Duration(config.AppConfig.AuthTokenTTL) * time.Minute,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize authentication: %v", err)
+	}
 
 	// Setup and start API server
-	router := api.SetupRouter(ethClient)
+	router := api.SetupRouter(voting, jobManager, events, authService)
 
 	log.Printf("Server starting on port %s", config.AppConfig.ServerPort)
 	if err := router.Run(":" + config.AppConfig.ServerPort); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// newSigner returns the admin transaction signer selected by SIGNER_TYPE, or
+// nil if none is configured
+func newSigner() (blockchain.Signer, error) {
+	cfg := config.AppConfig
+	switch cfg.SignerType {
+	case "key":
+		if cfg.AdminPrivKey == "" {
+			return nil, nil
+		}
+		log.Printf("Signing with ADMIN_PRIVATE_KEY; use a keystore or remote signer in production")
+		return blockchain.KeySignerFromHex(cfg.AdminPrivKey)
+	case "keystore":
+		passphrase, err := keystorePassphrase()
+		if err != nil {
+			return nil, err
+		}
+		return blockchain.NewKeystoreSigner(cfg.KeystorePath, passphrase)
+	case "remote":
+		if !common.IsHexAddress(cfg.RemoteSignerAddress) {
+			return nil, fmt.Errorf("REMOTE_SIGNER_ADDRESS %q is not an address", cfg.RemoteSignerAddress)
+		}
+		return blockchain.NewRemoteSigner(cfg.RemoteSignerURL, common.HexToAddress(cfg.RemoteSignerAddress), cfg.RemoteSignerMethod)
+	default:
+		return nil, fmt.Errorf("unknown SIGNER_TYPE %q, want key, keystore or remote", cfg.SignerType)
+	}
+}
+
+// newOperatorSigners returns signers for the operator accounts configured
+// for SIGNER_TYPE
+func newOperatorSigners() ([]blockchain.Signer, error) {
+	cfg := config.AppConfig
+	var signers []blockchain.Signer
+	switch cfg.SignerType {
+	case "key":
+		for _, key := range cfg.OperatorPrivKeys {
+			signer, err := blockchain.KeySignerFromHex(key)
+			if err != nil {
+				return nil, fmt.Errorf("OPERATOR_PRIVATE_KEYS: %v", err)
+			}
+			signers = append(signers, signer)
+		}
+	case "keystore":
+		if len(cfg.OperatorKeystores) == 0 {
+			return nil, nil
+		}
+		passphrase, err := keystorePassphrase()
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range cfg.OperatorKeystores {
+			signer, err := blockchain.NewKeystoreSigner(path, passphrase)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", path, err)
+			}
+			signers = append(signers, signer)
+		}
+	case "remote":
+		for _, address := range cfg.OperatorAddresses {
+			if !common.IsHexAddress(address) {
+				return nil, fmt.Errorf("OPERATOR_ADDRESSES entry %q is not an address", address)
+			}
+			signer, err := blockchain.NewRemoteSigner(cfg.RemoteSignerURL, common.HexToAddress(address), cfg.RemoteSignerMethod)
+			if err != nil {
+				return nil, err
+			}
+			signers = append(signers, signer)
+		}
+	}
+	return signers, nil
+}
+
+// keystorePassphrase returns the keystore password, read from
+// KEYSTORE_PASSWORD_FILE if set
+func keystorePassphrase() (string, error) {
+	cfg := config.AppConfig
+	if cfg.KeystorePasswordFile == "" {
+		return cfg.KeystorePassword, nil
+	}
+	data, err := os.ReadFile(cfg.KeystorePasswordFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read keystore password: %v", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
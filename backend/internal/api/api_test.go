@@ -0,0 +1,1033 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gin-gonic/gin"
+
+	"voting-dapp/backend/internal/auth"
+	"voting-dapp/backend/internal/blockchain"
+	"voting-dapp/backend/internal/cache"
+	"voting-dapp/backend/internal/config"
+	"voting-dapp/backend/internal/indexer"
+	"voting-dapp/backend/internal/jobs"
+	"voting-dapp/backend/internal/models"
+	"voting-dapp/backend/internal/service"
+	"voting-dapp/backend/internal/store"
+	"voting-dapp/backend/internal/stream"
+)
+
+const testVotingPower = 10
+
+// voteSigner is the part of a service needed to sign votes for it
+type voteSigner interface {
+	VoteNonce(voter string) (uint64, error)
+	VoteDigest(pollID, optionIndex, nonce uint64, deadline int64) (common.Hash, error)
+}
+
+// testClient sends requests to a router and signs in and votes against it
+type testClient struct {
+	t      *testing.T
+	router *gin.Engine
+	signer voteSigner
+
+	adminToken string
+}
+
+// testEnv is an API router backed by a simulated chain. A background miner
+// commits pending transactions so that synchronous handlers return.
+type testEnv struct {
+	*testClient
+	sim    *service.Simulated
+	jobs   *jobs.Manager
+	events *stream.Broker
+
+	// mineMu serializes the miner with manual time travel
+	mineMu sync.Mutex
+}
+
+// apiResult is the envelope shared by success and error responses
+type apiResult struct {
+	Success bool             `json:"success"`
+	Data    json.RawMessage  `json:"data"`
+	Error   string           `json:"error"`
+	Meta    *models.BlockRef `json:"meta"`
+}
+
+// pollPage is a page of the poll listing
+type pollPage struct {
+	Polls []struct {
+		ID         uint64
+		Title      string
+		Creator    string
+		TotalVotes uint64
+	}
+	Total      int
+	NextCursor string
+}
+
+// newTestAuth creates a session service for the "localhost" domain and
+// the simulated chain
+func newTestAuth(t *testing.T) *auth.Service {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	config.AppConfig.CORSOrigins = []string{"http://localhost"}
+
+	authService, err := auth.NewService(auth.Config{
+		Domain:  "localhost",
+		ChainID: 1337,
+		Secret:  bytes.Repeat([]byte{7}, 32),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return authService
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	authService := newTestAuth(t)
+
+	// Start a day back so blocks can be mined and time advanced freely
+	// without running ahead of the wall clock. Writes are shared with
+	// operators, as in a deployment under load.
+	sim, err := service.NewSimulated(blockchain.SimulatedOptions{
+		Voters:      3,
+		VotingPower: testVotingPower,
+		Operators:   2,
+		StartTime:   time.Now().Add(-24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(sim.Close)
+	sim.SetTxTimeout(30 * time.Second)
+
+	db, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go sim.Client.Run(ctx)
+
+	jobManager := jobs.NewManager(sim.Client, db, 50*time.Millisecond)
+	go jobManager.Run(ctx)
+
+	events := stream.NewBroker(stream.Config{Heartbeat: time.Second})
+	go stream.NewWatcher(sim.Client, events, 20*time.Millisecond).Run(ctx)
+
+	e := &testEnv{
+		testClient: &testClient{
+			t:      t,
+			router: SetupRouter(sim, jobManager, events, authService),
+			signer: sim,
+		},
+		sim:    sim,
+		jobs:   jobManager,
+		events: events,
+	}
+	go e.mine(ctx)
+
+	e.adminToken = e.login(sim.Admin.Key)
+	return e
+}
+
+// mine commits the pending block whenever it holds transactions
+func (e *testEnv) mine(ctx context.Context) {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.mineMu.Lock()
+			// Adjusting by zero fails only when the pending block has transactions
+			if e.sim.Backend.AdjustTime(0) != nil {
+				e.sim.Backend.Commit()
+			}
+			e.mineMu.Unlock()
+		}
+	}
+}
+
+// advanceTime mines an empty block d after the next block would be mined
+func (e *testEnv) advanceTime(d time.Duration) {
+	e.t.Helper()
+	e.mineMu.Lock()
+	defer e.mineMu.Unlock()
+	if err := e.sim.Backend.AdjustTime(d); err != nil {
+		e.t.Fatal(err)
+	}
+	e.sim.Backend.Commit()
+}
+
+// chainTime returns the timestamp of the latest block
+func (e *testEnv) chainTime() int64 {
+	e.t.Helper()
+	header, err := e.sim.Backend.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		e.t.Fatal(err)
+	}
+	return int64(header.Time)
+}
+
+func (e *testClient) request(method, path string, body interface{}, token string) (int, apiResult) {
+	e.t.Helper()
+
+	var reader *bytes.Reader
+	switch b := body.(type) {
+	case nil:
+		reader = bytes.NewReader(nil)
+	case string:
+		reader = bytes.NewReader([]byte(b))
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			e.t.Fatal(err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	e.router.ServeHTTP(w, req)
+
+	var res apiResult
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		e.t.Fatalf("%s %s: invalid response %q", method, path, w.Body.String())
+	}
+	return w.Code, res
+}
+
+// expect performs a request and fails the test unless it returns status
+func (e *testClient) expect(status int, method, path string, body interface{}, token string) apiResult {
+	e.t.Helper()
+	code, res := e.request(method, path, body, token)
+	if code != status {
+		e.t.Fatalf("%s %s: got %d %s, want %d", method, path, code, res.Error, status)
+	}
+	return res
+}
+
+func decode(t *testing.T, res apiResult, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(res.Data, v); err != nil {
+		t.Fatalf("decode %s: %v", res.Data, err)
+	}
+}
+
+// login signs in with a SIWE message and returns the session token
+func (e *testClient) login(key *ecdsa.PrivateKey) string {
+	e.t.Helper()
+
+	var nonce struct{ Nonce string }
+	decode(e.t, e.expect(http.StatusOK, "GET", "/api/auth/nonce", nil, ""), &nonce)
+
+	message := fmt.Sprintf("localhost wants you to sign in with your Ethereum account:\n%s\n\n"+
+		"Sign in to the voting API.\n\nURI: http://localhost\nVersion: 1\nChain ID: 1337\nNonce: %s\nIssued At: %s",
+		crypto.PubkeyToAddress(key.PublicKey).Hex(), nonce.Nonce, time.Now().UTC().Format(time.RFC3339))
+	sig, err := crypto.Sign(accounts.TextHash([]byte(message)), key)
+	if err != nil {
+		e.t.Fatal(err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+
+	var session struct{ Token string }
+	decode(e.t, e.expect(http.StatusOK, "POST", "/api/auth/verify", map[string]string{
+		"message":   message,
+		"signature": hexutil.Encode(sig),
+	}, ""), &session)
+	return session.Token
+}
+
+// signedVote builds the request body for a vote signed by key
+func (e *testClient) signedVote(key *ecdsa.PrivateKey, pollID, optionIndex uint64) map[string]interface{} {
+	e.t.Helper()
+
+	voter := crypto.PubkeyToAddress(key.PublicKey)
+	nonce, err := e.signer.VoteNonce(voter.Hex())
+	if err != nil {
+		e.t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Hour).Unix()
+	digest, err := e.signer.VoteDigest(pollID, optionIndex, nonce, deadline)
+	if err != nil {
+		e.t.Fatal(err)
+	}
+	sig, err := crypto.Sign(digest.Bytes(), key)
+	if err != nil {
+		e.t.Fatal(err)
+	}
+
+	return map[string]interface{}{
+		"pollId":      pollID,
+		"optionIndex": optionIndex,
+		"voter":       voter.Hex(),
+		"nonce":       nonce,
+		"deadline":    deadline,
+		"signature":   hexutil.Encode(sig),
+	}
+}
+
+// createPoll creates a poll that starts in an hour and lasts an hour
+func (e *testEnv) createPoll(title string) uint64 {
+	e.t.Helper()
+
+	start := e.chainTime() + 3600
+	var created struct{ PollID uint64 }
+	decode(e.t, e.expect(http.StatusCreated, "POST", "/api/polls", map[string]interface{}{
+		"title":       title,
+		"description": "A test poll",
+		"options":     []string{"Yes", "No"},
+		"startTime":   start,
+		"endTime":     start + 3600,
+	}, e.adminToken), &created)
+	return created.PollID
+}
+
+func (e *testClient) pollStatus(pollID uint64) string {
+	e.t.Helper()
+	var status struct{ Status string }
+	decode(e.t, e.expect(http.StatusOK, "GET", fmt.Sprintf("/api/polls/%d/status", pollID), nil, ""), &status)
+	return status.Status
+}
+
+func TestHealthAndContractInfo(t *testing.T) {
+	e := newTestEnv(t)
+
+	// The node is reported once its head has been read
+	var health struct {
+		Status string
+		Node   models.NodeHealth
+	}
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		status, res := e.request("GET", "/api/health", nil, "")
+		if status == http.StatusOK {
+			decode(t, res, &health)
+			break
+		}
+		if status != http.StatusServiceUnavailable || time.Now().After(deadline) {
+			t.Fatalf("GET /api/health: got %d", status)
+		}
+	}
+	if health.Status != "healthy" || !health.Node.Connected || !health.Node.Subscribed || health.Node.Transport != models.TransportSimulated {
+		t.Errorf("health = %+v", health)
+	}
+
+	var info struct {
+		Address string
+		Admin   string
+		ChainID uint64
+		Network string
+	}
+	decode(t, e.expect(http.StatusOK, "GET", "/api/contract", nil, ""), &info)
+	if info.Address != e.sim.Contract.Hex() {
+		t.Errorf("contract address = %s, want %s", info.Address, e.sim.Contract.Hex())
+	}
+	if info.Admin != e.sim.Admin.Address.Hex() {
+		t.Errorf("admin = %s, want %s", info.Admin, e.sim.Admin.Address.Hex())
+	}
+	if info.ChainID != 1337 || info.Network != "localhost" {
+		t.Errorf("network = %s (chain %d), want localhost (chain 1337)", info.Network, info.ChainID)
+	}
+}
+
+func TestOperatorsReport(t *testing.T) {
+	e := newTestEnv(t)
+
+	var operators []models.OperatorStatus
+	decode(t, e.expect(http.StatusOK, "GET", "/api/operators", nil, e.adminToken), &operators)
+	if len(operators) != 1+len(e.sim.Simulation.Operators) {
+		t.Fatalf("operators = %+v, want the admin and %d operators", operators, len(e.sim.Simulation.Operators))
+	}
+	admin := operators[0]
+	if admin.Address != e.sim.Admin.Address.Hex() || !admin.Admin || !admin.Authorized {
+		t.Errorf("admin = %+v", admin)
+	}
+	if admin.Balance == "0" || admin.Nonce == 0 || admin.Pending != 0 {
+		t.Errorf("admin has balance %s, nonce %d and %d pending", admin.Balance, admin.Nonce, admin.Pending)
+	}
+	for i, op := range operators[1:] {
+		if op.Address != e.sim.Simulation.Operators[i].Address.Hex() || op.Admin || !op.Authorized || op.Balance == "0" {
+			t.Errorf("operator %d = %+v", i, op)
+		}
+	}
+}
+
+func TestPollsCreatedByAdminWithOperators(t *testing.T) {
+	e := newTestEnv(t)
+
+	// Writes between the polls go to the operators in turn; the polls
+	// themselves are still the admin's
+	for i := 0; i < 3; i++ {
+		e.createPoll(fmt.Sprintf("Poll %d", i))
+		e.expect(http.StatusOK, "POST", "/api/voting-power/assign", map[string]interface{}{
+			"voter": e.sim.Voters[0].Address.Hex(),
+			"power": 1,
+		}, e.adminToken)
+	}
+
+	var operators []models.OperatorStatus
+	decode(t, e.expect(http.StatusOK, "GET", "/api/operators", nil, e.adminToken), &operators)
+	for _, op := range operators[1:] {
+		if op.Nonce == 0 {
+			t.Errorf("operator %s sent no transactions", op.Address)
+		}
+	}
+
+	var polls pollPage
+	decode(t, e.expect(http.StatusOK, "GET", "/api/creators/"+e.sim.Admin.Address.Hex()+"/polls", nil, ""), &polls)
+	if polls.Total != 3 {
+		t.Fatalf("admin's polls = %+v, want all 3", polls)
+	}
+	for _, p := range polls.Polls {
+		if p.Creator != e.sim.Admin.Address.Hex() {
+			t.Errorf("poll %d created by %s, want admin", p.ID, p.Creator)
+		}
+	}
+}
+
+func TestAdminRoutesRequireAdminSession(t *testing.T) {
+	e := newTestEnv(t)
+	voterToken := e.login(e.sim.Voters[0].Key)
+
+	routes := []struct{ method, path string }{
+		{"POST", "/api/polls"},
+		{"POST", "/api/polls/1/cancel"},
+		{"POST", "/api/polls/1/activate"},
+		{"POST", "/api/polls/1/deactivate"},
+		{"POST", "/api/voting-power/assign"},
+		{"POST", "/api/voting-power/assign-batch"},
+		{"POST", "/api/tx/1/speedup"},
+		{"POST", "/api/tx/1/cancel"},
+		{"GET", "/api/operators"},
+		{"POST", "/api/admin/transfer"},
+		{"POST", "/api/roles/grant"},
+		{"POST", "/api/roles/revoke"},
+	}
+	for _, r := range routes {
+		if code, _ := e.request(r.method, r.path, "{}", ""); code != http.StatusUnauthorized {
+			t.Errorf("%s %s without session: got %d, want 401", r.method, r.path, code)
+		}
+		if code, _ := e.request(r.method, r.path, "{}", "not-a-token"); code != http.StatusUnauthorized {
+			t.Errorf("%s %s with invalid token: got %d, want 401", r.method, r.path, code)
+		}
+		if code, _ := e.request(r.method, r.path, "{}", voterToken); code != http.StatusForbidden {
+			t.Errorf("%s %s as non-admin: got %d, want 403", r.method, r.path, code)
+		}
+	}
+}
+
+func TestRoleManagement(t *testing.T) {
+	e := newTestEnv(t)
+	manager, other := e.sim.Voters[0], e.sim.Voters[1]
+	managerToken := e.login(manager.Key)
+
+	rolesOf := func(address string) []string {
+		var roles models.AccountRoles
+		decode(t, e.expect(http.StatusOK, "GET", "/api/roles/"+address, nil, ""), &roles)
+		return roles.Roles
+	}
+	if roles := rolesOf(e.sim.Admin.Address.Hex()); len(roles) != 1 || roles[0] != "admin" {
+		t.Errorf("admin roles = %v", roles)
+	}
+
+	// A poll manager may manage polls but not voting power
+	grant := gin.H{"address": manager.Address.Hex(), "role": "poll-manager"}
+	e.expect(http.StatusOK, "POST", "/api/roles/grant", grant, e.adminToken)
+	if roles := rolesOf(manager.Address.Hex()); len(roles) != 1 || roles[0] != "poll-manager" {
+		t.Errorf("manager roles = %v", roles)
+	}
+	start := e.chainTime() + 3600
+	var created struct{ PollID uint64 }
+	decode(t, e.expect(http.StatusCreated, "POST", "/api/polls", gin.H{
+		"title":     "Managed",
+		"options":   []string{"Yes", "No"},
+		"startTime": start,
+		"endTime":   start + 3600,
+	}, managerToken), &created)
+	e.expect(http.StatusOK, "POST", fmt.Sprintf("/api/polls/%d/deactivate", created.PollID), nil, managerToken)
+	e.expect(http.StatusForbidden, "POST", "/api/voting-power/assign",
+		gin.H{"voter": other.Address.Hex(), "power": 1}, managerToken)
+	e.expect(http.StatusForbidden, "POST", "/api/roles/grant", grant, managerToken)
+
+	e.expect(http.StatusOK, "POST", "/api/roles/revoke", grant, e.adminToken)
+	e.expect(http.StatusForbidden, "POST", fmt.Sprintf("/api/polls/%d/activate", created.PollID), nil, managerToken)
+
+	e.expect(http.StatusBadRequest, "POST", "/api/roles/grant", gin.H{"address": manager.Address.Hex(), "role": "admin"}, e.adminToken)
+	e.expect(http.StatusBadRequest, "POST", "/api/roles/grant", gin.H{"address": "0x123", "role": "poll-manager"}, e.adminToken)
+	e.expect(http.StatusBadRequest, "GET", "/api/roles/not-an-address", nil, "")
+
+	// Once admin rights move, the old admin's session loses them
+	e.expect(http.StatusOK, "POST", "/api/admin/transfer", gin.H{"newAdmin": other.Address.Hex()}, e.adminToken)
+	var info models.ContractInfo
+	decode(t, e.expect(http.StatusOK, "GET", "/api/contract", nil, ""), &info)
+	if info.Admin != other.Address.Hex() {
+		t.Errorf("admin = %s, want %s", info.Admin, other.Address.Hex())
+	}
+	e.expect(http.StatusForbidden, "POST", "/api/roles/grant", grant, e.adminToken)
+	e.expect(http.StatusOK, "GET", "/api/operators", nil, e.login(other.Key))
+}
+
+func TestAuthVerifyErrors(t *testing.T) {
+	e := newTestEnv(t)
+
+	if code, res := e.request("POST", "/api/auth/verify", map[string]string{
+		"message":   "not a SIWE message",
+		"signature": "0x00",
+	}, ""); code != http.StatusUnauthorized {
+		t.Errorf("invalid message: got %d %s, want 401", code, res.Error)
+	}
+	if code, _ := e.request("POST", "/api/auth/verify", "{}", ""); code != http.StatusBadRequest {
+		t.Errorf("missing fields: got %d, want 400", code)
+	}
+}
+
+func TestPollLifecycle(t *testing.T) {
+	e := newTestEnv(t)
+	voter1, voter2 := e.sim.Voters[0], e.sim.Voters[1]
+
+	id := e.createPoll("Lifecycle")
+
+	var poll struct {
+		ID       uint64
+		Title    string
+		Options  []string
+		Creator  string
+		IsActive bool
+	}
+	decode(t, e.expect(http.StatusOK, "GET", fmt.Sprintf("/api/polls/%d", id), nil, ""), &poll)
+	if poll.ID != id || poll.Title != "Lifecycle" || len(poll.Options) != 2 || !poll.IsActive {
+		t.Fatalf("unexpected poll %+v", poll)
+	}
+	if poll.Creator != e.sim.Admin.Address.Hex() {
+		t.Errorf("creator = %s, want admin", poll.Creator)
+	}
+
+	var polls pollPage
+	decode(t, e.expect(http.StatusOK, "GET", "/api/polls", nil, ""), &polls)
+	if polls.Total != 1 || len(polls.Polls) != 1 || polls.Polls[0].ID != id {
+		t.Fatalf("polls = %+v, want [%d]", polls, id)
+	}
+	creator := "/api/creators/" + e.sim.Admin.Address.Hex() + "/polls"
+	decode(t, e.expect(http.StatusOK, "GET", creator, nil, ""), &polls)
+	if polls.Total != 1 || polls.Polls[0].ID != id {
+		t.Fatalf("admin's polls = %+v, want [%d]", polls, id)
+	}
+	creator = "/api/creators/" + voter1.Address.Hex() + "/polls"
+	decode(t, e.expect(http.StatusOK, "GET", creator, nil, ""), &polls)
+	if polls.Total != 0 {
+		t.Fatalf("voter's polls = %+v, want none", polls)
+	}
+
+	if status := e.pollStatus(id); status != "Pending" {
+		t.Fatalf("status before start = %s, want Pending", status)
+	}
+	res := e.expect(http.StatusBadRequest, "POST", "/api/votes", e.signedVote(voter1.Key, id, 0), "")
+	if !strings.Contains(res.Error, "Poll has not started yet") {
+		t.Errorf("vote before start: %s", res.Error)
+	}
+
+	e.advanceTime(90 * time.Minute)
+	if status := e.pollStatus(id); status != "Active" {
+		t.Fatalf("status after start = %s, want Active", status)
+	}
+
+	e.expect(http.StatusOK, "POST", "/api/votes", e.signedVote(voter1.Key, id, 1), "")
+	e.expect(http.StatusOK, "POST", "/api/votes", e.signedVote(voter2.Key, id, 0), "")
+
+	res = e.expect(http.StatusBadRequest, "POST", "/api/votes", e.signedVote(voter1.Key, id, 0), "")
+	if !strings.Contains(res.Error, "Already voted") {
+		t.Errorf("double vote: %s", res.Error)
+	}
+
+	var results struct {
+		VoteCounts []uint64
+		TotalVotes uint64
+	}
+	decode(t, e.expect(http.StatusOK, "GET", fmt.Sprintf("/api/polls/%d/results", id), nil, ""), &results)
+	if results.TotalVotes != 2*testVotingPower || results.VoteCounts[0] != testVotingPower || results.VoteCounts[1] != testVotingPower {
+		t.Fatalf("results = %+v", results)
+	}
+
+	var status struct {
+		HasVoted    bool
+		OptionIndex uint64
+		VotingPower uint64
+	}
+	decode(t, e.expect(http.StatusOK, "GET", fmt.Sprintf("/api/votes/%d/voter/%s", id, voter1.Address.Hex()), nil, ""), &status)
+	if !status.HasVoted || status.OptionIndex != 1 || status.VotingPower != testVotingPower {
+		t.Errorf("voter status = %+v", status)
+	}
+
+	var page struct {
+		Votes []struct {
+			Voter  string
+			TxHash string
+		}
+		Total int
+	}
+	decode(t, e.expect(http.StatusOK, "GET", fmt.Sprintf("/api/polls/%d/votes?limit=1", id), nil, ""), &page)
+	if page.Total != 2 || len(page.Votes) != 1 || page.Votes[0].Voter != voter1.Address.Hex() || page.Votes[0].TxHash == "" {
+		t.Errorf("votes page = %+v", page)
+	}
+
+	var history []struct{ PollID, OptionIndex uint64 }
+	decode(t, e.expect(http.StatusOK, "GET", "/api/voters/"+voter2.Address.Hex()+"/votes", nil, ""), &history)
+	if len(history) != 1 || history[0].PollID != id || history[0].OptionIndex != 0 {
+		t.Errorf("voter history = %+v", history)
+	}
+
+	e.expect(http.StatusOK, "POST", fmt.Sprintf("/api/polls/%d/deactivate", id), nil, e.adminToken)
+	if status := e.pollStatus(id); status != "Inactive" {
+		t.Fatalf("status after deactivate = %s, want Inactive", status)
+	}
+	e.expect(http.StatusOK, "POST", fmt.Sprintf("/api/polls/%d/activate", id), nil, e.adminToken)
+	if status := e.pollStatus(id); status != "Active" {
+		t.Fatalf("status after activate = %s, want Active", status)
+	}
+
+	e.advanceTime(2 * time.Hour)
+	if status := e.pollStatus(id); status != "Ended" {
+		t.Fatalf("status after end = %s, want Ended", status)
+	}
+
+	e.expect(http.StatusOK, "POST", fmt.Sprintf("/api/polls/%d/cancel", id), nil, e.adminToken)
+	if status := e.pollStatus(id); status != "Canceled" {
+		t.Fatalf("status after cancel = %s, want Canceled", status)
+	}
+}
+
+func TestPollErrors(t *testing.T) {
+	e := newTestEnv(t)
+
+	for _, path := range []string{"/api/polls/abc", "/api/polls/abc/results", "/api/polls/abc/status", "/api/polls/abc/votes"} {
+		if code, _ := e.request("GET", path, nil, ""); code != http.StatusBadRequest {
+			t.Errorf("GET %s: got %d, want 400", path, code)
+		}
+	}
+	if code, _ := e.request("POST", "/api/polls/abc/cancel", nil, e.adminToken); code != http.StatusBadRequest {
+		t.Errorf("cancel invalid ID: got %d, want 400", code)
+	}
+
+	if code, _ := e.request("GET", "/api/polls/42", nil, ""); code != http.StatusNotFound {
+		t.Errorf("missing poll: got %d, want 404", code)
+	}
+	if code, _ := e.request("POST", "/api/polls/42/cancel", nil, e.adminToken); code != http.StatusInternalServerError {
+		t.Errorf("cancel missing poll: got %d, want 500", code)
+	}
+
+	start := e.chainTime() + 3600
+	if code, _ := e.request("POST", "/api/polls", map[string]interface{}{
+		"title": "One option", "options": []string{"Only"}, "startTime": start, "endTime": start + 60,
+	}, e.adminToken); code != http.StatusBadRequest {
+		t.Errorf("single option: got %d, want 400", code)
+	}
+	if _, res := e.request("POST", "/api/polls", map[string]interface{}{
+		"title": "Backwards", "options": []string{"A", "B"}, "startTime": start, "endTime": start - 60,
+	}, e.adminToken); !strings.Contains(res.Error, "Invalid time range") {
+		t.Errorf("end before start: %q", res.Error)
+	}
+
+	if code, _ := e.request("GET", "/api/polls/1/votes?limit=0", nil, ""); code != http.StatusBadRequest {
+		t.Errorf("invalid pagination: got %d, want 400", code)
+	}
+}
+
+func TestVotingPower(t *testing.T) {
+	e := newTestEnv(t)
+	voter := e.sim.Voters[0].Address.Hex()
+
+	var power struct{ Power uint64 }
+	decode(t, e.expect(http.StatusOK, "GET", "/api/voting-power/"+voter, nil, ""), &power)
+	if power.Power != testVotingPower {
+		t.Fatalf("initial power = %d, want %d", power.Power, testVotingPower)
+	}
+
+	e.expect(http.StatusOK, "POST", "/api/voting-power/assign", map[string]interface{}{
+		"voter": voter, "power": 25,
+	}, e.adminToken)
+	decode(t, e.expect(http.StatusOK, "GET", "/api/voting-power/"+voter, nil, ""), &power)
+	if power.Power != 25 {
+		t.Fatalf("power after assign = %d, want 25", power.Power)
+	}
+
+	others := []string{e.sim.Voters[1].Address.Hex(), e.sim.Voters[2].Address.Hex()}
+	e.expect(http.StatusOK, "POST", "/api/voting-power/assign-batch", map[string]interface{}{
+		"voters": others, "powers": []uint64{3, 4},
+	}, e.adminToken)
+	for i, addr := range others {
+		decode(t, e.expect(http.StatusOK, "GET", "/api/voting-power/"+addr, nil, ""), &power)
+		if power.Power != uint64(3+i) {
+			t.Errorf("power of %s = %d, want %d", addr, power.Power, 3+i)
+		}
+	}
+
+	e.expect(http.StatusBadRequest, "POST", "/api/voting-power/assign-batch", map[string]interface{}{
+		"voters": others, "powers": []uint64{1},
+	}, e.adminToken)
+	e.expect(http.StatusBadRequest, "POST", "/api/voting-power/assign", map[string]interface{}{
+		"voter": voter,
+	}, e.adminToken)
+}
+
+func TestReadsAtBlock(t *testing.T) {
+	e := newTestEnv(t)
+	voter := e.sim.Voters[0].Address.Hex()
+	pollID := e.createPoll("Before")
+
+	res := e.expect(http.StatusOK, "GET", "/api/voting-power/"+voter, nil, "")
+	before := res.Meta
+	if before == nil || before.BlockHash == "" {
+		t.Fatalf("meta = %+v, want the block read at", before)
+	}
+
+	e.expect(http.StatusOK, "POST", "/api/voting-power/assign", map[string]interface{}{
+		"voter": voter, "power": 25,
+	}, e.adminToken)
+	e.createPoll("After")
+
+	var power struct{ Power uint64 }
+	at := fmt.Sprintf("?block=%d", before.BlockNumber)
+	res = e.expect(http.StatusOK, "GET", "/api/voting-power/"+voter+at, nil, "")
+	decode(t, res, &power)
+	if power.Power != testVotingPower || *res.Meta != *before {
+		t.Errorf("power at block %d = %d in %+v, want %d", before.BlockNumber, power.Power, res.Meta, testVotingPower)
+	}
+
+	var polls pollPage
+	decode(t, e.expect(http.StatusOK, "GET", "/api/polls"+at, nil, ""), &polls)
+	if polls.Total != 1 || polls.Polls[0].ID != pollID {
+		t.Errorf("polls at block %d = %+v, want only poll %d", before.BlockNumber, polls, pollID)
+	}
+
+	var status struct{ VotingPower uint64 }
+	res = e.expect(http.StatusOK, "GET", fmt.Sprintf("/api/votes/%d/voter/%s", pollID, voter), nil, "")
+	decode(t, res, &status)
+	if status.VotingPower != 25 || res.Meta.BlockNumber <= before.BlockNumber {
+		t.Errorf("latest status = %+v in %+v", status, res.Meta)
+	}
+
+	res = e.expect(http.StatusOK, "GET", fmt.Sprintf("/api/polls/%d/results?at=%d", pollID, before.BlockNumber), nil, "")
+	if *res.Meta != *before {
+		t.Errorf("results at block %d read at %+v", before.BlockNumber, res.Meta)
+	}
+
+	e.expect(http.StatusNotFound, "GET", fmt.Sprintf("/api/polls/%d?block=%d", pollID, res.Meta.BlockNumber+1000), nil, "")
+	e.expect(http.StatusBadRequest, "GET", "/api/polls?block=recent", nil, "")
+	e.expect(http.StatusOK, "GET", "/api/polls?block=latest", nil, "")
+}
+
+func TestPollStatusFilterFollowsReadBlock(t *testing.T) {
+	e := newTestEnv(t)
+	pollID := e.createPoll("Status")
+
+	ids := func(query string) []uint64 {
+		var polls pollPage
+		decode(t, e.expect(http.StatusOK, "GET", "/api/polls"+query, nil, ""), &polls)
+		ids := []uint64{}
+		for _, p := range polls.Polls {
+			ids = append(ids, p.ID)
+		}
+		return ids
+	}
+	pending := e.expect(http.StatusOK, "GET", "/api/polls", nil, "").Meta
+	if got := ids("?status=pending"); !reflect.DeepEqual(got, []uint64{pollID}) {
+		t.Fatalf("pending polls = %v, want [%d]", got, pollID)
+	}
+
+	// Statuses follow the time of the block read, as the contract's do
+	e.advanceTime(90 * time.Minute)
+	if got := ids("?status=active"); !reflect.DeepEqual(got, []uint64{pollID}) {
+		t.Errorf("active polls = %v, want [%d]", got, pollID)
+	}
+	if got := ids("?status=pending"); len(got) != 0 {
+		t.Errorf("pending polls = %v after the start, want none", got)
+	}
+	if got := ids(fmt.Sprintf("?status=pending&block=%d", pending.BlockNumber)); !reflect.DeepEqual(got, []uint64{pollID}) {
+		t.Errorf("pending polls at block %d = %v, want [%d]", pending.BlockNumber, got, pollID)
+	}
+	if status := e.pollStatus(pollID); status != models.PollStatusActive {
+		t.Errorf("contract status = %s, want %s", status, models.PollStatusActive)
+	}
+}
+
+func TestIndexedCacheFollowsIndexer(t *testing.T) {
+	e := newTestEnv(t)
+	db, err := store.Open(filepath.Join(t.TempDir(), "index.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	ix := indexer.New(e.sim.Client, db, indexer.Config{Confirmations: 3})
+	cached := service.NewCached(service.NewIndexed(e.sim, db), cache.NewLRU(100))
+	ix.OnCommit(cached.Commit)
+	reads := &testClient{t: t, router: SetupRouter(cached, nil, e.events, newTestAuth(t))}
+	backfill := func() {
+		t.Helper()
+		if err := ix.Backfill(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	getPoll := func(id uint64) (votes uint64, finality string) {
+		var poll struct {
+			TotalVotes uint64
+			Finality   string
+		}
+		decode(t, reads.expect(http.StatusOK, "GET", fmt.Sprintf("/api/polls/%d", id), nil, ""), &poll)
+		return poll.TotalVotes, poll.Finality
+	}
+
+	id := e.createPoll("Indexed")
+	backfill()
+	if votes, finality := getPoll(id); votes != 0 || finality != models.FinalityUnconfirmed {
+		t.Fatalf("indexed poll: %d votes, %q", votes, finality)
+	}
+
+	// A read after the vote is mined but before it is indexed must not
+	// outlive the indexer storing it
+	e.advanceTime(90 * time.Minute)
+	e.expect(http.StatusOK, "POST", "/api/votes", e.signedVote(e.sim.Voters[0].Key, id, 0), "")
+	if votes, _ := getPoll(id); votes != 0 {
+		t.Fatalf("poll read before indexing: %d votes, want 0", votes)
+	}
+	backfill()
+	if votes, finality := getPoll(id); votes != testVotingPower || finality != models.FinalityUnconfirmed {
+		t.Fatalf("poll after indexing the vote: %d votes, %q", votes, finality)
+	}
+
+	for i := 0; i < 3; i++ {
+		e.advanceTime(time.Second)
+	}
+	backfill()
+	if votes, finality := getPoll(id); votes != testVotingPower || finality != models.FinalityFinal {
+		t.Errorf("poll after confirmations: %d votes, %q, want final", votes, finality)
+	}
+}
+
+func TestVoteErrors(t *testing.T) {
+	e := newTestEnv(t)
+	id := e.createPoll("Errors")
+	e.advanceTime(90 * time.Minute)
+
+	voter := e.sim.Voters[0]
+	stranger, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bad := e.signedVote(voter.Key, id, 0)
+	bad["signature"] = "not hex"
+	e.expect(http.StatusBadRequest, "POST", "/api/votes", bad, "")
+
+	bad = e.signedVote(voter.Key, id, 0)
+	bad["voter"] = "nope"
+	e.expect(http.StatusBadRequest, "POST", "/api/votes", bad, "")
+
+	forged := e.signedVote(stranger, id, 0)
+	forged["voter"] = voter.Address.Hex()
+	res := e.expect(http.StatusUnauthorized, "POST", "/api/votes", forged, "")
+	if !strings.Contains(res.Error, "signature was made by") {
+		t.Errorf("forged vote: %s", res.Error)
+	}
+
+	res = e.expect(http.StatusBadRequest, "POST", "/api/votes", e.signedVote(stranger, id, 0), "")
+	if !strings.Contains(res.Error, "No voting power") {
+		t.Errorf("vote without power: %s", res.Error)
+	}
+
+	res = e.expect(http.StatusBadRequest, "POST", "/api/votes", e.signedVote(voter.Key, id, 5), "")
+	if !strings.Contains(res.Error, "Invalid option index") {
+		t.Errorf("invalid option: %s", res.Error)
+	}
+
+	// Votes signed correctly but with a stale nonce or past their deadline
+	resign := func(nonce uint64, deadline int64) map[string]interface{} {
+		digest, err := e.sim.VoteDigest(id, 0, nonce, deadline)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := crypto.Sign(digest.Bytes(), voter.Key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vote := e.signedVote(voter.Key, id, 0)
+		vote["nonce"], vote["deadline"], vote["signature"] = nonce, deadline, hexutil.Encode(sig)
+		return vote
+	}
+	res = e.expect(http.StatusBadRequest, "POST", "/api/votes", resign(3, time.Now().Add(time.Hour).Unix()), "")
+	if !strings.Contains(res.Error, "invalid nonce") {
+		t.Errorf("vote with a wrong nonce: %s", res.Error)
+	}
+	expired := resign(0, time.Now().Add(-time.Minute).Unix())
+	res = e.expect(http.StatusBadRequest, "POST", "/api/votes", expired, "")
+	if !strings.Contains(res.Error, "signature expired") {
+		t.Errorf("expired vote: %s", res.Error)
+	}
+
+	var nonce struct{ Nonce uint64 }
+	decode(t, e.expect(http.StatusOK, "GET", "/api/votes/nonce/"+voter.Address.Hex(), nil, ""), &nonce)
+	if nonce.Nonce != 0 {
+		t.Errorf("nonce after failed votes = %d, want 0", nonce.Nonce)
+	}
+	e.expect(http.StatusBadRequest, "GET", "/api/votes/nonce/nope", nil, "")
+	e.expect(http.StatusBadRequest, "GET", "/api/voters/nope/votes", nil, "")
+}
+
+func TestAsyncTransactionJob(t *testing.T) {
+	e := newTestEnv(t)
+	voter := e.sim.Voters[0].Address.Hex()
+
+	req := httptest.NewRequest("POST", "/api/voting-power/assign", strings.NewReader(fmt.Sprintf(`{"voter":%q,"power":42}`, voter)))
+	req.Header.Set("Authorization", "Bearer "+e.adminToken)
+	req.Header.Set("Prefer", "respond-async")
+	w := httptest.NewRecorder()
+	e.router.ServeHTTP(w, req)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("got %d %s, want 202", w.Code, w.Body.String())
+	}
+	location := w.Header().Get("Location")
+	if !strings.HasPrefix(location, "/api/tx/") {
+		t.Fatalf("Location = %q", location)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		var job struct {
+			Status string
+			TxHash string
+		}
+		decode(t, e.expect(http.StatusOK, "GET", location, nil, ""), &job)
+		if job.Status == "mined" {
+			break
+		}
+		if job.Status != "pending" || time.Now().After(deadline) {
+			t.Fatalf("job status = %s", job.Status)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	var power struct{ Power uint64 }
+	decode(t, e.expect(http.StatusOK, "GET", "/api/voting-power/"+voter, nil, ""), &power)
+	if power.Power != 42 {
+		t.Errorf("power = %d, want 42", power.Power)
+	}
+
+	e.expect(http.StatusNotFound, "GET", "/api/tx/unknown", nil, "")
+}
+
+// txJobView is the part of a transaction job the tests check
+type txJobView struct {
+	ID               string
+	Status           string
+	TxHash           string
+	PreviousTxHashes []string
+}
+
+// submitStuckJob assigns voting power asynchronously and drops the
+// transaction from the pending block, as a node does with an underpriced
+// transaction, leaving its job pending. Mining stays paused until the
+// returned function is called.
+func (e *testEnv) submitStuckJob(voter string, power uint64) (txJobView, func()) {
+	e.t.Helper()
+	e.mineMu.Lock()
+	var job txJobView
+	decode(e.t, e.expect(http.StatusAccepted, "POST", "/api/voting-power/assign?async=true",
+		gin.H{"voter": voter, "power": power}, e.adminToken), &job)
+	e.sim.Backend.Rollback()
+	return job, e.mineMu.Unlock
+}
+
+// waitForJob polls a transaction job until it leaves the pending status
+func (e *testEnv) waitForJob(id string) txJobView {
+	e.t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		var job txJobView
+		decode(e.t, e.expect(http.StatusOK, "GET", "/api/tx/"+id, nil, ""), &job)
+		if job.Status != models.TxStatusPending {
+			return job
+		}
+		if time.Now().After(deadline) {
+			e.t.Fatalf("job %s still pending", id)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestStuckTransactionJobs(t *testing.T) {
+	e := newTestEnv(t)
+	voter := e.sim.Voters[0].Address.Hex()
+	powerOf := func() uint64 {
+		var power struct{ Power uint64 }
+		decode(t, e.expect(http.StatusOK, "GET", "/api/voting-power/"+voter, nil, ""), &power)
+		return power.Power
+	}
+
+	// Speeding up re-sends the transaction, which is mined in its place
+	stuck, mine := e.submitStuckJob(voter, 42)
+	var sped txJobView
+	decode(t, e.expect(http.StatusAccepted, "POST", "/api/tx/"+stuck.ID+"/speedup", nil, e.adminToken), &sped)
+	mine()
+	if sped.TxHash == stuck.TxHash || len(sped.PreviousTxHashes) != 1 || sped.PreviousTxHashes[0] != stuck.TxHash {
+		t.Errorf("sped up job = %+v, original hash %s", sped, stuck.TxHash)
+	}
+	if job := e.waitForJob(stuck.ID); job.Status != models.TxStatusMined || job.TxHash != sped.TxHash {
+		t.Errorf("sped up job = %+v, want mined as %s", job, sped.TxHash)
+	}
+	if power := powerOf(); power != 42 {
+		t.Errorf("power = %d, want 42", power)
+	}
+
+	// Finished jobs can no longer be replaced
+	e.expect(http.StatusConflict, "POST", "/api/tx/"+stuck.ID+"/cancel", nil, e.adminToken)
+	e.expect(http.StatusNotFound, "POST", "/api/tx/unknown/speedup", nil, e.adminToken)
+
+	// Canceling uses up the nonce without the original call
+	unwanted, mine := e.submitStuckJob(voter, 7)
+	e.expect(http.StatusAccepted, "POST", "/api/tx/"+unwanted.ID+"/cancel", nil, e.adminToken)
+	mine()
+	if job := e.waitForJob(unwanted.ID); job.Status != models.TxStatusCanceled {
+		t.Errorf("canceled job status = %s", job.Status)
+	}
+	if power := powerOf(); power != 42 {
+		t.Errorf("power after cancellation = %d, want 42", power)
+	}
+
+	// Jobs pending past the threshold are sped up automatically
+	e.jobs.SetStuckPolicy(jobs.StuckPolicy{After: 100 * time.Millisecond, MaxSpeedups: 1})
+	slow, mine := e.submitStuckJob(voter, 9)
+	mine()
+	if job := e.waitForJob(slow.ID); job.Status != models.TxStatusMined || len(job.PreviousTxHashes) != 1 {
+		t.Errorf("automatically sped up job = %+v", job)
+	}
+	if power := powerOf(); power != 9 {
+		t.Errorf("power = %d, want 9", power)
+	}
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"voting-dapp/backend/internal/auth"
+	"voting-dapp/backend/internal/blockchain"
+	"voting-dapp/backend/internal/models"
+)
+
+// sessionAddressKey is the context key holding the signed-in address
+const sessionAddressKey = "sessionAddress"
+
+// getAuthNonce returns a single-use nonce for a SIWE message
+func (h *Handler) getAuthNonce(c *gin.Context) {
+	nonce, err := h.auth.NewNonce()
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, auth.ErrTooManyNonces) {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    gin.H{"nonce": nonce},
+	})
+}
+
+// verifyAuth checks a signed SIWE message and returns a session token
+func (h *Handler) verifyAuth(c *gin.Context) {
+	var req models.AuthVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	session, err := h.auth.Verify(req.Message, req.Signature)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    session,
+	})
+}
+
+// requireAdmin rejects requests that do not carry a session token for the
+// contract admin
+func (h *Handler) requireAdmin(c *gin.Context) {
+	h.authorize(c, blockchain.RoleAdmin)
+}
+
+// requireRole returns middleware that rejects requests that do not carry a
+// session token for an account holding role. The admin holds every role.
+func (h *Handler) requireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h.authorize(c, role)
+	}
+}
+
+// authorize lets the request through if its session account holds role
+func (h *Handler) authorize(c *gin.Context, role string) {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Missing session token",
+		})
+		return
+	}
+
+	address, err := h.auth.Authenticate(token)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	allowed, err := h.voting.HasRole(role, address.Hex())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+	if !allowed {
+		message := "Only the contract admin can perform this action"
+		if role != blockchain.RoleAdmin {
+			message = fmt.Sprintf("This action requires the %s role", role)
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, models.ErrorResponse{
+			Success: false,
+			Error:   message,
+		})
+		return
+	}
+
+	c.Set(sessionAddressKey, address.Hex())
+	c.Next()
+}
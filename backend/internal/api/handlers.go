@@ -1,32 +1,58 @@
 package api
+
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 
+	"voting-dapp/backend/internal/auth"
 	"voting-dapp/backend/internal/blockchain"
 	"voting-dapp/backend/internal/config"
+	"voting-dapp/backend/internal/jobs"
 	"voting-dapp/backend/internal/models"
+	"voting-dapp/backend/internal/service"
+	"voting-dapp/backend/internal/store"
+	"voting-dapp/backend/internal/stream"
 )
 
-import (
-	"net/http"
-
-	"github.com/gin-contrib/cors"
-	"github.com/gin-gonic/gin"
-
-	"voting-dapp/backend/internal/blockchain"
-	"voting-dapp/backend/internal/config"
-	"voting-dapp/backend/internal/models"
+// Page sizes for paginated listings
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 500
 )
 
-var ethClient *blockchain.Client
+// Handler serves the API routes on top of a VotingService
+type Handler struct {
+	voting service.VotingService
+	jobs   *jobs.Manager
+	events *stream.Broker
+	auth   *auth.Service
+}
+
+// NewHandler creates a handler. Asynchronous transactions are tracked by
+// jobManager, event streams are fed by events and admin routes require a
+// session from authenticator. Streaming is disabled when events is nil.
+func NewHandler(voting service.VotingService, jobManager *jobs.Manager, events *stream.Broker, authenticator *auth.Service) *Handler {
+	return &Handler{
+		voting: voting,
+		jobs:   jobManager,
+		events: events,
+		auth:   authenticator,
+	}
+}
 
 // SetupRouter initializes the API router
-func SetupRouter(client *blockchain.Client) *gin.Engine {
-	ethClient = client
+func SetupRouter(voting service.VotingService, jobManager *jobs.Manager, events *stream.Broker, authenticator *auth.Service) *gin.Engine {
+	h := NewHandler(voting, jobManager, events, authenticator)
 
 	router := gin.Default()
 
@@ -34,63 +60,122 @@ func SetupRouter(client *blockchain.Client) *gin.Engine {
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     config.AppConfig.CORSOrigins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Prefer", "If-None-Match"},
+		ExposeHeaders:    []string{"Content-Length", "Location", "ETag"},
 		AllowCredentials: true,
 	}))
 
+	// Conditional GET
+	router.Use(etag)
+
 	// API routes
 	api := router.Group("/api")
 	{
 		// Health check
-		api.GET("/health", healthCheck)
+		api.GET("/health", h.healthCheck)
 
 		// Contract info
-		api.GET("/contract", getContractInfo)
+		api.GET("/contract", h.getContractInfo)
+
+		// Accounts the backend sends transactions from
+		api.GET("/operators", h.requireAdmin, h.getOperators)
+
+		// Authentication routes
+		authRoutes := api.Group("/auth")
+		{
+			authRoutes.GET("/nonce", h.getAuthNonce)
+			authRoutes.POST("/verify", h.verifyAuth)
+		}
 
 		// Poll routes
 		polls := api.Group("/polls")
 		{
-			polls.GET("", getAllPolls)
-			polls.GET("/:id", getPoll)
-			polls.GET("/:id/results", getPollResults)
-			polls.GET("/:id/status", getPollStatus)
-			polls.POST("", createPoll)
-			polls.POST("/:id/cancel", cancelPoll)
-			polls.POST("/:id/activate", activatePoll)
-			polls.POST("/:id/deactivate", deactivatePoll)
+			polls.GET("", h.getAllPolls)
+			polls.GET("/:id", h.getPoll)
+			polls.GET("/:id/results", h.getPollResults)
+			polls.GET("/:id/results/series", h.getResultsSeries)
+			polls.GET("/:id/status", h.getPollStatus)
+			polls.GET("/:id/votes", h.getPollVotes)
+			polls.GET("/:id/stream", h.streamPollEvents)
+			polls.POST("", h.requireRole(blockchain.RolePollManager), h.createPoll)
+			polls.POST("/:id/cancel", h.requireRole(blockchain.RolePollManager), h.cancelPoll)
+			polls.POST("/:id/activate", h.requireRole(blockchain.RolePollManager), h.activatePoll)
+			polls.POST("/:id/deactivate", h.requireRole(blockchain.RolePollManager), h.deactivatePoll)
 		}
 
 		// Voting routes
 		votes := api.Group("/votes")
 		{
-			votes.POST("", castVote)
-			votes.GET("/:pollId/voter/:address", getVoterStatus)
+			votes.POST("", h.castVote)
+			votes.GET("/:pollId/voter/:address", h.getVoterStatus)
+			votes.GET("/nonce/:address", h.getVoteNonce)
 		}
 
+		// Voter routes
+		api.GET("/voters/:address/votes", h.getVoterVotes)
+		api.GET("/voters/:address/status", h.getVoterStatuses)
+
+		// Results of several polls
+		api.GET("/results", h.getBatchResults)
+
+		// Creator routes
+		api.GET("/creators/:address/polls", h.getCreatorPolls)
+
 		// Voting power routes
 		power := api.Group("/voting-power")
 		{
-			power.GET("/:address", getVotingPower)
-			power.POST("/assign", assignVotingPower)
-			power.POST("/assign-batch", batchAssignVotingPower)
+			power.GET("/:address", h.getVotingPower)
+			power.POST("/assign", h.requireRole(blockchain.RolePowerAssigner), h.assignVotingPower)
+			power.POST("/assign-batch", h.requireRole(blockchain.RolePowerAssigner), h.batchAssignVotingPower)
+		}
+
+		// Access control routes
+		api.POST("/admin/transfer", h.requireAdmin, h.transferAdmin)
+		roles := api.Group("/roles")
+		{
+			roles.GET("/:address", h.getRoles)
+			roles.POST("/grant", h.requireAdmin, h.grantRole)
+			roles.POST("/revoke", h.requireAdmin, h.revokeRole)
 		}
+
+		// Event stream of every poll
+		api.GET("/events", h.streamEvents)
+
+		// Transaction job routes
+		api.GET("/tx/:id", h.getTxJob)
+		api.POST("/tx/:id/speedup", h.requireAdmin, h.speedUpTxJob)
+		api.POST("/tx/:id/cancel", h.requireAdmin, h.cancelTxJob)
 	}
 
 	return router
 }
 
-// healthCheck returns server health status
-func healthCheck(c *gin.Context) {
+// healthCheck returns server health status, which is degraded while the
+// node connection is down, and read cache statistics if reads are cached
+func (h *Handler) healthCheck(c *gin.Context) {
+	node := h.voting.Health()
+	data := gin.H{"status": "healthy", "node": node}
+	if cached, ok := h.voting.(*service.Cached); ok {
+		data["cache"] = cached.Stats()
+	}
+	if !node.Connected {
+		data["status"] = "degraded"
+		c.JSON(http.StatusServiceUnavailable, models.APIResponse{
+			Success: false,
+			Data:    data,
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
-		Data:    gin.H{"status": "healthy"},
+		Data:    data,
 	})
 }
 
 // getContractInfo returns contract information
-func getContractInfo(c *gin.Context) {
-	admin, err := ethClient.GetAdmin()
+func (h *Handler) getContractInfo(c *gin.Context) {
+	admin, err := h.voting.GetAdmin()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Success: false,
@@ -99,18 +184,22 @@ func getContractInfo(c *gin.Context) {
 		return
 	}
 
+	chainID := h.voting.ChainID()
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
 		Data: models.ContractInfo{
-			Address: ethClient.GetContractAddress(),
+			Address: h.voting.GetContractAddress(),
 			Admin:   admin,
+			ChainID: chainID.Uint64(),
+			Network: blockchain.NetworkName(chainID),
 		},
 	})
 }
 
-// getAllPolls returns all poll IDs
-func getAllPolls(c *gin.Context) {
-	ids, err := ethClient.GetAllPollIds()
+// getOperators returns the balance and pending transactions of each
+// account the backend sends transactions from
+func (h *Handler) getOperators(c *gin.Context) {
+	operators, err := h.voting.Operators(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Success: false,
@@ -119,23 +208,94 @@ func getAllPolls(c *gin.Context) {
 		return
 	}
 
-	polls := make([]*models.Poll, 0, len(ids))
-	for _, id := range ids {
-		poll, err := ethClient.GetPoll(id)
-		if err != nil {
-			continue
-		}
-		polls = append(polls, poll)
-	}
-
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
-		Data:    polls,
+		Data:    operators,
 	})
 }
 
+// getAllPolls returns a page of the polls matching the query filters
+func (h *Handler) getAllPolls(c *gin.Context) {
+	query, err := parsePollQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	reader, block, ok := h.readerAt(c)
+	if !ok {
+		return
+	}
+
+	var polls []*models.Poll
+	if creator := c.Query("creator"); creator != "" {
+		if !common.IsHexAddress(creator) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Success: false,
+				Error:   "Invalid creator address",
+			})
+			return
+		}
+		polls, err = reader.ListPollsByCreator(creator)
+	} else {
+		polls, err = reader.ListPolls()
+	}
+	failed, partial := blockchain.AsPartialRead(err)
+	if err != nil && !partial {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	h.respondPollPage(c, reader, block, polls, failed, query)
+}
+
+// getCreatorPolls returns a page of the polls an address created, taking
+// the same filters as getAllPolls
+func (h *Handler) getCreatorPolls(c *gin.Context) {
+	address := c.Param("address")
+	if !common.IsHexAddress(address) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid address",
+		})
+		return
+	}
+
+	query, err := parsePollQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	reader, block, ok := h.readerAt(c)
+	if !ok {
+		return
+	}
+
+	polls, err := reader.ListPollsByCreator(address)
+	failed, partial := blockchain.AsPartialRead(err)
+	if err != nil && !partial {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	h.respondPollPage(c, reader, block, polls, failed, query)
+}
+
 // getPoll returns a specific poll
-func getPoll(c *gin.Context) {
+func (h *Handler) getPoll(c *gin.Context) {
 	pollID := c.Param("id")
 	var id uint64
 	if _, err := fmt.Sscanf(pollID, "%d", &id); err != nil {
@@ -146,7 +306,12 @@ func getPoll(c *gin.Context) {
 		return
 	}
 
-	poll, err := ethClient.GetPoll(id)
+	reader, block, ok := h.readerAt(c)
+	if !ok {
+		return
+	}
+
+	poll, err := reader.GetPoll(id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, models.ErrorResponse{
 			Success: false,
@@ -157,12 +322,14 @@ func getPoll(c *gin.Context) {
 
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
+		Meta:    readBlock(reader, block),
 		Data:    poll,
 	})
 }
 
-// getPollResults returns poll results
-func getPollResults(c *gin.Context) {
+// getPollResults returns poll results, as of the time or block in the at
+// query parameter if it is set
+func (h *Handler) getPollResults(c *gin.Context) {
 	pollID := c.Param("id")
 	var id uint64
 	if _, err := fmt.Sscanf(pollID, "%d", &id); err != nil {
@@ -173,7 +340,17 @@ func getPollResults(c *gin.Context) {
 		return
 	}
 
-	results, err := ethClient.GetPollResults(id)
+	if c.Query("at") != "" {
+		h.respondResultsAt(c, id)
+		return
+	}
+
+	reader, block, ok := h.readerAt(c)
+	if !ok {
+		return
+	}
+
+	results, err := reader.GetPollResults(id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, models.ErrorResponse{
 			Success: false,
@@ -184,12 +361,46 @@ func getPollResults(c *gin.Context) {
 
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
+		Meta:    readBlock(reader, block),
 		Data:    results,
 	})
 }
 
+// getBatchResults returns the results of the polls listed in the polls
+// query parameter, read together
+func (h *Handler) getBatchResults(c *gin.Context) {
+	ids, ok := parsePollIDs(c)
+	if !ok {
+		return
+	}
+
+	reader, block, ok := h.readerAt(c)
+	if !ok {
+		return
+	}
+
+	results, err := reader.GetPollsResults(ids)
+	failed, partial := blockchain.AsPartialRead(err)
+	if err != nil && !partial {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Meta:    readBlock(reader, block),
+		Data: &models.BatchResults{
+			Results: results,
+			Failed:  failed,
+		},
+	})
+}
+
 // getPollStatus returns poll status
-func getPollStatus(c *gin.Context) {
+func (h *Handler) getPollStatus(c *gin.Context) {
 	pollID := c.Param("id")
 	var id uint64
 	if _, err := fmt.Sscanf(pollID, "%d", &id); err != nil {
@@ -200,7 +411,12 @@ func getPollStatus(c *gin.Context) {
 		return
 	}
 
-	status, err := ethClient.GetPollStatus(id)
+	reader, block, ok := h.readerAt(c)
+	if !ok {
+		return
+	}
+
+	status, err := reader.GetPollStatus(id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, models.ErrorResponse{
 			Success: false,
@@ -211,6 +427,7 @@ func getPollStatus(c *gin.Context) {
 
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
+		Meta:    readBlock(reader, block),
 		Data: models.PollStatus{
 			PollID: id,
 			Status: status,
@@ -219,7 +436,7 @@ func getPollStatus(c *gin.Context) {
 }
 
 // createPoll creates a new poll
-func createPoll(c *gin.Context) {
+func (h *Handler) createPoll(c *gin.Context) {
 	var req models.CreatePollRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -229,7 +446,19 @@ func createPoll(c *gin.Context) {
 		return
 	}
 
-	pollID, err := ethClient.CreatePoll(
+	if wantsAsync(c) {
+		tx, err := h.voting.CreatePollTx(
+			req.Title,
+			req.Description,
+			req.Options,
+			req.StartTime,
+			req.EndTime,
+		)
+		h.respondAccepted(c, jobs.ActionCreatePoll, tx, err)
+		return
+	}
+
+	pollID, err := h.voting.CreatePoll(
 		req.Title,
 		req.Description,
 		req.Options,
@@ -237,7 +466,7 @@ func createPoll(c *gin.Context) {
 		req.EndTime,
 	)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		c.JSON(writeErrorStatus(err), models.ErrorResponse{
 			Success: false,
 			Error:   err.Error(),
 		})
@@ -253,7 +482,7 @@ func createPoll(c *gin.Context) {
 }
 
 // cancelPoll cancels a poll
-func cancelPoll(c *gin.Context) {
+func (h *Handler) cancelPoll(c *gin.Context) {
 	pollID := c.Param("id")
 	var id uint64
 	if _, err := fmt.Sscanf(pollID, "%d", &id); err != nil {
@@ -264,8 +493,14 @@ func cancelPoll(c *gin.Context) {
 		return
 	}
 
-	if err := ethClient.CancelPoll(id); err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+	if wantsAsync(c) {
+		tx, err := h.voting.CancelPollTx(id)
+		h.respondAccepted(c, jobs.ActionCancelPoll, tx, err)
+		return
+	}
+
+	if err := h.voting.CancelPoll(id); err != nil {
+		c.JSON(writeErrorStatus(err), models.ErrorResponse{
 			Success: false,
 			Error:   err.Error(),
 		})
@@ -279,7 +514,7 @@ func cancelPoll(c *gin.Context) {
 }
 
 // activatePoll activates a poll
-func activatePoll(c *gin.Context) {
+func (h *Handler) activatePoll(c *gin.Context) {
 	pollID := c.Param("id")
 	var id uint64
 	if _, err := fmt.Sscanf(pollID, "%d", &id); err != nil {
@@ -290,8 +525,14 @@ func activatePoll(c *gin.Context) {
 		return
 	}
 
-	if err := ethClient.ActivatePoll(id); err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+	if wantsAsync(c) {
+		tx, err := h.voting.ActivatePollTx(id)
+		h.respondAccepted(c, jobs.ActionActivatePoll, tx, err)
+		return
+	}
+
+	if err := h.voting.ActivatePoll(id); err != nil {
+		c.JSON(writeErrorStatus(err), models.ErrorResponse{
 			Success: false,
 			Error:   err.Error(),
 		})
@@ -305,7 +546,7 @@ func activatePoll(c *gin.Context) {
 }
 
 // deactivatePoll deactivates a poll
-func deactivatePoll(c *gin.Context) {
+func (h *Handler) deactivatePoll(c *gin.Context) {
 	pollID := c.Param("id")
 	var id uint64
 	if _, err := fmt.Sscanf(pollID, "%d", &id); err != nil {
@@ -316,8 +557,14 @@ func deactivatePoll(c *gin.Context) {
 		return
 	}
 
-	if err := ethClient.DeactivatePoll(id); err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+	if wantsAsync(c) {
+		tx, err := h.voting.DeactivatePollTx(id)
+		h.respondAccepted(c, jobs.ActionDeactivatePoll, tx, err)
+		return
+	}
+
+	if err := h.voting.DeactivatePoll(id); err != nil {
+		c.JSON(writeErrorStatus(err), models.ErrorResponse{
 			Success: false,
 			Error:   err.Error(),
 		})
@@ -331,7 +578,7 @@ func deactivatePoll(c *gin.Context) {
 }
 
 // castVote casts a vote
-func castVote(c *gin.Context) {
+func (h *Handler) castVote(c *gin.Context) {
 	var req models.VoteRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -341,8 +588,39 @@ func castVote(c *gin.Context) {
 		return
 	}
 
-	if err := ethClient.Vote(req.PollID, req.OptionIndex); err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+	if !common.IsHexAddress(req.Voter) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid voter address",
+		})
+		return
+	}
+	signature, err := hexutil.Decode(req.Signature)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid signature encoding",
+		})
+		return
+	}
+
+	vote := &blockchain.SignedVote{
+		PollID:      req.PollID,
+		OptionIndex: req.OptionIndex,
+		Voter:       common.HexToAddress(req.Voter),
+		Nonce:       req.Nonce,
+		Deadline:    req.Deadline,
+		Signature:   signature,
+	}
+
+	if wantsAsync(c) {
+		tx, err := h.voting.VoteBySigTx(vote)
+		h.respondAccepted(c, jobs.ActionVoteBySig, tx, err)
+		return
+	}
+
+	if err := h.voting.VoteBySig(vote); err != nil {
+		c.JSON(writeErrorStatus(err), models.ErrorResponse{
 			Success: false,
 			Error:   err.Error(),
 		})
@@ -355,8 +633,37 @@ func castVote(c *gin.Context) {
 	})
 }
 
+// getVoteNonce returns the nonce a voter must sign into their next vote
+func (h *Handler) getVoteNonce(c *gin.Context) {
+	address := c.Param("address")
+	if !common.IsHexAddress(address) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid address",
+		})
+		return
+	}
+
+	nonce, err := h.voting.VoteNonce(address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: gin.H{
+			"address": address,
+			"nonce":   nonce,
+		},
+	})
+}
+
 // getVoterStatus returns voter status for a poll
-func getVoterStatus(c *gin.Context) {
+func (h *Handler) getVoterStatus(c *gin.Context) {
 	pollID := c.Param("pollId")
 	voter := c.Param("address")
 
@@ -369,7 +676,12 @@ func getVoterStatus(c *gin.Context) {
 		return
 	}
 
-	status, err := ethClient.GetVoterStatus(id, voter)
+	reader, block, ok := h.readerAt(c)
+	if !ok {
+		return
+	}
+
+	status, err := reader.GetVoterStatus(id, voter)
 	if err != nil {
 		c.JSON(http.StatusNotFound, models.ErrorResponse{
 			Success: false,
@@ -380,15 +692,139 @@ func getVoterStatus(c *gin.Context) {
 
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
+		Meta:    readBlock(reader, block),
 		Data:    status,
 	})
 }
 
+// getVoterStatuses returns a voter's status in the polls listed in the
+// polls query parameter, read together
+func (h *Handler) getVoterStatuses(c *gin.Context) {
+	address := c.Param("address")
+	if !common.IsHexAddress(address) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid address",
+		})
+		return
+	}
+	ids, ok := parsePollIDs(c)
+	if !ok {
+		return
+	}
+
+	reader, block, ok := h.readerAt(c)
+	if !ok {
+		return
+	}
+
+	statuses, err := reader.GetVoterStatuses(ids, address)
+	failed, partial := blockchain.AsPartialRead(err)
+	if err != nil && !partial {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Meta:    readBlock(reader, block),
+		Data: &models.BatchVoterStatus{
+			Voter:    common.HexToAddress(address).Hex(),
+			Statuses: statuses,
+			Failed:   failed,
+		},
+	})
+}
+
+// getPollVotes returns a page of the individual ballots cast in a poll
+func (h *Handler) getPollVotes(c *gin.Context) {
+	pollID := c.Param("id")
+	var id uint64
+	if _, err := fmt.Sscanf(pollID, "%d", &id); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid poll ID",
+		})
+		return
+	}
+
+	offset, limit, ok := parsePagination(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid pagination parameters",
+		})
+		return
+	}
+
+	votes, err := h.voting.GetPollVotes(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	page := &models.VotePage{
+		Votes:  []*models.Vote{},
+		Total:  len(votes),
+		Offset: offset,
+		Limit:  limit,
+	}
+	if offset < len(votes) {
+		end := offset + limit
+		if end > len(votes) {
+			end = len(votes)
+		}
+		page.Votes = votes[offset:end]
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    page,
+	})
+}
+
+// getVoterVotes returns every vote an address has cast across all polls
+func (h *Handler) getVoterVotes(c *gin.Context) {
+	address := c.Param("address")
+	if !common.IsHexAddress(address) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid address",
+		})
+		return
+	}
+
+	votes, err := h.voting.GetVotesByVoter(address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    votes,
+	})
+}
+
 // getVotingPower returns voting power for an address
-func getVotingPower(c *gin.Context) {
+func (h *Handler) getVotingPower(c *gin.Context) {
 	address := c.Param("address")
 
-	power, err := ethClient.GetVotingPower(address)
+	reader, block, ok := h.readerAt(c)
+	if !ok {
+		return
+	}
+
+	power, err := reader.GetVotingPower(address)
 	if err != nil {
 		c.JSON(http.StatusNotFound, models.ErrorResponse{
 			Success: false,
@@ -399,6 +835,7 @@ func getVotingPower(c *gin.Context) {
 
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
+		Meta:    readBlock(reader, block),
 		Data: gin.H{
 			"address": address,
 			"power":   power,
@@ -407,7 +844,7 @@ func getVotingPower(c *gin.Context) {
 }
 
 // assignVotingPower assigns voting power to a voter
-func assignVotingPower(c *gin.Context) {
+func (h *Handler) assignVotingPower(c *gin.Context) {
 	var req models.AssignVotingPowerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -417,8 +854,14 @@ func assignVotingPower(c *gin.Context) {
 		return
 	}
 
-	if err := ethClient.AssignVotingPower(req.Voter, req.Power); err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+	if wantsAsync(c) {
+		tx, err := h.voting.AssignVotingPowerTx(req.Voter, req.Power)
+		h.respondAccepted(c, jobs.ActionAssignVotingPower, tx, err)
+		return
+	}
+
+	if err := h.voting.AssignVotingPower(req.Voter, req.Power); err != nil {
+		c.JSON(writeErrorStatus(err), models.ErrorResponse{
 			Success: false,
 			Error:   err.Error(),
 		})
@@ -432,7 +875,7 @@ func assignVotingPower(c *gin.Context) {
 }
 
 // batchAssignVotingPower batch assigns voting power
-func batchAssignVotingPower(c *gin.Context) {
+func (h *Handler) batchAssignVotingPower(c *gin.Context) {
 	var req models.BatchAssignVotingPowerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -450,8 +893,14 @@ func batchAssignVotingPower(c *gin.Context) {
 		return
 	}
 
-	if err := ethClient.BatchAssignVotingPower(req.Voters, req.Powers); err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+	if wantsAsync(c) {
+		tx, err := h.voting.BatchAssignVotingPowerTx(req.Voters, req.Powers)
+		h.respondAccepted(c, jobs.ActionBatchAssignVotingPower, tx, err)
+		return
+	}
+
+	if err := h.voting.BatchAssignVotingPower(req.Voters, req.Powers); err != nil {
+		c.JSON(writeErrorStatus(err), models.ErrorResponse{
 			Success: false,
 			Error:   err.Error(),
 		})
@@ -463,3 +912,213 @@ func batchAssignVotingPower(c *gin.Context) {
 		Data:    gin.H{"message": "Voting powers assigned successfully"},
 	})
 }
+
+// getTxJob returns the status of an asynchronously submitted transaction
+func (h *Handler) getTxJob(c *gin.Context) {
+	job, err := h.jobs.Get(c.Param("id"))
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Success: false,
+			Error:   "Transaction job not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    job,
+	})
+}
+
+// speedUpTxJob re-sends a pending job's transaction with higher fees
+func (h *Handler) speedUpTxJob(c *gin.Context) {
+	job, err := h.jobs.SpeedUp(c.Request.Context(), c.Param("id"))
+	h.respondReplaced(c, job, err)
+}
+
+// cancelTxJob replaces a pending job's transaction with a zero-value
+// transfer to the admin account
+func (h *Handler) cancelTxJob(c *gin.Context) {
+	job, err := h.jobs.Cancel(c.Request.Context(), c.Param("id"))
+	h.respondReplaced(c, job, err)
+}
+
+// respondReplaced answers a speed-up or cancellation with the job, which
+// stays pending until one of its transactions is mined
+func (h *Handler) respondReplaced(c *gin.Context, job *models.TxJob, err error) {
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Success: false,
+			Error:   "Transaction job not found",
+		})
+		return
+	}
+	if err != nil {
+		status := writeErrorStatus(err)
+		if errors.Is(err, jobs.ErrNotPending) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.Header("Location", "/api/tx/"+job.ID)
+	c.JSON(http.StatusAccepted, models.APIResponse{
+		Success: true,
+		Data:    job,
+	})
+}
+
+// wantsAsync reports whether the caller asked not to wait for the transaction
+// to be mined, either with ?async=true or a "Prefer: respond-async" header
+func wantsAsync(c *gin.Context) bool {
+	if async, err := strconv.ParseBool(c.Query("async")); err == nil && async {
+		return true
+	}
+	return strings.Contains(c.GetHeader("Prefer"), "respond-async")
+}
+
+// respondAccepted registers a submitted transaction as a job and replies
+// 202 Accepted with it
+func (h *Handler) respondAccepted(c *gin.Context, action string, tx *types.Transaction, err error) {
+	if err != nil {
+		c.JSON(writeErrorStatus(err), models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	job, err := h.jobs.Track(action, tx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.Header("Location", "/api/tx/"+job.ID)
+	c.JSON(http.StatusAccepted, models.APIResponse{
+		Success: true,
+		Data:    job,
+	})
+}
+
+// writeErrorStatus returns the HTTP status for a failed write. A send refused
+// because network fees are above the configured cap is worth retrying later;
+// a signed vote that is forged, expired, reuses a nonce or would revert is the
+// client's to fix.
+func writeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, blockchain.ErrFeeTooHigh):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, blockchain.ErrInvalidVoteSignature):
+		return http.StatusUnauthorized
+	case errors.Is(err, blockchain.ErrVoteRejected):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// parsePagination reads the offset and limit query parameters
+func parsePagination(c *gin.Context) (offset, limit int, ok bool) {
+	if v := c.Query("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, false
+		}
+		offset = n
+	}
+	limit, ok = parseLimit(c)
+	return offset, limit, ok
+}
+
+// parseLimit reads the limit query parameter
+func parseLimit(c *gin.Context) (int, bool) {
+	v := c.Query("limit")
+	if v == "" {
+		return defaultPageLimit, true
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 || n > maxPageLimit {
+		return 0, false
+	}
+	return n, true
+}
+
+// parsePollIDs reads the comma-separated poll IDs of the polls query
+// parameter, replying with 400 if they are missing, invalid or too many
+func parsePollIDs(c *gin.Context) ([]uint64, bool) {
+	fields := strings.Split(c.Query("polls"), ",")
+	if c.Query("polls") == "" || len(fields) > maxPageLimit {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   fmt.Sprintf("polls must list 1 to %d poll IDs", maxPageLimit),
+		})
+		return nil, false
+	}
+
+	ids := make([]uint64, len(fields))
+	for i, field := range fields {
+		id, err := strconv.ParseUint(strings.TrimSpace(field), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Success: false,
+				Error:   "Invalid poll ID",
+			})
+			return nil, false
+		}
+		ids[i] = id
+	}
+	return ids, true
+}
+
+// readerAt returns a reader of the block named by the block query parameter,
+// or of the latest block if it is unset, along with the block it reads. It
+// replies with 400 if the block is invalid and 404 if it is unknown.
+func (h *Handler) readerAt(c *gin.Context) (service.Reader, *models.BlockRef, bool) {
+	var number *big.Int
+	if v := c.Query("block"); v != "" && v != "latest" {
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Success: false,
+				Error:   "Invalid block, want a block number or latest",
+			})
+			return nil, nil, false
+		}
+		number = new(big.Int).SetUint64(n)
+	}
+
+	reader, block, err := h.voting.At(number)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, blockchain.ErrUnknownBlock) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return nil, nil, false
+	}
+	return reader, &block, true
+}
+
+// readBlock returns the block the reads of reader so far saw, given the
+// block readerAt opened it at
+func readBlock(reader service.Reader, block *models.BlockRef) *models.BlockRef {
+	ref := service.ReadBlock(reader, *block)
+	return &ref
+}
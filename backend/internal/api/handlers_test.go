@@ -0,0 +1,526 @@
+package api
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"voting-dapp/backend/internal/cache"
+	"voting-dapp/backend/internal/models"
+	"voting-dapp/backend/internal/service"
+	"voting-dapp/backend/internal/stream"
+)
+
+// fakeEnv is an API router backed by an in-memory fake whose clock only
+// moves when the test advances it
+type fakeEnv struct {
+	*testClient
+	fake   *service.Fake
+	events *stream.Broker
+	now    time.Time
+}
+
+func newFakeEnv(t *testing.T) *fakeEnv {
+	t.Helper()
+
+	admin, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake := service.NewFake(admin)
+	events := stream.NewBroker(stream.Config{Heartbeat: 20 * time.Millisecond})
+
+	e := &fakeEnv{
+		testClient: &testClient{
+			t:      t,
+			router: SetupRouter(fake, nil, events, newTestAuth(t)),
+			signer: fake,
+		},
+		fake:   fake,
+		events: events,
+		now:    time.Unix(1700000000, 0),
+	}
+	fake.Now = func() time.Time { return e.now }
+
+	e.adminToken = e.login(admin)
+	return e
+}
+
+// newVoter returns a key whose account was given power by the admin
+func (e *fakeEnv) newVoter(power uint64) *ecdsa.PrivateKey {
+	e.t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		e.t.Fatal(err)
+	}
+	e.expect(http.StatusOK, "POST", "/api/voting-power/assign", map[string]interface{}{
+		"voter": crypto.PubkeyToAddress(key.PublicKey).Hex(),
+		"power": power,
+	}, e.adminToken)
+	return key
+}
+
+// createPoll creates a poll that starts in an hour and lasts an hour
+func (e *fakeEnv) createPoll(title string) uint64 {
+	e.t.Helper()
+	start := e.now.Unix() + 3600
+	var created struct{ PollID uint64 }
+	decode(e.t, e.expect(http.StatusCreated, "POST", "/api/polls", map[string]interface{}{
+		"title":     title,
+		"options":   []string{"Yes", "No", "Abstain"},
+		"startTime": start,
+		"endTime":   start + 3600,
+	}, e.adminToken), &created)
+	return created.PollID
+}
+
+func TestRoutersDoNotShareState(t *testing.T) {
+	a := newFakeEnv(t)
+	b := newFakeEnv(t)
+
+	a.createPoll("Only on A")
+
+	var page pollPage
+	decode(t, a.expect(http.StatusOK, "GET", "/api/polls", nil, ""), &page)
+	if page.Total != 1 {
+		t.Fatalf("router A has %d polls, want 1", page.Total)
+	}
+	decode(t, b.expect(http.StatusOK, "GET", "/api/polls", nil, ""), &page)
+	if page.Total != 0 {
+		t.Fatalf("router B has %d polls, want 0", page.Total)
+	}
+
+	// A session from one router's admin is not the other's admin
+	b.expect(http.StatusForbidden, "POST", "/api/polls/1/cancel", nil, a.adminToken)
+}
+
+func TestFakePollStatusFollowsClock(t *testing.T) {
+	e := newFakeEnv(t)
+	voter := e.newVoter(4)
+	id := e.createPoll("Clock")
+
+	if status := e.pollStatus(id); status != "Pending" {
+		t.Fatalf("status = %s, want Pending", status)
+	}
+	res := e.expect(http.StatusBadRequest, "POST", "/api/votes", e.signedVote(voter, id, 2), "")
+	if !strings.Contains(res.Error, "Poll has not started yet") {
+		t.Errorf("early vote: %s", res.Error)
+	}
+
+	e.now = e.now.Add(90 * time.Minute)
+	if status := e.pollStatus(id); status != "Active" {
+		t.Fatalf("status = %s, want Active", status)
+	}
+	e.expect(http.StatusOK, "POST", "/api/votes", e.signedVote(voter, id, 2), "")
+
+	var results struct {
+		VoteCounts []uint64
+		TotalVotes uint64
+	}
+	decode(t, e.expect(http.StatusOK, "GET", fmt.Sprintf("/api/polls/%d/results", id), nil, ""), &results)
+	if results.TotalVotes != 4 || results.VoteCounts[2] != 4 {
+		t.Errorf("results = %+v", results)
+	}
+
+	e.now = e.now.Add(time.Hour)
+	if status := e.pollStatus(id); status != "Ended" {
+		t.Fatalf("status = %s, want Ended", status)
+	}
+	res = e.expect(http.StatusBadRequest, "POST", "/api/votes", e.signedVote(e.newVoter(1), id, 0), "")
+	if !strings.Contains(res.Error, "Poll has ended") {
+		t.Errorf("late vote: %s", res.Error)
+	}
+}
+
+func TestPollVotesPagination(t *testing.T) {
+	e := newFakeEnv(t)
+	id := e.createPoll("Pages")
+	e.now = e.now.Add(90 * time.Minute)
+
+	voters := make([]string, 5)
+	for i := range voters {
+		key := e.newVoter(uint64(i + 1))
+		voters[i] = crypto.PubkeyToAddress(key.PublicKey).Hex()
+		e.expect(http.StatusOK, "POST", "/api/votes", e.signedVote(key, id, uint64(i%2)), "")
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", voters},
+		{"?limit=2", voters[:2]},
+		{"?offset=2&limit=2", voters[2:4]},
+		{"?offset=4&limit=2", voters[4:]},
+		{"?offset=9", nil},
+	}
+	for _, tt := range tests {
+		var page struct {
+			Votes []struct{ Voter string }
+			Total int
+		}
+		decode(t, e.expect(http.StatusOK, "GET", fmt.Sprintf("/api/polls/%d/votes%s", id, tt.query), nil, ""), &page)
+		if page.Total != len(voters) {
+			t.Errorf("%q: total = %d, want %d", tt.query, page.Total, len(voters))
+		}
+		if len(page.Votes) != len(tt.want) {
+			t.Errorf("%q: got %d votes, want %d", tt.query, len(page.Votes), len(tt.want))
+			continue
+		}
+		for i, v := range page.Votes {
+			if v.Voter != tt.want[i] {
+				t.Errorf("%q: vote %d by %s, want %s", tt.query, i, v.Voter, tt.want[i])
+			}
+		}
+	}
+
+	for _, query := range []string{"?offset=-1", "?limit=0", fmt.Sprintf("?limit=%d", maxPageLimit+1), "?limit=x"} {
+		e.expect(http.StatusBadRequest, "GET", fmt.Sprintf("/api/polls/%d/votes%s", id, query), nil, "")
+	}
+}
+
+func TestVoteNonceAdvancesAfterRelay(t *testing.T) {
+	e := newFakeEnv(t)
+	voter := e.newVoter(1)
+	address := crypto.PubkeyToAddress(voter.PublicKey).Hex()
+	first := e.createPoll("First")
+	second := e.createPoll("Second")
+	e.now = e.now.Add(90 * time.Minute)
+
+	replay := e.signedVote(voter, first, 0)
+	e.expect(http.StatusOK, "POST", "/api/votes", replay, "")
+
+	var nonce struct{ Nonce uint64 }
+	decode(t, e.expect(http.StatusOK, "GET", "/api/votes/nonce/"+address, nil, ""), &nonce)
+	if nonce.Nonce != 1 {
+		t.Fatalf("nonce = %d, want 1", nonce.Nonce)
+	}
+
+	res := e.expect(http.StatusBadRequest, "POST", "/api/votes", replay, "")
+	if !strings.Contains(res.Error, "invalid nonce") {
+		t.Errorf("replayed vote: %s", res.Error)
+	}
+	e.expect(http.StatusOK, "POST", "/api/votes", e.signedVote(voter, second, 1), "")
+
+	var history []struct{ PollID uint64 }
+	decode(t, e.expect(http.StatusOK, "GET", "/api/voters/"+address+"/votes", nil, ""), &history)
+	if len(history) != 2 || history[0].PollID != first || history[1].PollID != second {
+		t.Errorf("history = %+v", history)
+	}
+}
+
+func TestPollListing(t *testing.T) {
+	e := newFakeEnv(t)
+	voter := e.newVoter(3)
+	create := func(title, description string, start, end time.Duration) uint64 {
+		var created struct{ PollID uint64 }
+		decode(t, e.expect(http.StatusCreated, "POST", "/api/polls", map[string]interface{}{
+			"title":       title,
+			"description": description,
+			"options":     []string{"Yes", "No"},
+			"startTime":   e.now.Add(start).Unix(),
+			"endTime":     e.now.Add(end).Unix(),
+		}, e.adminToken), &created)
+		return created.PollID
+	}
+	budget := create("Budget", "Approve the yearly budget", time.Hour, 3*time.Hour)
+	park := create("Park", "Build a new park", time.Hour, 2*time.Hour)
+	roads := create("Roads", "Fund the park roads", 5*time.Hour, 6*time.Hour)
+	canceled := create("Library", "Extend library hours", time.Hour, 2*time.Hour)
+	e.expect(http.StatusOK, "POST", fmt.Sprintf("/api/polls/%d/cancel", canceled), nil, e.adminToken)
+
+	e.now = e.now.Add(90 * time.Minute)
+	e.expect(http.StatusOK, "POST", "/api/votes", e.signedVote(voter, park, 0), "")
+
+	ids := func(query string) []uint64 {
+		t.Helper()
+		var page pollPage
+		decode(t, e.expect(http.StatusOK, "GET", "/api/polls"+query, nil, ""), &page)
+		got := []uint64{}
+		for _, p := range page.Polls {
+			got = append(got, p.ID)
+		}
+		return got
+	}
+	from := e.now.Add(4 * time.Hour).Unix()
+	tests := []struct {
+		query string
+		want  []uint64
+	}{
+		{"", []uint64{budget, park, roads, canceled}},
+		{"?status=active", []uint64{budget, park}},
+		{"?status=Pending,Canceled", []uint64{roads, canceled}},
+		{"?q=PARK", []uint64{park, roads}},
+		{"?q=park+new", []uint64{park}},
+		{fmt.Sprintf("?from=%d", from), []uint64{roads}},
+		{"?sort=totalVotes&order=desc", []uint64{park, canceled, roads, budget}},
+		{"?sort=endTime&order=desc&status=active", []uint64{budget, park}},
+	}
+	for _, tt := range tests {
+		if got := ids(tt.query); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%q: polls = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+
+	// Following the cursor walks every poll once, in order
+	var walked []uint64
+	query := "?sort=endTime&order=desc&limit=1"
+	for {
+		var page pollPage
+		decode(t, e.expect(http.StatusOK, "GET", "/api/polls"+query, nil, ""), &page)
+		if page.Total != 4 || len(page.Polls) != 1 {
+			t.Fatalf("%q: page = %+v", query, page)
+		}
+		walked = append(walked, page.Polls[0].ID)
+		if page.NextCursor == "" {
+			break
+		}
+		query = "?sort=endTime&order=desc&limit=1&cursor=" + page.NextCursor
+	}
+	if want := []uint64{roads, budget, canceled, park}; fmt.Sprint(walked) != fmt.Sprint(want) {
+		t.Errorf("walked %v, want %v", walked, want)
+	}
+
+	var page pollPage
+	decode(t, e.expect(http.StatusOK, "GET", "/api/polls?limit=2", nil, ""), &page)
+	e.expect(http.StatusBadRequest, "GET", "/api/polls?sort=endTime&cursor="+page.NextCursor, nil, "")
+	for _, query := range []string{"?status=open", "?from=x", "?from=2&to=1", "?sort=title", "?order=up", "?limit=0", "?cursor=!"} {
+		e.expect(http.StatusBadRequest, "GET", "/api/polls"+query, nil, "")
+	}
+	e.expect(http.StatusBadRequest, "GET", "/api/polls?creator=nobody", nil, "")
+	e.expect(http.StatusBadRequest, "GET", "/api/creators/nobody/polls", nil, "")
+
+	voterAddress := crypto.PubkeyToAddress(voter.PublicKey).Hex()
+	decode(t, e.expect(http.StatusOK, "GET", "/api/creators/"+voterAddress+"/polls", nil, ""), &page)
+	if page.Total != 0 {
+		t.Errorf("voter created %d polls, want 0", page.Total)
+	}
+}
+
+func TestBatchReadsReportFailures(t *testing.T) {
+	e := newFakeEnv(t)
+	voter := e.newVoter(5)
+	first := e.createPoll("First")
+	second := e.createPoll("Second")
+	e.now = e.now.Add(90 * time.Minute)
+	e.expect(http.StatusOK, "POST", "/api/votes", e.signedVote(voter, second, 1), "")
+
+	var results struct {
+		Results []struct {
+			PollID     uint64
+			TotalVotes uint64
+		}
+		Failed []struct{ PollID uint64 }
+	}
+	decode(t, e.expect(http.StatusOK, "GET", fmt.Sprintf("/api/results?polls=%d,%d,42", first, second), nil, ""), &results)
+	if len(results.Results) != 2 || results.Results[1].PollID != second || results.Results[1].TotalVotes != 5 {
+		t.Errorf("results = %+v", results.Results)
+	}
+	if len(results.Failed) != 1 || results.Failed[0].PollID != 42 {
+		t.Errorf("failed = %+v, want poll 42", results.Failed)
+	}
+
+	address := crypto.PubkeyToAddress(voter.PublicKey).Hex()
+	var statuses struct {
+		Statuses map[string]struct {
+			HasVoted    bool
+			VotingPower uint64
+		}
+		Failed []struct{ PollID uint64 }
+	}
+	decode(t, e.expect(http.StatusOK, "GET", fmt.Sprintf("/api/voters/%s/status?polls=%d,%d", address, first, second), nil, ""), &statuses)
+	if len(statuses.Failed) != 0 || statuses.Statuses[fmt.Sprint(first)].HasVoted || !statuses.Statuses[fmt.Sprint(second)].HasVoted {
+		t.Errorf("statuses = %+v", statuses)
+	}
+
+	for _, path := range []string{"/api/results", "/api/results?polls=1,x", "/api/voters/nobody/status?polls=1"} {
+		e.expect(http.StatusBadRequest, "GET", path, nil, "")
+	}
+}
+
+func TestHistoricalResults(t *testing.T) {
+	e := newFakeEnv(t)
+	first, second := e.newVoter(3), e.newVoter(2)
+	created := e.now
+	poll := e.createPoll("History")
+
+	e.now = created.Add(70 * time.Minute)
+	e.expect(http.StatusOK, "POST", "/api/votes", e.signedVote(first, poll, 0), "")
+	e.now = created.Add(100 * time.Minute)
+	e.expect(http.StatusOK, "POST", "/api/votes", e.signedVote(second, poll, 1), "")
+
+	resultsAt := func(at string) ([]uint64, *models.BlockRef) {
+		t.Helper()
+		var results struct{ VoteCounts []uint64 }
+		res := e.expect(http.StatusOK, "GET", fmt.Sprintf("/api/polls/%d/results?at=%s", poll, at), nil, "")
+		decode(t, res, &results)
+		return results.VoteCounts, res.Meta
+	}
+	counts, block := resultsAt(fmt.Sprint(created.Add(80 * time.Minute).Unix()))
+	if !reflect.DeepEqual(counts, []uint64{3, 0, 0}) || block == nil {
+		t.Errorf("counts after the first vote = %v in %+v", counts, block)
+	}
+	if counts, _ := resultsAt(created.Add(110 * time.Minute).Format(time.RFC3339)); !reflect.DeepEqual(counts, []uint64{3, 2, 0}) {
+		t.Errorf("counts after the second vote = %v", counts)
+	}
+	if counts, _ := resultsAt(fmt.Sprint(block.BlockNumber - 1)); !reflect.DeepEqual(counts, []uint64{0, 0, 0}) {
+		t.Errorf("counts before the first vote = %v", counts)
+	}
+
+	var series struct {
+		Points []struct {
+			Timestamp  int64
+			VoteCounts []uint64
+		}
+	}
+	decode(t, e.expect(http.StatusOK, "GET", fmt.Sprintf("/api/polls/%d/results/series?bucket=1800", poll), nil, ""), &series)
+	start := created.Add(time.Hour).Unix()
+	if len(series.Points) != 2 ||
+		series.Points[0].Timestamp != start+1800 || !reflect.DeepEqual(series.Points[0].VoteCounts, []uint64{3, 0, 0}) ||
+		series.Points[1].Timestamp != start+3600 || !reflect.DeepEqual(series.Points[1].VoteCounts, []uint64{3, 2, 0}) {
+		t.Errorf("series = %+v", series.Points)
+	}
+
+	e.expect(http.StatusNotFound, "GET", fmt.Sprintf("/api/polls/%d/results?at=1", poll), nil, "")
+	e.expect(http.StatusNotFound, "GET", fmt.Sprintf("/api/polls/%d/results?at=999", poll), nil, "")
+	for _, query := range []string{"results?at=soon", "results?at=1&block=1", "results/series?bucket=1", "results/series?from=x"} {
+		e.expect(http.StatusBadRequest, "GET", fmt.Sprintf("/api/polls/%d/%s", poll, query), nil, "")
+	}
+}
+
+func TestCachedReadsAndETags(t *testing.T) {
+	e := newFakeEnv(t)
+	voter := e.newVoter(4)
+	poll := e.createPoll("Cached")
+
+	// Writes go to the fake directly, so only events invalidate the cache
+	cached := service.NewCached(e.fake, cache.NewLRU(100))
+	reads := &testClient{t: t, router: SetupRouter(cached, nil, e.events, newTestAuth(t))}
+
+	var read *models.BlockRef
+	totalVotes := func() uint64 {
+		var results struct{ TotalVotes uint64 }
+		res := reads.expect(http.StatusOK, "GET", fmt.Sprintf("/api/polls/%d/results", poll), nil, "")
+		decode(t, res, &results)
+		read = res.Meta
+		return results.TotalVotes
+	}
+	totalVotes()
+	before := *read
+	if stats := cached.Stats(); stats.Hits != 0 || stats.Misses != 1 {
+		t.Fatalf("stats after first read = %+v", stats)
+	}
+
+	e.now = e.now.Add(90 * time.Minute)
+	e.expect(http.StatusOK, "POST", "/api/votes", e.signedVote(voter, poll, 0), "")
+	if got := totalVotes(); got != 0 {
+		t.Fatalf("total votes = %d before the vote event, want the cached 0", got)
+	}
+	if stats := cached.Stats(); stats.Hits != 1 {
+		t.Fatalf("stats after second read = %+v", stats)
+	}
+	if *read != before {
+		t.Errorf("cached results reported at %+v, want the block they were read at %+v", read, before)
+	}
+	cached.Apply(models.Event{Type: models.EventVoted, PollID: poll})
+	if got := totalVotes(); got != 4 || read.BlockNumber <= before.BlockNumber {
+		t.Fatalf("total votes = %d at %+v after the vote event, want 4 after block %d", got, read, before.BlockNumber)
+	}
+
+	var health struct{ Cache models.CacheStats }
+	decode(t, reads.expect(http.StatusOK, "GET", "/api/health", nil, ""), &health)
+	if health.Cache.Invalidations != 1 || health.Cache.Hits == 0 {
+		t.Errorf("health cache stats = %+v", health.Cache)
+	}
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/polls/%d", poll), nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		reads.router.ServeHTTP(w, req)
+		return w
+	}
+	first := get("")
+	tag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || !strings.HasPrefix(tag, `W/"`) {
+		t.Fatalf("got %d with ETag %q, want a weak tag", first.Code, tag)
+	}
+	if w := get(`"other", ` + tag); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("matching If-None-Match: got %d with %d bytes, want 304", w.Code, w.Body.Len())
+	}
+	if w := get(strings.TrimPrefix(tag, "W/")); w.Code != http.StatusNotModified {
+		t.Errorf("If-None-Match with the tag made strong: got %d, want 304", w.Code)
+	}
+
+	e.expect(http.StatusOK, "POST", fmt.Sprintf("/api/polls/%d/cancel", poll), nil, e.adminToken)
+	cached.Apply(models.Event{Type: models.EventPollCanceled, PollID: poll})
+	if w := get(tag); w.Code != http.StatusOK || w.Header().Get("ETag") == tag {
+		t.Errorf("after cancel: got %d with ETag %q, want a new tag", w.Code, w.Header().Get("ETag"))
+	}
+}
+
+// pinnedFake reads results at the block its latest reader was taken at, as
+// the chain services do, where the fake itself reads whatever is current
+type pinnedFake struct {
+	*service.Fake
+}
+
+func (f pinnedFake) At(block *big.Int) (service.Reader, models.BlockRef, error) {
+	r, ref, err := f.Fake.At(block)
+	if err != nil {
+		return nil, models.BlockRef{}, err
+	}
+	return pinnedReader{Reader: r, fake: f.Fake, block: new(big.Int).SetUint64(ref.BlockNumber)}, ref, nil
+}
+
+type pinnedReader struct {
+	service.Reader
+	fake  *service.Fake
+	block *big.Int
+}
+
+func (r pinnedReader) GetPollResults(pollID uint64) (*models.PollResults, error) {
+	results, _, err := r.fake.GetPollResultsAt(pollID, r.block)
+	return results, err
+}
+
+func TestCachedReadRacingInvalidation(t *testing.T) {
+	e := newFakeEnv(t)
+	voter := e.newVoter(4)
+	poll := e.createPoll("Raced")
+	cached := service.NewCached(pinnedFake{e.fake}, cache.NewLRU(100))
+
+	// The vote and its event land between pinning a block and reading it
+	r, _, err := cached.At(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.now = e.now.Add(90 * time.Minute)
+	e.expect(http.StatusOK, "POST", "/api/votes", e.signedVote(voter, poll, 0), "")
+	cached.Apply(models.Event{Type: models.EventVoted, PollID: poll})
+
+	stale, err := r.GetPollResults(poll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stale.TotalVotes != 0 {
+		t.Fatalf("pinned read has %d votes, want the 0 before the vote", stale.TotalVotes)
+	}
+	results, err := cached.GetPollResults(poll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results.TotalVotes != 4 {
+		t.Errorf("total votes = %d after the vote event, want 4", results.TotalVotes)
+	}
+}
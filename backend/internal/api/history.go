@@ -0,0 +1,207 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"voting-dapp/backend/internal/blockchain"
+	"voting-dapp/backend/internal/models"
+)
+
+// minTimestamp separates block numbers from unix timestamps in the at query
+// parameter; no chain is anywhere near a billion blocks
+const minTimestamp = 1_000_000_000
+
+// defaultBucket is the width of a results series bucket in seconds
+const defaultBucket = 3600
+
+// parseTime reads a unix timestamp or an RFC 3339 time
+func parseTime(v string) (int64, bool) {
+	if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return n, n >= 0
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return 0, false
+	}
+	return t.Unix(), true
+}
+
+// resolveAt returns the block the at query parameter names: a block number
+// below minTimestamp, or the last block mined by a unix timestamp or an
+// RFC 3339 time. It replies with 400 if at is invalid and 404 if no block
+// was mined by then.
+func (h *Handler) resolveAt(c *gin.Context) (*big.Int, bool) {
+	v := c.Query("at")
+	if n, err := strconv.ParseUint(v, 10, 64); err == nil && n < minTimestamp {
+		return new(big.Int).SetUint64(n), true
+	}
+	timestamp, ok := parseTime(v)
+	if !ok {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid at, want a block number, unix timestamp or RFC 3339 time",
+		})
+		return nil, false
+	}
+
+	block, err := h.voting.BlockAtTime(timestamp)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, blockchain.ErrUnknownBlock) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return nil, false
+	}
+	return block, true
+}
+
+// respondResultsAt replies with a poll's results as of the at query
+// parameter, reporting the block they were reconstructed at
+func (h *Handler) respondResultsAt(c *gin.Context, pollID uint64) {
+	if c.Query("block") != "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Set either at or block, not both",
+		})
+		return
+	}
+	block, ok := h.resolveAt(c)
+	if !ok {
+		return
+	}
+
+	results, ref, err := h.voting.GetPollResultsAt(pollID, block)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    results,
+		Meta:    &ref,
+	})
+}
+
+// getResultsSeries returns a poll's cumulative vote counts at the end of
+// each bucket of the bucket query parameter's width, in seconds, from the
+// poll's start to its end or between the from and to query parameters
+func (h *Handler) getResultsSeries(c *gin.Context) {
+	pollID := c.Param("id")
+	var id uint64
+	if _, err := fmt.Sscanf(pollID, "%d", &id); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid poll ID",
+		})
+		return
+	}
+
+	bucket := int64(defaultBucket)
+	if v := c.Query("bucket"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Success: false,
+				Error:   "Invalid bucket, want a number of seconds",
+			})
+			return
+		}
+		bucket = n
+	}
+
+	poll, err := h.voting.GetPoll(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	from, to := poll.StartTime, poll.EndTime
+	for param, bound := range map[string]*int64{"from": &from, "to": &to} {
+		if v := c.Query(param); v != "" {
+			t, ok := parseTime(v)
+			if !ok {
+				c.JSON(http.StatusBadRequest, models.ErrorResponse{
+					Success: false,
+					Error:   fmt.Sprintf("Invalid %s, want a unix timestamp or RFC 3339 time", param),
+				})
+				return
+			}
+			*bound = t
+		}
+	}
+	if from >= to || (to-from-1)/bucket+1 > maxPageLimit {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   fmt.Sprintf("from must be before to, at most %d buckets apart", maxPageLimit),
+		})
+		return
+	}
+
+	votes, err := h.voting.GetPollVotes(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    tallySeries(poll, votes, from, to, bucket),
+	})
+}
+
+// tallySeries accumulates votes into the running tally at the end of each
+// bucket between from and to. The last bucket ends at to.
+func tallySeries(poll *models.Poll, votes []*models.Vote, from, to, bucket int64) *models.ResultsSeries {
+	sort.SliceStable(votes, func(i, j int) bool { return votes[i].Timestamp < votes[j].Timestamp })
+
+	series := &models.ResultsSeries{
+		PollID:  poll.ID,
+		Options: poll.Options,
+		Bucket:  bucket,
+		Points:  []*models.ResultsPoint{},
+	}
+	counts := make([]uint64, len(poll.Options))
+	var total uint64
+	next := 0
+	for end := from; end < to; {
+		if to-end > bucket {
+			end += bucket
+		} else {
+			end = to
+		}
+		for ; next < len(votes) && votes[next].Timestamp <= end; next++ {
+			if v := votes[next]; v.OptionIndex < uint64(len(counts)) {
+				counts[v.OptionIndex] += v.Weight
+				total += v.Weight
+			}
+		}
+		series.Points = append(series.Points, &models.ResultsPoint{
+			Timestamp:  end,
+			VoteCounts: append([]uint64(nil), counts...),
+			TotalVotes: total,
+		})
+	}
+	return series
+}
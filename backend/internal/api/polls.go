@@ -0,0 +1,249 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"voting-dapp/backend/internal/models"
+	"voting-dapp/backend/internal/service"
+)
+
+// pollStatuses are the statuses GetPollStatus reports
+var pollStatuses = []string{
+	models.PollStatusPending,
+	models.PollStatusActive,
+	models.PollStatusEnded,
+	models.PollStatusCanceled,
+	models.PollStatusInactive,
+}
+
+// pollSortKeys maps the sort query parameter to the value polls are ordered
+// by. Ties are broken by poll ID.
+var pollSortKeys = map[string]func(*models.Poll) int64{
+	"id":         func(p *models.Poll) int64 { return int64(p.ID) },
+	"startTime":  func(p *models.Poll) int64 { return p.StartTime },
+	"endTime":    func(p *models.Poll) int64 { return p.EndTime },
+	"totalVotes": func(p *models.Poll) int64 { return int64(p.TotalVotes) },
+}
+
+// pollQuery filters, orders and pages a poll listing
+type pollQuery struct {
+	// statuses holds the statuses to keep; empty keeps every status
+	statuses map[string]bool
+	// from and to bound the time window a poll must overlap
+	from, to int64
+	// terms must each appear in the title or description
+	terms []string
+	sort  string
+	desc  bool
+	limit int
+	// after is the position of the last poll on the previous page
+	after *pollCursor
+}
+
+// pollCursor is a position in a poll listing: the sort key and ID of a poll
+type pollCursor struct {
+	key int64
+	id  uint64
+}
+
+// parsePollQuery reads the poll listing query parameters
+func parsePollQuery(c *gin.Context) (*pollQuery, error) {
+	q := &pollQuery{
+		statuses: make(map[string]bool),
+		from:     math.MinInt64,
+		to:       math.MaxInt64,
+		terms:    strings.Fields(strings.ToLower(c.Query("q"))),
+		sort:     c.DefaultQuery("sort", "id"),
+	}
+
+	if v := c.Query("status"); v != "" {
+		for _, name := range strings.Split(v, ",") {
+			status, ok := canonicalStatus(strings.TrimSpace(name))
+			if !ok {
+				return nil, fmt.Errorf("Invalid status %q, want one of %s", name, strings.Join(pollStatuses, ", "))
+			}
+			q.statuses[status] = true
+		}
+	}
+
+	for param, bound := range map[string]*int64{"from": &q.from, "to": &q.to} {
+		if v := c.Query(param); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid %s timestamp", param)
+			}
+			*bound = n
+		}
+	}
+	if q.from > q.to {
+		return nil, errors.New("from must not be after to")
+	}
+
+	if _, ok := pollSortKeys[q.sort]; !ok {
+		return nil, fmt.Errorf("Invalid sort %q, want id, startTime, endTime or totalVotes", q.sort)
+	}
+	switch c.DefaultQuery("order", "asc") {
+	case "asc":
+	case "desc":
+		q.desc = true
+	default:
+		return nil, errors.New("Invalid order, want asc or desc")
+	}
+
+	limit, ok := parseLimit(c)
+	if !ok {
+		return nil, errors.New("Invalid pagination parameters")
+	}
+	q.limit = limit
+
+	if v := c.Query("cursor"); v != "" {
+		after, err := q.decodeCursor(v)
+		if err != nil {
+			return nil, err
+		}
+		q.after = after
+	}
+	return q, nil
+}
+
+// canonicalStatus returns the status name matching s regardless of case
+func canonicalStatus(s string) (string, bool) {
+	for _, status := range pollStatuses {
+		if strings.EqualFold(s, status) {
+			return status, true
+		}
+	}
+	return "", false
+}
+
+// matches reports whether a poll passes the time window and text filters
+func (q *pollQuery) matches(p *models.Poll) bool {
+	if p.EndTime < q.from || p.StartTime > q.to {
+		return false
+	}
+	text := strings.ToLower(p.Title + "\n" + p.Description)
+	for _, term := range q.terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// before reports whether a poll at position a is listed before one at b
+func (q *pollQuery) before(a, b pollCursor) bool {
+	if q.desc {
+		a, b = b, a
+	}
+	if a.key != b.key {
+		return a.key < b.key
+	}
+	return a.id < b.id
+}
+
+// position returns the place of a poll in the listing order
+func (q *pollQuery) position(p *models.Poll) pollCursor {
+	return pollCursor{key: pollSortKeys[q.sort](p), id: p.ID}
+}
+
+// encodeCursor returns an opaque cursor for the position after p. It records
+// the ordering so that it cannot be replayed against a different one.
+func (q *pollQuery) encodeCursor(p *models.Poll) string {
+	pos := q.position(p)
+	raw := fmt.Sprintf("%s:%t:%d:%d", q.sort, q.desc, pos.key, pos.id)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor parses a cursor made by encodeCursor for the same ordering
+func (q *pollQuery) decodeCursor(cursor string) (*pollCursor, error) {
+	invalid := errors.New("Invalid cursor")
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, invalid
+	}
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 4 {
+		return nil, invalid
+	}
+	if parts[0] != q.sort || parts[1] != strconv.FormatBool(q.desc) {
+		return nil, errors.New("Cursor was issued for a different sort order")
+	}
+	key, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, invalid
+	}
+	id, err := strconv.ParseUint(parts[3], 10, 64)
+	if err != nil {
+		return nil, invalid
+	}
+	return &pollCursor{key: key, id: id}, nil
+}
+
+// respondPollPage filters and orders polls read by reader at block by query
+// and replies with the page after the query's cursor, listing the polls
+// that could not be read
+func (h *Handler) respondPollPage(c *gin.Context, reader service.Reader, block *models.BlockRef, polls []*models.Poll, failed []models.ReadFailure, query *pollQuery) {
+	// Status depends on the chain's clock, so derive it at the time of the
+	// block the polls were read at
+	var now int64
+	if len(query.statuses) > 0 {
+		var err error
+		if now, err = service.BlockTime(reader); err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+			})
+			return
+		}
+	}
+
+	matched := make([]*models.Poll, 0, len(polls))
+	for _, p := range polls {
+		if !query.matches(p) {
+			continue
+		}
+		if len(query.statuses) > 0 && !query.statuses[p.StatusAt(now)] {
+			continue
+		}
+		matched = append(matched, p)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return query.before(query.position(matched[i]), query.position(matched[j]))
+	})
+
+	start := 0
+	if query.after != nil {
+		start = sort.Search(len(matched), func(i int) bool {
+			return query.before(*query.after, query.position(matched[i]))
+		})
+	}
+	end := start + query.limit
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	page := &models.PollPage{
+		Polls:  matched[start:end],
+		Total:  len(matched),
+		Limit:  query.limit,
+		Failed: failed,
+	}
+	if end < len(matched) {
+		page.NextCursor = query.encodeCursor(matched[end-1])
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    page,
+		Meta:    readBlock(reader, block),
+	})
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+
+	"voting-dapp/backend/internal/blockchain"
+	"voting-dapp/backend/internal/jobs"
+	"voting-dapp/backend/internal/models"
+)
+
+// getRoles returns the contract roles an address holds
+func (h *Handler) getRoles(c *gin.Context) {
+	address := c.Param("address")
+	if !common.IsHexAddress(address) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid address",
+		})
+		return
+	}
+
+	roles, err := h.voting.GetRoles(address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    roles,
+	})
+}
+
+// transferAdmin hands the contract admin role to another account. The
+// backend's own account stops being the admin once the transfer is mined.
+func (h *Handler) transferAdmin(c *gin.Context) {
+	var req models.TransferAdminRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if !common.IsHexAddress(req.NewAdmin) || common.HexToAddress(req.NewAdmin) == (common.Address{}) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid admin address",
+		})
+		return
+	}
+
+	if wantsAsync(c) {
+		tx, err := h.voting.TransferAdminTx(req.NewAdmin)
+		h.respondAccepted(c, jobs.ActionTransferAdmin, tx, err)
+		return
+	}
+
+	if err := h.voting.TransferAdmin(req.NewAdmin); err != nil {
+		c.JSON(writeErrorStatus(err), models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Admin rights transferred successfully"},
+	})
+}
+
+// grantRole grants a role to an account
+func (h *Handler) grantRole(c *gin.Context) {
+	req, ok := bindRoleRequest(c)
+	if !ok {
+		return
+	}
+
+	if wantsAsync(c) {
+		tx, err := h.voting.GrantRoleTx(req.Role, req.Address)
+		h.respondAccepted(c, jobs.ActionGrantRole, tx, err)
+		return
+	}
+
+	if err := h.voting.GrantRole(req.Role, req.Address); err != nil {
+		c.JSON(writeErrorStatus(err), models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Role granted successfully"},
+	})
+}
+
+// revokeRole revokes a role from an account
+func (h *Handler) revokeRole(c *gin.Context) {
+	req, ok := bindRoleRequest(c)
+	if !ok {
+		return
+	}
+
+	if wantsAsync(c) {
+		tx, err := h.voting.RevokeRoleTx(req.Role, req.Address)
+		h.respondAccepted(c, jobs.ActionRevokeRole, tx, err)
+		return
+	}
+
+	if err := h.voting.RevokeRole(req.Role, req.Address); err != nil {
+		c.JSON(writeErrorStatus(err), models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Role revoked successfully"},
+	})
+}
+
+// bindRoleRequest reads a role grant or revocation, replying 400 if the
+// address or role is invalid. The admin role moves with /api/admin/transfer
+// instead.
+func bindRoleRequest(c *gin.Context) (models.RoleRequest, bool) {
+	var req models.RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return req, false
+	}
+
+	if !common.IsHexAddress(req.Address) || common.HexToAddress(req.Address) == (common.Address{}) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid account address",
+		})
+		return req, false
+	}
+	if !blockchain.IsGrantableRole(req.Role) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Role must be " + blockchain.RolePollManager + " or " + blockchain.RolePowerAssigner,
+		})
+		return req, false
+	}
+	return req, true
+}
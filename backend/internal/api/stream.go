@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"voting-dapp/backend/internal/models"
+)
+
+// streamRetry is the reconnection delay suggested to EventSource clients
+const streamRetry = 3 * time.Second
+
+// streamEvents streams the events of every poll
+func (h *Handler) streamEvents(c *gin.Context) {
+	h.serveEvents(c, 0)
+}
+
+// streamPollEvents streams the events of a single poll
+func (h *Handler) streamPollEvents(c *gin.Context) {
+	pollID := c.Param("id")
+	var id uint64
+	if _, err := fmt.Sscanf(pollID, "%d", &id); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid poll ID",
+		})
+		return
+	}
+
+	if _, err := h.voting.GetPoll(id); err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	h.serveEvents(c, id)
+}
+
+// serveEvents writes events for pollID, or every poll when it is 0, as
+// Server-Sent Events until the client disconnects. Events after the
+// Last-Event-ID header, or the lastEventId query parameter, are replayed first.
+func (h *Handler) serveEvents(c *gin.Context, pollID uint64) {
+	if h.events == nil {
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Success: false,
+			Error:   "Event streaming is not enabled",
+		})
+		return
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("lastEventId")
+	}
+	sub, replay := h.events.Subscribe(pollID, lastEventID)
+	defer sub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetry.Milliseconds())
+	for _, e := range replay {
+		if err := writeEvent(c, e); err != nil {
+			return
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.events.Heartbeat())
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case e, ok := <-sub.Events:
+			if !ok {
+				// Dropped for falling behind; the client resumes from its last ID
+				return
+			}
+			if err := writeEvent(c, e); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+// writeEvent writes a single Server-Sent Event
+func writeEvent(c *gin.Context, e models.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"voting-dapp/backend/internal/models"
+)
+
+// sseMessage is one event, or a comment such as a heartbeat, read from a
+// stream
+type sseMessage struct {
+	ID      string
+	Event   string
+	Data    models.Event
+	Comment string
+}
+
+// openStream connects to an event stream and returns the messages it
+// delivers. The connection is closed when the test ends.
+func (e *testClient) openStream(path, lastEventID string) <-chan sseMessage {
+	e.t.Helper()
+
+	server := httptest.NewServer(e.router)
+	ctx, cancel := context.WithCancel(context.Background())
+	e.t.Cleanup(func() {
+		cancel()
+		server.Close()
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "GET", server.URL+path, nil)
+	if err != nil {
+		e.t.Fatal(err)
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		e.t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		e.t.Fatalf("GET %s: got %d, want 200", path, resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		e.t.Errorf("GET %s: Content-Type = %q", path, ct)
+	}
+
+	messages := make(chan sseMessage, 64)
+	go func() {
+		defer resp.Body.Close()
+		defer close(messages)
+
+		var msg sseMessage
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if msg != (sseMessage{}) {
+					messages <- msg
+				}
+				msg = sseMessage{}
+			case strings.HasPrefix(line, ":"):
+				msg.Comment = strings.TrimSpace(line[1:])
+			case strings.HasPrefix(line, "id: "):
+				msg.ID = line[4:]
+			case strings.HasPrefix(line, "event: "):
+				msg.Event = line[7:]
+			case strings.HasPrefix(line, "data: "):
+				json.Unmarshal([]byte(line[6:]), &msg.Data)
+			}
+		}
+	}()
+	return messages
+}
+
+// waitForEvent reads messages until one matches, failing after a timeout
+func waitForEvent(t *testing.T, messages <-chan sseMessage, desc string, match func(sseMessage) bool) sseMessage {
+	t.Helper()
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case msg, ok := <-messages:
+			if !ok {
+				t.Fatalf("stream closed while waiting for %s", desc)
+			}
+			if match(msg) {
+				return msg
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for %s", desc)
+		}
+	}
+}
+
+func isStatus(pollID uint64, status string) func(sseMessage) bool {
+	return func(msg sseMessage) bool {
+		data, _ := msg.Data.Data.(map[string]interface{})
+		return msg.Event == models.EventStatus && msg.Data.PollID == pollID && data["status"] == status
+	}
+}
+
+func TestPollStreamPushesChainEvents(t *testing.T) {
+	e := newTestEnv(t)
+	voter := e.sim.Voters[0]
+	id := e.createPoll("Streamed")
+
+	pollEvents := e.openStream(fmt.Sprintf("/api/polls/%d/stream", id), "")
+	allEvents := e.openStream("/api/events", "")
+
+	e.advanceTime(90 * time.Minute)
+	waitForEvent(t, pollEvents, "Active status", isStatus(id, "Active"))
+
+	e.expect(http.StatusOK, "POST", "/api/votes", e.signedVote(voter.Key, id, 1), "")
+	msg := waitForEvent(t, pollEvents, "vote", func(msg sseMessage) bool { return msg.Event == models.EventVoted })
+	data, _ := msg.Data.Data.(map[string]interface{})
+	if data["voter"] != voter.Address.Hex() || data["optionIndex"] != float64(1) || data["weight"] != float64(testVotingPower) {
+		t.Errorf("vote event data = %v", data)
+	}
+	if msg.ID == "" || msg.ID != msg.Data.ID || msg.Data.TxHash == "" {
+		t.Errorf("vote event id %q, data %+v", msg.ID, msg.Data)
+	}
+
+	e.expect(http.StatusOK, "POST", fmt.Sprintf("/api/polls/%d/deactivate", id), nil, e.adminToken)
+	waitForEvent(t, pollEvents, "deactivation", func(msg sseMessage) bool { return msg.Event == models.EventPollDeactivated })
+	waitForEvent(t, pollEvents, "Inactive status", isStatus(id, "Inactive"))
+
+	// Other polls appear on the global stream only
+	other := e.createPoll("Other")
+	waitForEvent(t, allEvents, "poll creation", func(msg sseMessage) bool {
+		return msg.Event == models.EventPollCreated && msg.Data.PollID == other
+	})
+	waitForEvent(t, allEvents, "Pending status", isStatus(other, "Pending"))
+
+	e.expect(http.StatusOK, "POST", fmt.Sprintf("/api/polls/%d/cancel", id), nil, e.adminToken)
+	msg = waitForEvent(t, pollEvents, "cancellation", func(msg sseMessage) bool {
+		if msg.ID != "" && msg.Data.PollID != id {
+			t.Errorf("poll %d stream received %s for poll %d", id, msg.Event, msg.Data.PollID)
+		}
+		return msg.Event == models.EventPollCanceled
+	})
+	waitForEvent(t, pollEvents, "Canceled status", isStatus(id, "Canceled"))
+
+	// Reconnecting after the cancellation replays only what followed it
+	resumed := e.openStream(fmt.Sprintf("/api/polls/%d/stream", id), msg.ID)
+	waitForEvent(t, resumed, "replayed Canceled status", func(m sseMessage) bool {
+		if m.Event == models.EventPollCanceled {
+			t.Errorf("replayed the event resumed from")
+		}
+		return isStatus(id, "Canceled")(m)
+	})
+}
+
+func TestStreamResumesAndSendsHeartbeats(t *testing.T) {
+	e := newFakeEnv(t)
+	id := e.createPoll("Resume")
+
+	e.events.Publish(
+		models.Event{Type: models.EventVoted, PollID: id, BlockNumber: 3},
+		models.Event{Type: models.EventVoted, PollID: id + 1, BlockNumber: 4},
+		models.Event{Type: models.EventStatus, PollID: id, BlockNumber: 5, Index: 2},
+	)
+
+	messages := e.openStream(fmt.Sprintf("/api/polls/%d/stream", id), "3-0")
+	msg := waitForEvent(t, messages, "replay", func(msg sseMessage) bool { return msg.ID != "" })
+	if msg.ID != "5-2" || msg.Event != models.EventStatus {
+		t.Fatalf("replayed %s %s, want 5-2 status", msg.ID, msg.Event)
+	}
+	waitForEvent(t, messages, "heartbeat", func(msg sseMessage) bool { return msg.Comment == "heartbeat" })
+
+	e.events.Publish(models.Event{Type: models.EventPollCanceled, PollID: id, BlockNumber: 6})
+	msg = waitForEvent(t, messages, "live event", func(msg sseMessage) bool { return msg.ID != "" })
+	if msg.ID != "6-0" || msg.Event != models.EventPollCanceled {
+		t.Fatalf("received %s %s, want 6-0 pollCanceled", msg.ID, msg.Event)
+	}
+
+	e.expect(http.StatusNotFound, "GET", "/api/polls/42/stream", nil, "")
+	e.expect(http.StatusBadRequest, "GET", "/api/polls/abc/stream", nil, "")
+}
+
+func TestStreamDisabledWithoutBroker(t *testing.T) {
+	e := newFakeEnv(t)
+	e.router = SetupRouter(e.fake, nil, nil, newTestAuth(t))
+
+	e.expect(http.StatusServiceUnavailable, "GET", "/api/events", nil, "")
+}
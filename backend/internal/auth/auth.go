@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// nonceTTL is how long a sign-in nonce can be redeemed
+	nonceTTL = 5 * time.Minute
+	// maxPendingNonces bounds the number of unredeemed nonces held in memory
+	maxPendingNonces = 10000
+)
+
+// ErrTooManyNonces is returned when too many sign-in attempts are pending
+var ErrTooManyNonces = errors.New("too many pending sign-in requests")
+
+// Config configures a Service
+type Config struct {
+	// Domain is the host the SIWE message must be issued for
+	Domain string
+	// ChainID is the chain the SIWE message must be issued for
+	ChainID uint64
+	// Secret signs session tokens
+	Secret []byte
+	// TokenTTL is how long a session token is valid
+	TokenTTL time.Duration
+}
+
+// Session is an authenticated session
+type Session struct {
+	Token     string    `json:"token"`
+	Address   string    `json:"address"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Service issues sign-in nonces, verifies SIWE messages and issues and
+// checks session tokens. It is safe for concurrent use.
+type Service struct {
+	cfg Config
+
+	mu     sync.Mutex
+	nonces map[string]time.Time
+}
+
+// NewService creates an authentication service
+func NewService(cfg Config) (*Service, error) {
+	if cfg.Domain == "" {
+		return nil, fmt.Errorf("auth domain is required")
+	}
+	if cfg.ChainID == 0 {
+		return nil, fmt.Errorf("auth chain ID is required")
+	}
+	if len(cfg.Secret) < 32 {
+		return nil, fmt.Errorf("auth secret must be at least 32 bytes")
+	}
+	if cfg.TokenTTL == 0 {
+		cfg.TokenTTL = time.Hour
+	}
+	return &Service{cfg: cfg, nonces: make(map[string]time.Time)}, nil
+}
+
+// NewNonce returns a single-use nonce to embed in a SIWE message
+func (s *Service) NewNonce() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	nonce := hex.EncodeToString(buf)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for n, expiry := range s.nonces {
+		if now.After(expiry) {
+			delete(s.nonces, n)
+		}
+	}
+	if len(s.nonces) >= maxPendingNonces {
+		return "", ErrTooManyNonces
+	}
+	s.nonces[nonce] = now.Add(nonceTTL)
+	return nonce, nil
+}
+
+// consumeNonce redeems a nonce, reporting whether it was valid
+func (s *Service) consumeNonce(nonce string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiry, ok := s.nonces[nonce]
+	if !ok {
+		return false
+	}
+	delete(s.nonces, nonce)
+	return time.Now().Before(expiry)
+}
+
+// Verify checks a signed SIWE message and starts a session for its signer
+func (s *Service) Verify(message, signature string) (*Session, error) {
+	msg, err := ParseMessage(message)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message: %v", err)
+	}
+
+	if msg.Domain != s.cfg.Domain {
+		return nil, fmt.Errorf("message is for domain %s", msg.Domain)
+	}
+	if msg.ChainID != s.cfg.ChainID {
+		return nil, fmt.Errorf("message is for chain %d", msg.ChainID)
+	}
+
+	now := time.Now()
+	if msg.ExpirationTime != nil && now.After(*msg.ExpirationTime) {
+		return nil, fmt.Errorf("message has expired")
+	}
+	if msg.NotBefore != nil && now.Before(*msg.NotBefore) {
+		return nil, fmt.Errorf("message is not yet valid")
+	}
+
+	signer, err := RecoverSigner(message, signature)
+	if err != nil {
+		return nil, err
+	}
+	if signer != msg.Address {
+		return nil, fmt.Errorf("signature does not match address %s", msg.Address.Hex())
+	}
+
+	// Redeem the nonce last so a malformed request cannot burn it
+	if !s.consumeNonce(msg.Nonce) {
+		return nil, fmt.Errorf("unknown or expired nonce")
+	}
+
+	expiresAt := now.Add(s.cfg.TokenTTL)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Issuer:    s.cfg.Domain,
+		Subject:   msg.Address.Hex(),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	})
+	signed, err := token.SignedString(s.cfg.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign session token: %v", err)
+	}
+
+	return &Session{Token: signed, Address: msg.Address.Hex(), ExpiresAt: expiresAt}, nil
+}
+
+// Authenticate validates a session token and returns the signed-in address
+func (s *Service) Authenticate(token string) (common.Address, error) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return s.cfg.Secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid session token: %v", err)
+	}
+	if !claims.VerifyIssuer(s.cfg.Domain, true) || !common.IsHexAddress(claims.Subject) {
+		return common.Address{}, fmt.Errorf("invalid session token")
+	}
+	return common.HexToAddress(claims.Subject), nil
+}
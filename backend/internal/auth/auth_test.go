@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var testSecret = bytes.Repeat([]byte{7}, 32)
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+	s, err := NewService(Config{Domain: "example.com", ChainID: 1337, Secret: testSecret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// signIn builds a message for key's account with a fresh nonce and the
+// given fields replaced, and signs it
+func signIn(t *testing.T, s *Service, key *ecdsa.PrivateKey, domain string, fields map[string]string) (message, signature string) {
+	t.Helper()
+	nonce, err := s.NewNonce()
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := map[string]string{"Nonce": nonce}
+	for k, v := range fields {
+		values[k] = v
+	}
+	message = siweMessage(domain, crypto.PubkeyToAddress(key.PublicKey).Hex(), values)
+
+	sig, err := crypto.Sign(accounts.TextHash([]byte(message)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return message, hexutil.Encode(sig)
+}
+
+func TestNewServiceRequiresChainID(t *testing.T) {
+	if _, err := NewService(Config{Domain: "example.com", Secret: testSecret}); err == nil {
+		t.Error("service without a chain ID accepted")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	s := newTestService(t)
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	message, signature := signIn(t, s, key, "example.com", nil)
+	session, err := s.Verify(message, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session.Address != address.Hex() {
+		t.Errorf("session address = %s, want %s", session.Address, address.Hex())
+	}
+	if got, err := s.Authenticate(session.Token); err != nil || got != address {
+		t.Errorf("authenticated %s, %v, want %s", got.Hex(), err, address.Hex())
+	}
+
+	if _, err := s.Verify(message, signature); err == nil || !strings.Contains(err.Error(), "nonce") {
+		t.Errorf("replayed message: got %v, want a nonce error", err)
+	}
+}
+
+func TestVerifyErrors(t *testing.T) {
+	s := newTestService(t)
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	past := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	cases := map[string]struct {
+		domain string
+		fields map[string]string
+		want   string
+	}{
+		"wrong domain":    {"evil.example", nil, "domain evil.example"},
+		"wrong chain":     {"example.com", map[string]string{"Chain ID": "1"}, "chain 1"},
+		"expired":         {"example.com", map[string]string{"Expiration Time": past}, "expired"},
+		"not yet valid":   {"example.com", map[string]string{"Not Before": future}, "not yet valid"},
+		"unissued nonce":  {"example.com", map[string]string{"Nonce": "fedcba9876543210"}, "unknown or expired nonce"},
+		"invalid message": {"example.com", map[string]string{"Version": "0"}, "invalid message"},
+	}
+	for name, tc := range cases {
+		message, signature := signIn(t, s, key, tc.domain, tc.fields)
+		if _, err := s.Verify(message, signature); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: got %v, want error containing %q", name, err, tc.want)
+		}
+	}
+
+	// A message changed after signing recovers to another account
+	message, signature := signIn(t, s, key, "example.com", nil)
+	tampered := strings.Replace(message, "Sign in", "Sign over", 1)
+	if _, err := s.Verify(tampered, signature); err == nil || !strings.Contains(err.Error(), "signature does not match") {
+		t.Errorf("tampered message: got %v", err)
+	}
+	sig := hexutil.MustDecode(signature)
+	sig[10] ^= 1
+	if _, err := s.Verify(message, hexutil.Encode(sig)); err == nil {
+		t.Error("tampered signature accepted")
+	}
+
+	// Failed attempts do not burn the nonce
+	if _, err := s.Verify(message, signature); err != nil {
+		t.Errorf("valid message after failed attempts: %v", err)
+	}
+}
+
+func TestAuthenticateErrors(t *testing.T) {
+	s := newTestService(t)
+	address := "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	token := func(secret []byte, claims jwt.RegisteredClaims) string {
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+	now := time.Now()
+	valid := jwt.RegisteredClaims{
+		Issuer:    "example.com",
+		Subject:   address,
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+	if _, err := s.Authenticate(token(testSecret, valid)); err != nil {
+		t.Fatalf("valid token: %v", err)
+	}
+
+	expired := valid
+	expired.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Minute))
+	otherIssuer := valid
+	otherIssuer.Issuer = "evil.example"
+	noSubject := valid
+	noSubject.Subject = "admin"
+
+	cases := map[string]string{
+		"expired":      token(testSecret, expired),
+		"other secret": token(bytes.Repeat([]byte{8}, 32), valid),
+		"other issuer": token(testSecret, otherIssuer),
+		"no subject":   token(testSecret, noSubject),
+		"garbage":      "not.a.token",
+	}
+	for name, tok := range cases {
+		if _, err := s.Authenticate(tok); err == nil {
+			t.Errorf("%s token accepted", name)
+		}
+	}
+
+	// Sessions end after the configured lifetime
+	short, err := NewService(Config{Domain: "example.com", ChainID: 1337, Secret: testSecret, TokenTTL: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := short.Verify(signIn(t, short, key, "example.com", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(2 * time.Second)
+	if _, err := short.Authenticate(session.Token); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("session past its lifetime: got %v, want expired", err)
+	}
+}
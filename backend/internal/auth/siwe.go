@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const siweHeaderSuffix = " wants you to sign in with your Ethereum account:"
+
+// Message is a parsed EIP-4361 (Sign-In with Ethereum) message
+type Message struct {
+	Domain         string
+	Address        common.Address
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        uint64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// ParseMessage parses the text of an EIP-4361 message
+func ParseMessage(text string) (*Message, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if len(lines) < 2 || !strings.HasSuffix(lines[0], siweHeaderSuffix) {
+		return nil, fmt.Errorf("invalid message header")
+	}
+
+	msg := &Message{Domain: strings.TrimSuffix(lines[0], siweHeaderSuffix)}
+	if msg.Domain == "" {
+		return nil, fmt.Errorf("missing domain")
+	}
+
+	address := strings.TrimSpace(lines[1])
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid address %q", address)
+	}
+	msg.Address = common.HexToAddress(address)
+	if msg.Address.Hex() != address {
+		return nil, fmt.Errorf("address must be EIP-55 checksummed")
+	}
+
+	var (
+		err         error
+		inFields    bool
+		inResources bool
+		issuedAt    string
+	)
+	for _, line := range lines[2:] {
+		if line == "" {
+			continue
+		}
+		if inResources {
+			if !strings.HasPrefix(line, "- ") {
+				return nil, fmt.Errorf("unexpected line after resources: %q", line)
+			}
+			msg.Resources = append(msg.Resources, strings.TrimPrefix(line, "- "))
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ": ")
+		if line == "Resources:" {
+			key, ok = "Resources", true
+		}
+		if !ok || !isSIWEField(key) {
+			if inFields {
+				return nil, fmt.Errorf("unexpected line %q", line)
+			}
+			if msg.Statement != "" {
+				return nil, fmt.Errorf("statement must be a single line")
+			}
+			msg.Statement = line
+			continue
+		}
+		inFields = true
+
+		switch key {
+		case "URI":
+			msg.URI = value
+		case "Version":
+			msg.Version = value
+		case "Chain ID":
+			if msg.ChainID, err = strconv.ParseUint(value, 10, 64); err != nil {
+				return nil, fmt.Errorf("invalid chain ID %q", value)
+			}
+		case "Nonce":
+			msg.Nonce = value
+		case "Issued At":
+			issuedAt = value
+			if msg.IssuedAt, err = time.Parse(time.RFC3339, value); err != nil {
+				return nil, fmt.Errorf("invalid issued at time %q", value)
+			}
+		case "Expiration Time":
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid expiration time %q", value)
+			}
+			msg.ExpirationTime = &t
+		case "Not Before":
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid not before time %q", value)
+			}
+			msg.NotBefore = &t
+		case "Request ID":
+			msg.RequestID = value
+		case "Resources":
+			inResources = true
+		}
+	}
+
+	switch {
+	case msg.URI == "":
+		return nil, fmt.Errorf("missing URI")
+	case msg.Version != "1":
+		return nil, fmt.Errorf("unsupported version %q", msg.Version)
+	case msg.ChainID == 0:
+		return nil, fmt.Errorf("missing chain ID")
+	case len(msg.Nonce) < 8:
+		return nil, fmt.Errorf("missing or short nonce")
+	case issuedAt == "":
+		return nil, fmt.Errorf("missing issued at time")
+	}
+	return msg, nil
+}
+
+func isSIWEField(key string) bool {
+	switch key {
+	case "URI", "Version", "Chain ID", "Nonce", "Issued At", "Expiration Time", "Not Before", "Request ID", "Resources":
+		return true
+	}
+	return false
+}
+
+// RecoverSigner returns the address that produced an EIP-191 personal_sign
+// signature over text
+func RecoverSigner(text, signature string) (common.Address, error) {
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid signature encoding: %v", err)
+	}
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("invalid signature length %d", len(sig))
+	}
+	// Wallets return V as 27 or 28, SigToPub expects 0 or 1
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte(text)), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid signature: %v", err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
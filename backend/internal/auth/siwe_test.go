@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// siweMessage returns a SIWE message for address with the given fields
+// replaced, as "Key: value" lines
+func siweMessage(domain, address string, fields map[string]string) string {
+	values := map[string]string{
+		"URI":       "http://" + domain,
+		"Version":   "1",
+		"Chain ID":  "1337",
+		"Nonce":     "0123456789abcdef",
+		"Issued At": time.Now().UTC().Format(time.RFC3339),
+	}
+	for k, v := range fields {
+		values[k] = v
+	}
+
+	var b strings.Builder
+	b.WriteString(domain + siweHeaderSuffix + "\n" + address + "\n\nSign in to the voting API.\n\n")
+	for _, key := range []string{"URI", "Version", "Chain ID", "Nonce", "Issued At", "Expiration Time", "Not Before", "Request ID"} {
+		if v, ok := values[key]; ok && v != "" {
+			b.WriteString(key + ": " + v + "\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+const testAddress = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+
+func TestParseMessage(t *testing.T) {
+	text := siweMessage("example.com", testAddress, map[string]string{
+		"Expiration Time": "2030-01-01T00:00:00Z",
+		"Request ID":      "42",
+	}) + "\nResources:\n- https://example.com/a\n- https://example.com/b"
+
+	msg, err := ParseMessage(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Domain != "example.com" || msg.Address.Hex() != testAddress || msg.ChainID != 1337 ||
+		msg.Nonce != "0123456789abcdef" || msg.Statement != "Sign in to the voting API." || msg.RequestID != "42" {
+		t.Errorf("parsed %+v", msg)
+	}
+	if msg.ExpirationTime == nil || msg.ExpirationTime.Year() != 2030 {
+		t.Errorf("expiration time = %v", msg.ExpirationTime)
+	}
+	if len(msg.Resources) != 2 || msg.Resources[1] != "https://example.com/b" {
+		t.Errorf("resources = %v", msg.Resources)
+	}
+}
+
+func TestParseMessageErrors(t *testing.T) {
+	cases := map[string]struct {
+		text string
+		want string
+	}{
+		"header":         {"example.com wants you to sign in\n" + testAddress, "invalid message header"},
+		"no domain":      {siweMessage("", testAddress, nil), "missing domain"},
+		"address":        {siweMessage("example.com", "0x1234", nil), "invalid address"},
+		"checksum":       {siweMessage("example.com", strings.ToLower(testAddress), nil), "checksummed"},
+		"version":        {siweMessage("example.com", testAddress, map[string]string{"Version": "2"}), "unsupported version"},
+		"chain ID":       {siweMessage("example.com", testAddress, map[string]string{"Chain ID": "mainnet"}), "invalid chain ID"},
+		"no chain ID":    {siweMessage("example.com", testAddress, map[string]string{"Chain ID": ""}), "missing chain ID"},
+		"short nonce":    {siweMessage("example.com", testAddress, map[string]string{"Nonce": "abc"}), "short nonce"},
+		"issued at":      {siweMessage("example.com", testAddress, map[string]string{"Issued At": "yesterday"}), "invalid issued at"},
+		"no URI":         {siweMessage("example.com", testAddress, map[string]string{"URI": ""}), "missing URI"},
+		"expiration":     {siweMessage("example.com", testAddress, map[string]string{"Expiration Time": "soon"}), "invalid expiration"},
+		"two statements": {strings.Replace(siweMessage("example.com", testAddress, nil), "API.\n", "API.\nAnd more.\n", 1), "single line"},
+	}
+	for name, tc := range cases {
+		if _, err := ParseMessage(tc.text); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: got %v, want error containing %q", name, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"voting-dapp/backend/internal/models"
+)
+
+// maxMulticallSize is the most calls aggregated into one eth_call; larger
+// batches are split, with every part pinned to the block of the first
+var maxMulticallSize = 100
+
+// maxRPCBatchSize is the most eth_calls sent in one JSON-RPC batch when
+// there is no aggregator
+var maxRPCBatchSize = 100
+
+// rpcBatcher sends several JSON-RPC requests in one round trip
+type rpcBatcher interface {
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+}
+
+// PartialReadError is returned alongside the results of a batched read when
+// some of its calls failed. The results hold every read that succeeded.
+type PartialReadError struct {
+	Failures []models.ReadFailure
+}
+
+func (e *PartialReadError) Error() string {
+	reasons := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		reasons[i] = fmt.Sprintf("poll %d: %s", f.PollID, f.Error)
+	}
+	return fmt.Sprintf("%d reads failed: %s", len(e.Failures), strings.Join(reasons, "; "))
+}
+
+// PartialRead returns failures as a *PartialReadError, or nil if there are none
+func PartialRead(failures []models.ReadFailure) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	return &PartialReadError{Failures: failures}
+}
+
+// AsPartialRead returns the failed reads if err is a *PartialReadError
+func AsPartialRead(err error) ([]models.ReadFailure, bool) {
+	var partial *PartialReadError
+	if errors.As(err, &partial) {
+		return partial.Failures, true
+	}
+	return nil, false
+}
+
+// viewCall is a call to a view method of the Voting contract
+type viewCall struct {
+	method string
+	args   []interface{}
+}
+
+// callResult is the return data of a view call, or why it failed
+type callResult struct {
+	data []byte
+	err  error
+}
+
+// SetMulticall makes batched reads go through the Multicall3 aggregator at
+// address, answering each batch in a single eth_call. Without one, batched
+// reads are sent as a JSON-RPC batch of eth_calls pinned to the same block.
+func (c *Client) SetMulticall(address string) error {
+	if !common.IsHexAddress(address) {
+		return fmt.Errorf("invalid multicall address %q", address)
+	}
+	code, err := c.client.CodeAt(context.Background(), common.HexToAddress(address), nil)
+	if err != nil {
+		return fmt.Errorf("failed to check multicall contract: %v", err)
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("no multicall contract deployed at %s", address)
+	}
+	c.multicall = common.HexToAddress(address)
+	return nil
+}
+
+// batchCaller returns the caller for batched reads, which must agree across
+// a quorum of endpoints when critical and one is configured
+func (c *Client) batchCaller(critical bool) bind.ContractCaller {
+	if critical && c.quorum != nil {
+		return c.quorum
+	}
+	return c.client
+}
+
+// batchCall makes view calls on the Voting contract, all answered at the
+// same block, and returns each call's return data or error. The calls are
+// made at block, or at the current head if block is nil.
+func (c *Client) batchCall(caller bind.ContractCaller, block *big.Int, calls []viewCall) ([]callResult, error) {
+	votingABI, err := VotingMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	input := make([][]byte, len(calls))
+	for i, call := range calls {
+		input[i], err = votingABI.Pack(call.method, call.args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack %s: %v", call.method, err)
+		}
+	}
+
+	if c.multicall != (common.Address{}) {
+		return c.aggregate(caller, block, input)
+	}
+
+	// Without an aggregator, pin each call to the same block
+	ctx := context.Background()
+	if block == nil {
+		head, err := c.client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest block: %v", err)
+		}
+		block = head.Number
+	}
+	// Quorum reads go call by call so every one is compared across endpoints
+	if c.batcher != nil && caller == bind.ContractCaller(c.client) {
+		return c.rpcBatch(ctx, block, input)
+	}
+	results := make([]callResult, len(input))
+	for i, data := range input {
+		msg := ethereum.CallMsg{To: &c.contractAddr, Data: data}
+		results[i].data, results[i].err = caller.CallContract(ctx, msg, block)
+	}
+	return results, nil
+}
+
+// aggregate makes calls through the multicall contract at block. Unless
+// block is set, the first eth_call also reads the block number, which the
+// rest of a large batch is pinned to.
+func (c *Client) aggregate(caller bind.ContractCaller, block *big.Int, input [][]byte) ([]callResult, error) {
+	multicallABI, err := Multicall3MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	contract := bind.NewBoundContract(c.multicall, *multicallABI, caller, nil, nil)
+	blockNumberCall, err := multicallABI.Pack("getBlockNumber")
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]callResult, 0, len(input))
+	for start := 0; start < len(input); start += maxMulticallSize {
+		end := start + maxMulticallSize
+		if end > len(input) {
+			end = len(input)
+		}
+
+		calls := make([]Multicall3Call3, 0, end-start+1)
+		if block == nil {
+			calls = append(calls, Multicall3Call3{Target: c.multicall, CallData: blockNumberCall})
+		}
+		for _, data := range input[start:end] {
+			calls = append(calls, Multicall3Call3{Target: c.contractAddr, AllowFailure: true, CallData: data})
+		}
+
+		var out []interface{}
+		if err := contract.Call(&bind.CallOpts{BlockNumber: block}, &out, "aggregate3", calls); err != nil {
+			return nil, fmt.Errorf("multicall failed: %v", err)
+		}
+		returned := *abi.ConvertType(out[0], new([]Multicall3Result)).(*[]Multicall3Result)
+		if len(returned) != len(calls) {
+			return nil, fmt.Errorf("multicall returned %d results for %d calls", len(returned), len(calls))
+		}
+
+		if block == nil {
+			blockOut, err := multicallABI.Unpack("getBlockNumber", returned[0].ReturnData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read multicall block: %v", err)
+			}
+			block = *abi.ConvertType(blockOut[0], new(*big.Int)).(**big.Int)
+			returned = returned[1:]
+		}
+		for _, r := range returned {
+			if !r.Success {
+				results = append(results, callResult{err: revertError(r.ReturnData)})
+				continue
+			}
+			results = append(results, callResult{data: r.ReturnData})
+		}
+	}
+	return results, nil
+}
+
+// rpcBatch makes calls as JSON-RPC batches of eth_calls at block
+func (c *Client) rpcBatch(ctx context.Context, block *big.Int, input [][]byte) ([]callResult, error) {
+	results := make([]callResult, len(input))
+	data := make([]hexutil.Bytes, len(input))
+	for start := 0; start < len(input); start += maxRPCBatchSize {
+		end := start + maxRPCBatchSize
+		if end > len(input) {
+			end = len(input)
+		}
+
+		elems := make([]rpc.BatchElem, 0, end-start)
+		for i := start; i < end; i++ {
+			arg := map[string]interface{}{"to": c.contractAddr, "input": hexutil.Bytes(input[i])}
+			elems = append(elems, rpc.BatchElem{
+				Method: "eth_call",
+				Args:   []interface{}{arg, hexutil.EncodeBig(block)},
+				Result: &data[i],
+			})
+		}
+		if err := c.batcher.BatchCallContext(ctx, elems); err != nil {
+			return nil, fmt.Errorf("batched eth_call failed: %v", err)
+		}
+		for i, elem := range elems {
+			results[start+i] = callResult{data: data[start+i], err: elem.Error}
+		}
+	}
+	return results, nil
+}
+
+// revertError describes a reverted call from its return data
+func revertError(data []byte) error {
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return fmt.Errorf("execution reverted: %s", reason)
+	}
+	return errors.New("execution reverted")
+}
+
+// unpack decodes the return data of a Voting view method into out, whose
+// fields are named after the method's outputs
+func unpack(method string, result callResult, out interface{}) error {
+	if result.err != nil {
+		return result.err
+	}
+	votingABI, err := VotingMetaData.GetAbi()
+	if err != nil {
+		return err
+	}
+	return votingABI.UnpackIntoInterface(out, method, result.data)
+}
+
+// pollOutput is the return value of getPoll
+type pollOutput struct {
+	Title       string
+	Description string
+	Options     []string
+	StartTime   *big.Int
+	EndTime     *big.Int
+	Creator     common.Address
+	IsActive    bool
+	IsCanceled  bool
+	TotalVotes  *big.Int
+}
+
+// resultsOutput is the return value of getPollResults
+type resultsOutput struct {
+	OptionNames     []string
+	VoteCountsArray []*big.Int
+	TotalVotes      *big.Int
+}
+
+// voterStatusOutput is the return value of getVoterStatus
+type voterStatusOutput struct {
+	HasVotedStatus bool
+	OptionIndex    *big.Int
+}
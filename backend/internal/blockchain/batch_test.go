@@ -0,0 +1,335 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"voting-dapp/backend/internal/models"
+)
+
+// countingCaller records the block each eth_call is made at
+type countingCaller struct {
+	bind.ContractCaller
+	blocks []*big.Int
+}
+
+func (c *countingCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.blocks = append(c.blocks, blockNumber)
+	return c.ContractCaller.CallContract(ctx, call, blockNumber)
+}
+
+// ethCallService answers eth_call over JSON-RPC from a backend
+type ethCallService struct {
+	backend bind.ContractCaller
+}
+
+type ethCallArgs struct {
+	To    *common.Address `json:"to"`
+	Input hexutil.Bytes   `json:"input"`
+}
+
+func (s *ethCallService) Call(ctx context.Context, args ethCallArgs, block hexutil.Big) (hexutil.Bytes, error) {
+	return s.backend.CallContract(ctx, ethereum.CallMsg{To: args.To, Data: args.Input}, block.ToInt())
+}
+
+// countingBatcher records the size of each JSON-RPC batch
+type countingBatcher struct {
+	rpcBatcher
+	sizes []int
+}
+
+func (b *countingBatcher) BatchCallContext(ctx context.Context, elems []rpc.BatchElem) error {
+	b.sizes = append(b.sizes, len(elems))
+	return b.rpcBatcher.BatchCallContext(ctx, elems)
+}
+
+// serveRPC serves eth_call for client's backend on an in-process JSON-RPC
+// server and returns a client for it
+func serveRPC(t *testing.T, client *Client) *rpc.Client {
+	t.Helper()
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", &ethCallService{backend: client.client}); err != nil {
+		t.Fatal(err)
+	}
+	conn := rpc.DialInProc(server)
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+	return conn
+}
+
+func TestBatchedReads(t *testing.T) {
+	client, sim, err := NewSimulatedClient(SimulatedOptions{Voters: 1, VotingPower: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	for i := 0; i < 3; i++ {
+		createTestPoll(t, client, sim, "Yes", "No")
+	}
+	ids := []uint64{1, 2, 3, 99}
+
+	polls, err := client.GetPolls(ids)
+	failed, partial := AsPartialRead(err)
+	if !partial || len(failed) != 1 || failed[0].PollID != 99 {
+		t.Fatalf("err = %v, want poll 99 reported as failed", err)
+	}
+	if len(polls) != 3 {
+		t.Fatalf("got %d polls, want 3", len(polls))
+	}
+	for i, poll := range polls {
+		want, err := client.GetPoll(ids[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(poll, want) {
+			t.Errorf("poll %d = %+v, want %+v", ids[i], poll, want)
+		}
+	}
+
+	results, err := client.GetPollsResults(ids[:3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 || results[2].PollID != 3 || len(results[2].VoteCounts) != 2 {
+		t.Errorf("results = %+v", results)
+	}
+
+	voter := sim.Voters[0].Address.Hex()
+	statuses, err := client.GetVoterStatuses(ids, voter)
+	if _, partial := AsPartialRead(err); !partial {
+		t.Fatalf("err = %v, want a partial read", err)
+	}
+	if len(statuses) != 3 || statuses[1].VotingPower != 7 || statuses[1].HasVoted {
+		t.Errorf("statuses = %+v", statuses)
+	}
+
+	calls := []viewCall{
+		{method: "getPoll", args: []interface{}{big.NewInt(1)}},
+		{method: "getPoll", args: []interface{}{big.NewInt(2)}},
+		{method: "votingPower", args: []interface{}{common.HexToAddress(voter)}},
+	}
+	want, err := client.batchCall(sim.Backend, nil, calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A batch is one eth_call, and one split across several is pinned to
+	// the block the first was answered at
+	tests := []struct {
+		name      string
+		size      int
+		multicall common.Address
+		pinned    []bool
+	}{
+		{"aggregated", 100, sim.Multicall, []bool{false}},
+		{"split", 2, sim.Multicall, []bool{false, true}},
+		{"without multicall", 100, common.Address{}, []bool{true, true, true}},
+	}
+	defer func(size int) { maxMulticallSize = size }(maxMulticallSize)
+	for _, tt := range tests {
+		maxMulticallSize, client.multicall = tt.size, tt.multicall
+		caller := &countingCaller{ContractCaller: sim.Backend}
+		got, err := client.batchCall(caller, nil, calls)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: results differ from a single aggregated call", tt.name)
+		}
+		if len(caller.blocks) != len(tt.pinned) {
+			t.Fatalf("%s: made %d eth_calls, want %d", tt.name, len(caller.blocks), len(tt.pinned))
+		}
+		for i, block := range caller.blocks {
+			if (block != nil) != tt.pinned[i] {
+				t.Errorf("%s: eth_call %d at block %v", tt.name, i, block)
+			}
+		}
+	}
+}
+
+func TestReadsAtPastBlock(t *testing.T) {
+	client, sim, err := NewSimulatedClient(SimulatedOptions{Voters: 1, VotingPower: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	createTestPoll(t, client, sim, "Yes", "No")
+	voter := sim.Voters[0].Address.Hex()
+
+	past, err := client.At(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.AssignVotingPowerTx(voter, 9); err != nil {
+		t.Fatal(err)
+	}
+	createTestPoll(t, client, sim, "A", "B", "C")
+
+	head, err := client.At(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if head.Block().BlockNumber != past.Block().BlockNumber+1 || head.Block().BlockHash == past.Block().BlockHash {
+		t.Fatalf("head = %+v, past = %+v", head.Block(), past.Block())
+	}
+
+	// Reads at the past block see its state, with and without multicall
+	for _, multicall := range []common.Address{sim.Multicall, {}} {
+		client.multicall = multicall
+		reader, err := client.At(new(big.Int).SetUint64(past.Block().BlockNumber))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reader.Block() != past.Block() {
+			t.Errorf("block = %+v, want %+v", reader.Block(), past.Block())
+		}
+		if power, err := reader.GetVotingPower(voter); err != nil || power != 7 {
+			t.Errorf("power = %d, %v, want 7", power, err)
+		}
+		if ids, err := reader.GetAllPollIds(); err != nil || len(ids) != 1 {
+			t.Errorf("poll IDs = %v, %v, want [1]", ids, err)
+		}
+		if _, err := reader.GetPoll(2); err == nil {
+			t.Error("read poll 2 before it was created")
+		}
+		statuses, err := reader.GetVoterStatuses([]uint64{1, 2}, voter)
+		if failed, _ := AsPartialRead(err); len(failed) != 1 || failed[0].PollID != 2 {
+			t.Errorf("err = %v, want poll 2 reported as failed", err)
+		}
+		if statuses[1] == nil || statuses[1].VotingPower != 7 {
+			t.Errorf("statuses = %+v", statuses)
+		}
+	}
+
+	if power, err := client.GetVotingPower(voter); err != nil || power != 9 {
+		t.Errorf("latest power = %d, %v, want 9", power, err)
+	}
+	if _, err := client.At(big.NewInt(int64(head.Block().BlockNumber + 1))); !errors.Is(err, ErrUnknownBlock) {
+		t.Errorf("err = %v, want ErrUnknownBlock", err)
+	}
+}
+
+func TestBlockAtTime(t *testing.T) {
+	client, sim, err := NewSimulatedClient(SimulatedOptions{StartTime: time.Now().Add(-time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	ctx := context.Background()
+	times := make(map[uint64]int64)
+	for i := 0; i < 5; i++ {
+		if err := sim.Backend.AdjustTime(time.Minute); err != nil {
+			t.Fatal(err)
+		}
+		sim.Backend.Commit()
+		header, err := sim.Backend.HeaderByNumber(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		times[header.Number.Uint64()] = int64(header.Time)
+	}
+
+	for number, ts := range times {
+		for _, at := range []int64{ts, ts + 30} {
+			got, err := client.BlockAtTime(at)
+			if err != nil || got.Uint64() != number {
+				t.Errorf("BlockAtTime(%d) = %v, %v, want %d", at, got, err, number)
+			}
+		}
+	}
+	genesis, err := sim.Backend.HeaderByNumber(ctx, big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.BlockAtTime(int64(genesis.Time) - 1); !errors.Is(err, ErrUnknownBlock) {
+		t.Errorf("err = %v, want ErrUnknownBlock", err)
+	}
+}
+
+func TestRPCBatchMatchesMulticall(t *testing.T) {
+	client, sim, err := NewSimulatedClient(SimulatedOptions{Voters: 1, VotingPower: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	for i := 0; i < 3; i++ {
+		createTestPoll(t, client, sim, "Yes", "No")
+	}
+	voter := sim.Voters[0].Address
+	past, err := client.At(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.AssignVotingPowerTx(voter.Hex(), 9); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := []viewCall{
+		{method: "getPoll", args: []interface{}{big.NewInt(1)}},
+		{method: "getPoll", args: []interface{}{big.NewInt(99)}},
+		{method: "getPollResults", args: []interface{}{big.NewInt(3)}},
+		{method: "votingPower", args: []interface{}{voter}},
+		{method: "getVoterStatus", args: []interface{}{big.NewInt(2), voter}},
+	}
+	batcher := &countingBatcher{rpcBatcher: serveRPC(t, client)}
+	defer func(size int) { maxRPCBatchSize = size }(maxRPCBatchSize)
+	maxRPCBatchSize = 2
+
+	// Both paths give the same data and the same failures, at the head and
+	// at a past block
+	for _, block := range []*big.Int{nil, new(big.Int).SetUint64(past.Block().BlockNumber)} {
+		client.multicall, client.batcher = sim.Multicall, nil
+		want, err := client.batchCall(client.client, block, calls)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		client.multicall, client.batcher = common.Address{}, batcher
+		batcher.sizes = nil
+		got, err := client.batchCall(client.client, block, calls)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(batcher.sizes, []int{2, 2, 1}) {
+			t.Errorf("block %v: sent batches of %v, want [2 2 1]", block, batcher.sizes)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("block %v: got %d results, want %d", block, len(got), len(want))
+		}
+		for i := range want {
+			if !bytes.Equal(got[i].data, want[i].data) {
+				t.Errorf("block %v: %s returned %x, want %x", block, calls[i].method, got[i].data, want[i].data)
+			}
+			if (got[i].err == nil) != (want[i].err == nil) ||
+				got[i].err != nil && got[i].err.Error() != want[i].err.Error() {
+				t.Errorf("block %v: %s failed with %v, want %v", block, calls[i].method, got[i].err, want[i].err)
+			}
+		}
+	}
+
+	// Reads through the client decode the same way on both paths
+	var polls [2][]*models.Poll
+	for i, multicall := range []common.Address{sim.Multicall, {}} {
+		client.multicall = multicall
+		polls[i], err = client.GetPolls([]uint64{1, 2, 3, 99})
+		if failed, _ := AsPartialRead(err); len(failed) != 1 || failed[0].PollID != 99 {
+			t.Errorf("err = %v, want poll 99 reported as failed", err)
+		}
+	}
+	if !reflect.DeepEqual(polls[0], polls[1]) {
+		t.Errorf("polls differ: multicall %+v, batch %+v", polls[0], polls[1])
+	}
+}
@@ -0,0 +1,65 @@
+// Command bindgen generates the Go binding for a contract from its Hardhat
+// build artifact. It is run through go generate in the blockchain package:
+//
+//	cd contracts && npx hardhat compile
+//	cd backend && go generate ./internal/blockchain
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// artifact is the subset of a Hardhat artifact needed to generate a binding
+type artifact struct {
+	ContractName string          `json:"contractName"`
+	ABI          json.RawMessage `json:"abi"`
+	Bytecode     string          `json:"bytecode"`
+}
+
+func main() {
+	var (
+		artifactPath = flag.String("artifact", "", "path to the Hardhat artifact JSON")
+		pkg          = flag.String("pkg", "", "Go package name of the binding")
+		typ          = flag.String("type", "", "Go type name of the binding, defaults to the contract name")
+		out          = flag.String("out", "", "output file")
+	)
+	flag.Parse()
+	if *artifactPath == "" || *pkg == "" || *out == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	data, err := os.ReadFile(*artifactPath)
+	if err != nil {
+		log.Fatalf("Failed to read artifact: %v", err)
+	}
+	var a artifact
+	if err := json.Unmarshal(data, &a); err != nil {
+		log.Fatalf("Failed to parse artifact: %v", err)
+	}
+	if len(a.ABI) == 0 || a.Bytecode == "" {
+		log.Fatalf("Artifact %s has no ABI or bytecode", *artifactPath)
+	}
+	if *typ == "" {
+		*typ = a.ContractName
+	}
+
+	code, err := bind.Bind(
+		[]string{*typ},
+		[]string{string(a.ABI)},
+		[]string{strings.TrimPrefix(a.Bytecode, "0x")},
+		nil, *pkg, bind.LangGo, nil, nil,
+	)
+	if err != nil {
+		log.Fatalf("Failed to generate binding: %v", err)
+	}
+	if err := os.WriteFile(*out, []byte(code), 0o644); err != nil {
+		log.Fatalf("Failed to write binding: %v", err)
+	}
+}
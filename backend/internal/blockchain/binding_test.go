@@ -0,0 +1,181 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const (
+	contractSource   = "../../../contracts/src/Voting.sol"
+	contractArtifact = "../../../contracts/artifacts/src/Voting.sol/Voting.json"
+)
+
+var (
+	solComments    = regexp.MustCompile(`(?s)//[^\n]*|/\*.*?\*/`)
+	solFunctions   = regexp.MustCompile(`(?s)\bfunction\s+(\w+)\s*\(([^)]*)\)([^{;]*)`)
+	solEvents      = regexp.MustCompile(`(?s)\bevent\s+(\w+)\s*\(([^)]*)\)\s*;`)
+	solPublicVars  = regexp.MustCompile(`(?m)^\s*(mapping\s*\(.*\)|[\w\[\]]+)\s+public\s+(?:constant\s+|immutable\s+)?(\w+)\s*[;=]`)
+	solMappingKeys = regexp.MustCompile(`mapping\s*\(\s*(\w+)\s*=>`)
+)
+
+// canonicalType normalizes a Solidity type to its ABI spelling
+func canonicalType(t string) string {
+	switch {
+	case t == "uint" || strings.HasPrefix(t, "uint["):
+		return "uint256" + strings.TrimPrefix(t, "uint")
+	case t == "int" || strings.HasPrefix(t, "int["):
+		return "int256" + strings.TrimPrefix(t, "int")
+	}
+	return t
+}
+
+// paramTypes returns the ABI types of a Solidity parameter list
+func paramTypes(params string) []string {
+	var types []string
+	for _, p := range strings.Split(params, ",") {
+		fields := strings.Fields(p)
+		if len(fields) == 0 {
+			continue
+		}
+		types = append(types, canonicalType(fields[0]))
+	}
+	return types
+}
+
+// sourceSignatures extracts the externally visible function and event
+// signatures declared in a Solidity source file
+func sourceSignatures(src string) (methods, events []string) {
+	src = solComments.ReplaceAllString(src, "")
+
+	for _, m := range solFunctions.FindAllStringSubmatch(src, -1) {
+		modifiers := strings.Fields(m[3])
+		visible := false
+		for _, mod := range modifiers {
+			if mod == "public" || mod == "external" {
+				visible = true
+			}
+		}
+		if visible {
+			methods = append(methods, m[1]+"("+strings.Join(paramTypes(m[2]), ",")+")")
+		}
+	}
+
+	// Public state variables get getters taking the mapping keys
+	for _, m := range solPublicVars.FindAllStringSubmatch(src, -1) {
+		var keys []string
+		for _, k := range solMappingKeys.FindAllStringSubmatch(m[1], -1) {
+			keys = append(keys, canonicalType(k[1]))
+		}
+		if strings.HasSuffix(m[1], "[]") {
+			keys = append(keys, "uint256")
+		}
+		methods = append(methods, m[2]+"("+strings.Join(keys, ",")+")")
+	}
+
+	for _, m := range solEvents.FindAllStringSubmatch(src, -1) {
+		events = append(events, m[1]+"("+strings.Join(paramTypes(m[2]), ",")+")")
+	}
+
+	sort.Strings(methods)
+	sort.Strings(events)
+	return methods, events
+}
+
+// bindingSignatures returns the function and event signatures in the
+// committed binding's ABI
+func bindingSignatures(t *testing.T) (methods, events []string) {
+	t.Helper()
+
+	parsed, err := VotingMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range parsed.Methods {
+		methods = append(methods, m.Sig)
+	}
+	for _, e := range parsed.Events {
+		events = append(events, e.Sig)
+	}
+	sort.Strings(methods)
+	sort.Strings(events)
+	return methods, events
+}
+
+func TestBindingMatchesContractSource(t *testing.T) {
+	src, err := os.ReadFile(contractSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantMethods, wantEvents := sourceSignatures(string(src))
+	gotMethods, gotEvents := bindingSignatures(t)
+
+	if !reflect.DeepEqual(gotMethods, wantMethods) {
+		t.Errorf("binding functions drifted from Voting.sol, run go generate\nbinding: %v\nsource:  %v", gotMethods, wantMethods)
+	}
+	if !reflect.DeepEqual(gotEvents, wantEvents) {
+		t.Errorf("binding events drifted from Voting.sol, run go generate\nbinding: %v\nsource:  %v", gotEvents, wantEvents)
+	}
+}
+
+func TestBindingMatchesArtifact(t *testing.T) {
+	data, err := os.ReadFile(contractArtifact)
+	if os.IsNotExist(err) {
+		t.Skip("no Hardhat artifact, run npx hardhat compile in contracts/")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var artifact struct {
+		ABI json.RawMessage `json:"abi"`
+	}
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(normalizeABI(t, VotingMetaData.ABI), normalizeABI(t, string(artifact.ABI))) {
+		t.Error("binding ABI differs from the Hardhat artifact, run go generate")
+	}
+}
+
+// normalizeABI decodes an ABI JSON document into entries sorted by kind and
+// name. Ordering and internalType, which the binding generator reformats and
+// which does not affect encoding, are ignored.
+func normalizeABI(t *testing.T, doc string) []map[string]interface{} {
+	t.Helper()
+
+	var entries []map[string]interface{}
+	if err := json.Unmarshal([]byte(doc), &entries); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		dropInternalTypes(e)
+	}
+	key := func(e map[string]interface{}) string {
+		inputs, _ := json.Marshal(e["inputs"])
+		return fmt.Sprintf("%v/%v/%s", e["type"], e["name"], inputs)
+	}
+	sort.Slice(entries, func(i, j int) bool { return key(entries[i]) < key(entries[j]) })
+	return entries
+}
+
+func dropInternalTypes(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		delete(v, "internalType")
+		for _, child := range v {
+			dropInternalTypes(child)
+		}
+	case []interface{}:
+		for _, child := range v {
+			dropInternalTypes(child)
+		}
+	}
+}
@@ -2,100 +2,211 @@ package blockchain
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"fmt"
 	"math/big"
+	"sync/atomic"
+	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 
 	"voting-dapp/backend/internal/models"
 )
 
-// Client wraps the Ethereum client and contract
+// DefaultTxTimeout is how long write methods wait for a transaction to be mined
+const DefaultTxTimeout = 2 * time.Minute
+
+// Backend is the node API the client depends on. It is implemented by
+// *ethclient.Client and by the simulated backend.
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+}
+
+// Client wraps the Ethereum client and contract. Its embedded reader reads
+// the latest block.
 type Client struct {
-	client       *ethclient.Client
+	*Reader
+
+	client       Backend
+	closer       func()
 	contract     *Voting
 	auth         *bind.TransactOpts
+	nonces       *NonceManager
 	contractAddr common.Address
+	chainID      *big.Int
+	txTimeout    time.Duration
+	fees         FeePolicy
+	conn         *connState
+
+	// operators are further accounts that write transactions are dispatched
+	// across, in turn with auth's; dispatched counts the transactions sent
+	operators  []*operator
+	dispatched atomic.Uint64
+
+	// pool and critical are set when calls are routed across several
+	// endpoints; critical answers reads that need a quorum
+	pool     *Pool
+	quorum   *quorumCaller
+	critical *VotingCaller
+
+	// multicall is the aggregator batched reads go through, if any, and
+	// batcher sends them as JSON-RPC batches without one
+	multicall common.Address
+	batcher   rpcBatcher
+
+	// logRange bounds the log queries of reads over the contract's history
+	logRange LogRange
 }
 
-// NewClient creates a new blockchain client
-func NewClient(rpcURL, contractAddr, privateKey string) (*Client, error) {
+// NewClient creates a new blockchain client. rpcURL may be an HTTP,
+// WebSocket or IPC endpoint; Run keeps a WebSocket connection alive.
+// Transactions are signed by signer; a nil signer makes the client read-only.
+func NewClient(rpcURL, contractAddr string, signer Signer) (*Client, error) {
 	// Connect to Ethereum node
 	client, err := ethclient.Dial(rpcURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Ethereum node: %v", err)
 	}
 
-	// Parse contract address
-	contractAddress := common.HexToAddress(contractAddr)
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to get chain ID: %v", err)
+	}
 
-	// Initialize contract binding
-	contract, err := NewVoting(contractAddress, client)
+	c, err := newClient(client, chainID, contractAddr, signer)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize contract: %v", err)
+		client.Close()
+		return nil, err
 	}
+	c.closer = client.Close
+	c.conn = newConnState(transportOf(rpcURL))
+	c.batcher = client.Client()
+	return c, nil
+}
 
-	// Setup transaction options if private key provided
-	var auth *bind.TransactOpts
-	if privateKey != "" {
-		privKey, err := crypto.HexToECDSA(privateKey)
-		if err != nil {
-			client.Close()
-			return nil, fmt.Errorf("invalid private key: %v", err)
-		}
+// NewPoolClient creates a blockchain client that routes calls across
+// several RPC endpoints, failing over between them and, if cfg sets a
+// quorum, requiring that many endpoints to agree on critical reads
+func NewPoolClient(rpcURLs []string, contractAddr string, signer Signer, cfg PoolConfig) (*Client, error) {
+	pool, err := NewPool(rpcURLs, cfg)
+	if err != nil {
+		return nil, err
+	}
 
-		publicKey := privKey.Public()
-		publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-		if !ok {
-			client.Close()
-			return nil, fmt.Errorf("cannot assert type: publicKey is not of type *ecdsa.PublicKey")
-		}
+	chainID, err := pool.ChainID(context.Background())
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to get chain ID: %v", err)
+	}
 
-		fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
-		nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
+	c, err := newClient(pool, chainID, contractAddr, signer)
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
+	c.closer = pool.Close
+	c.conn = newConnState(models.TransportMulti)
+	c.pool = pool
+	c.batcher = pool
+	if cfg.Quorum >= 2 {
+		c.quorum = &quorumCaller{pool: pool}
+		c.critical, err = NewVotingCaller(c.contractAddr, c.quorum)
 		if err != nil {
-			client.Close()
-			return nil, fmt.Errorf("failed to get nonce: %v", err)
+			pool.Close()
+			return nil, fmt.Errorf("failed to initialize contract: %v", err)
 		}
+	}
+	return c, nil
+}
 
-		gasPrice, err := client.SuggestGasPrice(context.Background())
-		if err != nil {
-			client.Close()
-			return nil, fmt.Errorf("failed to get gas price: %v", err)
-		}
+// newClient binds the contract over backend and sets up transactions for
+// chainID if a signer is provided
+func newClient(backend Backend, chainID *big.Int, contractAddr string, signer Signer) (*Client, error) {
+	// Parse contract address
+	contractAddress := common.HexToAddress(contractAddr)
 
-		auth, err = bind.NewKeyedTransactorWithChainID(privKey, big.NewInt(1337))
-		if err != nil {
-			client.Close()
-			return nil, fmt.Errorf("failed to create transactor: %v", err)
-		}
-		auth.Nonce = big.NewInt(int64(nonce))
-		auth.Value = big.NewInt(0)
-		auth.GasLimit = uint64(3000000)
-		auth.GasPrice = gasPrice
+	// Initialize contract binding
+	contract, err := NewVoting(contractAddress, backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize contract: %v", err)
+	}
+
+	// Setup transaction options if a signer is provided
+	var auth *bind.TransactOpts
+	var nonces *NonceManager
+	if signer != nil {
+		auth = transactOpts(signer, chainID)
+		nonces = NewNonceManager(backend, signer.Address())
 	}
 
-	return &Client{
-		client:       client,
+	c := &Client{
+		client:       backend,
 		contract:     contract,
 		auth:         auth,
+		nonces:       nonces,
 		contractAddr: contractAddress,
-	}, nil
+		chainID:      chainID,
+		txTimeout:    DefaultTxTimeout,
+		fees:         DefaultFeePolicy(),
+	}
+	c.Reader = &Reader{client: c}
+	return c, nil
 }
 
 // Close closes the client connection
 func (c *Client) Close() {
-	if c.client != nil {
-		c.client.Close()
+	if c.closer != nil {
+		c.closer()
 	}
 }
 
+// transact submits a write transaction from the next operator account
+func (c *Client) transact(send func(*bind.TransactOpts) (*types.Transaction, error)) (*types.Transaction, error) {
+	return c.transactFrom(c.nextOperator(), send)
+}
+
+// transactFrom submits a write transaction from op using a nonce from its
+// nonce manager, with fees from the fee policy and a gas limit estimated
+// for the call
+func (c *Client) transactFrom(op *operator, send func(*bind.TransactOpts) (*types.Transaction, error)) (*types.Transaction, error) {
+	return op.nonces.Send(context.Background(), op.auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		if err := c.setFees(opts.Context, opts); err != nil {
+			return nil, err
+		}
+
+		// Build the transaction without sending it to learn the gas it needs
+		estimate := *opts
+		estimate.GasLimit = 0
+		estimate.NoSend = true
+		tx, err := send(&estimate)
+		if err != nil {
+			return nil, err
+		}
+
+		opts.GasLimit = scale(new(big.Int).SetUint64(tx.Gas()), c.fees.GasMultiplier).Uint64()
+		return send(opts)
+	})
+}
+
+// SetTxTimeout sets how long write methods wait for a transaction to be mined
+func (c *Client) SetTxTimeout(timeout time.Duration) {
+	c.txTimeout = timeout
+}
+
+// TxTimeout returns how long write methods wait for a transaction to be mined
+func (c *Client) TxTimeout() time.Duration {
+	return c.txTimeout
+}
+
 // GetContractAddress returns the contract address
 func (c *Client) GetContractAddress() string {
 	return c.contractAddr.Hex()
@@ -110,135 +221,106 @@ func (c *Client) GetAdmin() (string, error) {
 	return admin.Hex(), nil
 }
 
-// CreatePoll creates a new voting poll
-func (c *Client) CreatePoll(title, description string, options []string, startTime, endTime int64) (uint64, error) {
+// CreatePollTx submits a poll creation without waiting for it to be mined
+func (c *Client) CreatePollTx(title, description string, options []string, startTime, endTime int64) (*types.Transaction, error) {
 	if c.auth == nil {
-		return 0, fmt.Errorf("no private key configured for transactions")
-	}
-
-	tx, err := c.contract.CreatePoll(
-		c.auth,
-		title,
-		description,
-		options,
-		big.NewInt(startTime),
-		big.NewInt(endTime),
-	)
+		return nil, fmt.Errorf("no private key configured for transactions")
+	}
+
+	// The contract records the sender as the poll's creator, so polls always
+	// come from the client's own account
+	tx, err := c.transactFrom(c.primary(), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.contract.CreatePoll(
+			opts,
+			title,
+			description,
+			options,
+			big.NewInt(startTime),
+			big.NewInt(endTime),
+		)
+	})
 	if err != nil {
-		return 0, fmt.Errorf("failed to create poll: %v", err)
+		return nil, fmt.Errorf("failed to create poll: %w", err)
 	}
 
-	// Wait for transaction to be mined
-	receipt, err := bind.WaitMined(context.Background(), c.client, tx)
-	if err != nil {
-		return 0, fmt.Errorf("failed to get receipt: %v", err)
-	}
-
-	if receipt.Status == 0 {
-		return 0, fmt.Errorf("transaction failed")
-	}
+	return tx, nil
+}
 
-	// Get poll count
-	pollCount, err := c.contract.PollCount(nil)
+// CreatePoll creates a new voting poll
+func (c *Client) CreatePoll(title, description string, options []string, startTime, endTime int64) (uint64, error) {
+	tx, err := c.CreatePollTx(title, description, options, startTime, endTime)
 	if err != nil {
 		return 0, err
 	}
 
-	return pollCount.Uint64(), nil
-}
-
-// GetPoll retrieves poll details
-func (c *Client) GetPoll(pollID uint64) (*models.Poll, error) {
-	poll, err := c.contract.GetPoll(nil, big.NewInt(int64(pollID)))
+	receipt, err := c.waitMined(tx)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	return &models.Poll{
-		ID:          pollID,
-		Title:       poll.Title,
-		Description: poll.Description,
-		Options:     poll.Options,
-		StartTime:   poll.StartTime.Int64(),
-		EndTime:     poll.EndTime.Int64(),
-		Creator:     poll.Creator.Hex(),
-		IsActive:    poll.IsActive,
-		IsCanceled:  poll.IsCanceled,
-		TotalVotes:  poll.TotalVotes.Uint64(),
-	}, nil
+	return c.PollIDFromReceipt(receipt)
 }
 
-// GetPollResults retrieves poll results
-func (c *Client) GetPollResults(pollID uint64) (*models.PollResults, error) {
-	results, err := c.contract.GetPollResults(nil, big.NewInt(int64(pollID)))
-	if err != nil {
-		return nil, err
+// VoteTx submits a vote transaction without waiting for it to be mined
+func (c *Client) VoteTx(pollID, optionIndex uint64) (*types.Transaction, error) {
+	if c.auth == nil {
+		return nil, fmt.Errorf("no private key configured for transactions")
 	}
 
-	voteCounts := make([]uint64, len(results.VoteCountsArray))
-	for i, count := range results.VoteCountsArray {
-		voteCounts[i] = count.Uint64()
+	// Votes count for the sender, so they always come from the client's own account
+	tx, err := c.transactFrom(c.primary(), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.contract.Vote(opts, big.NewInt(int64(pollID)), big.NewInt(int64(optionIndex)))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to vote: %w", err)
 	}
 
-	return &models.PollResults{
-		PollID:     pollID,
-		Options:    results.OptionNames,
-		VoteCounts: voteCounts,
-		TotalVotes: results.TotalVotes.Uint64(),
-	}, nil
+	return tx, nil
 }
 
 // Vote casts a vote
 func (c *Client) Vote(pollID, optionIndex uint64) error {
-	if c.auth == nil {
-		return fmt.Errorf("no private key configured for transactions")
-	}
-
-	tx, err := c.contract.Vote(c.auth, big.NewInt(int64(pollID)), big.NewInt(int64(optionIndex)))
+	tx, err := c.VoteTx(pollID, optionIndex)
 	if err != nil {
-		return fmt.Errorf("failed to vote: %v", err)
+		return err
 	}
 
-	receipt, err := bind.WaitMined(context.Background(), c.client, tx)
-	if err != nil {
-		return fmt.Errorf("failed to get receipt: %v", err)
-	}
-
-	if receipt.Status == 0 {
-		return fmt.Errorf("transaction failed")
-	}
-
-	return nil
+	_, err = c.waitMined(tx)
+	return err
 }
 
-// AssignVotingPower assigns voting power to a voter
-func (c *Client) AssignVotingPower(voter string, power uint64) error {
+// AssignVotingPowerTx submits a voting power assignment without waiting for it to be mined
+func (c *Client) AssignVotingPowerTx(voter string, power uint64) (*types.Transaction, error) {
 	if c.auth == nil {
-		return fmt.Errorf("no private key configured for transactions")
+		return nil, fmt.Errorf("no private key configured for transactions")
 	}
 
 	voterAddr := common.HexToAddress(voter)
-	tx, err := c.contract.AssignVotingPower(c.auth, voterAddr, big.NewInt(int64(power)))
+	tx, err := c.transact(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.contract.AssignVotingPower(opts, voterAddr, big.NewInt(int64(power)))
+	})
 	if err != nil {
-		return fmt.Errorf("failed to assign voting power: %v", err)
+		return nil, fmt.Errorf("failed to assign voting power: %w", err)
 	}
 
-	receipt, err := bind.WaitMined(context.Background(), c.client, tx)
-	if err != nil {
-		return fmt.Errorf("failed to get receipt: %v", err)
-	}
+	return tx, nil
+}
 
-	if receipt.Status == 0 {
-		return fmt.Errorf("transaction failed")
+// AssignVotingPower assigns voting power to a voter
+func (c *Client) AssignVotingPower(voter string, power uint64) error {
+	tx, err := c.AssignVotingPowerTx(voter, power)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	_, err = c.waitMined(tx)
+	return err
 }
 
-// BatchAssignVotingPower batch assigns voting power
-func (c *Client) BatchAssignVotingPower(voters []string, powers []uint64) error {
+// BatchAssignVotingPowerTx submits a batch voting power assignment without waiting for it to be mined
+func (c *Client) BatchAssignVotingPowerTx(voters []string, powers []uint64) (*types.Transaction, error) {
 	if c.auth == nil {
-		return fmt.Errorf("no private key configured for transactions")
+		return nil, fmt.Errorf("no private key configured for transactions")
 	}
 
 	voterAddrs := make([]common.Address, len(voters))
@@ -251,171 +333,113 @@ func (c *Client) BatchAssignVotingPower(voters []string, powers []uint64) error
 		powerBigs[i] = big.NewInt(int64(power))
 	}
 
-	tx, err := c.contract.BatchAssignVotingPower(c.auth, voterAddrs, powerBigs)
+	tx, err := c.transact(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.contract.BatchAssignVotingPower(opts, voterAddrs, powerBigs)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to batch assign voting power: %v", err)
+		return nil, fmt.Errorf("failed to batch assign voting power: %w", err)
 	}
 
-	receipt, err := bind.WaitMined(context.Background(), c.client, tx)
-	if err != nil {
-		return fmt.Errorf("failed to get receipt: %v", err)
-	}
+	return tx, nil
+}
 
-	if receipt.Status == 0 {
-		return fmt.Errorf("transaction failed")
+// BatchAssignVotingPower batch assigns voting power
+func (c *Client) BatchAssignVotingPower(voters []string, powers []uint64) error {
+	tx, err := c.BatchAssignVotingPowerTx(voters, powers)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	_, err = c.waitMined(tx)
+	return err
 }
 
-// GetVotingPower gets voting power for an address
-func (c *Client) GetVotingPower(voter string) (uint64, error) {
-	voterAddr := common.HexToAddress(voter)
-	power, err := c.contract.VotingPower(nil, voterAddr)
-	if err != nil {
-		return 0, err
+// criticalCaller returns the binding for reads that must agree across a
+// quorum of endpoints when one is configured
+func (c *Client) criticalCaller() *VotingCaller {
+	if c.critical != nil {
+		return c.critical
 	}
-	return power.Uint64(), nil
+	return &c.contract.VotingCaller
 }
 
-// GetVoterStatus gets voter status for a poll
-func (c *Client) GetVoterStatus(pollID uint64, voter string) (*models.VoterStatus, error) {
-	voterAddr := common.HexToAddress(voter)
-	status, err := c.contract.GetVoterStatus(nil, big.NewInt(int64(pollID)), voterAddr)
-	if err != nil {
-		return nil, err
+// CancelPollTx submits a poll cancellation without waiting for it to be mined
+func (c *Client) CancelPollTx(pollID uint64) (*types.Transaction, error) {
+	if c.auth == nil {
+		return nil, fmt.Errorf("no private key configured for transactions")
 	}
 
-	votingPower, err := c.GetVotingPower(voter)
+	tx, err := c.transact(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.contract.CancelPoll(opts, big.NewInt(int64(pollID)))
+	})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to cancel poll: %w", err)
 	}
 
-	return &models.VoterStatus{
-		HasVoted:    status.HasVotedStatus,
-		OptionIndex: status.OptionIndex.Uint64(),
-		VotingPower: votingPower,
-	}, nil
-}
-
-// GetPollStatus gets the status of a poll
-func (c *Client) GetPollStatus(pollID uint64) (string, error) {
-	return c.contract.GetPollStatus(nil, big.NewInt(int64(pollID)))
+	return tx, nil
 }
 
-// GetAllPollIds gets all poll IDs
-func (c *Client) GetAllPollIds() ([]uint64, error) {
-	ids, err := c.contract.GetAllPollIds(nil)
+// CancelPoll cancels a poll
+func (c *Client) CancelPoll(pollID uint64) error {
+	tx, err := c.CancelPollTx(pollID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	result := make([]uint64, len(ids))
-	for i, id := range ids {
-		result[i] = id.Uint64()
-	}
-	return result, nil
+	_, err = c.waitMined(tx)
+	return err
 }
 
-// CancelPoll cancels a poll
-func (c *Client) CancelPoll(pollID uint64) error {
+// ActivatePollTx submits a poll activation without waiting for it to be mined
+func (c *Client) ActivatePollTx(pollID uint64) (*types.Transaction, error) {
 	if c.auth == nil {
-		return fmt.Errorf("no private key configured for transactions")
-	}
-
-	tx, err := c.contract.CancelPoll(c.auth, big.NewInt(int64(pollID)))
-	if err != nil {
-		return fmt.Errorf("failed to cancel poll: %v", err)
+		return nil, fmt.Errorf("no private key configured for transactions")
 	}
 
-	receipt, err := bind.WaitMined(context.Background(), c.client, tx)
+	tx, err := c.transact(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.contract.ActivatePoll(opts, big.NewInt(int64(pollID)))
+	})
 	if err != nil {
-		return fmt.Errorf("failed to get receipt: %v", err)
+		return nil, fmt.Errorf("failed to activate poll: %w", err)
 	}
 
-	if receipt.Status == 0 {
-		return fmt.Errorf("transaction failed")
-	}
-
-	return nil
+	return tx, nil
 }
 
 // ActivatePoll activates a poll
 func (c *Client) ActivatePoll(pollID uint64) error {
-	if c.auth == nil {
-		return fmt.Errorf("no private key configured for transactions")
-	}
-
-	tx, err := c.contract.ActivatePoll(c.auth, big.NewInt(int64(pollID)))
+	tx, err := c.ActivatePollTx(pollID)
 	if err != nil {
-		return fmt.Errorf("failed to activate poll: %v", err)
+		return err
 	}
 
-	receipt, err := bind.WaitMined(context.Background(), c.client, tx)
-	if err != nil {
-		return fmt.Errorf("failed to get receipt: %v", err)
-	}
-
-	if receipt.Status == 0 {
-		return fmt.Errorf("transaction failed")
-	}
-
-	return nil
+	_, err = c.waitMined(tx)
+	return err
 }
 
-// DeactivatePoll deactivates a poll
-func (c *Client) DeactivatePoll(pollID uint64) error {
+// DeactivatePollTx submits a poll deactivation without waiting for it to be mined
+func (c *Client) DeactivatePollTx(pollID uint64) (*types.Transaction, error) {
 	if c.auth == nil {
-		return fmt.Errorf("no private key configured for transactions")
-	}
-
-	tx, err := c.contract.DeactivatePoll(c.auth, big.NewInt(int64(pollID)))
-	if err != nil {
-		return fmt.Errorf("failed to deactivate poll: %v", err)
+		return nil, fmt.Errorf("no private key configured for transactions")
 	}
 
-	receipt, err := bind.WaitMined(context.Background(), c.client, tx)
+	tx, err := c.transact(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.contract.DeactivatePoll(opts, big.NewInt(int64(pollID)))
+	})
 	if err != nil {
-		return fmt.Errorf("failed to get receipt: %v", err)
+		return nil, fmt.Errorf("failed to deactivate poll: %w", err)
 	}
 
-	if receipt.Status == 0 {
-		return fmt.Errorf("transaction failed")
-	}
-
-	return nil
+	return tx, nil
 }
 
-// NewSimulatedClient creates a simulated blockchain client for testing
-func NewSimulatedClient() (*Client, error) {
-	privateKey, err := crypto.GenerateKey()
-	if err != nil {
-		return nil, err
-	}
-
-	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1337))
-	if err != nil {
-		return nil, err
-	}
-
-	address := crypto.PubkeyToAddress(privateKey.PublicKey)
-	alloc := make(core.GenesisAlloc)
-	alloc[address] = core.GenesisAccount{Balance: big.NewInt(1000000000000000000)}
-
-	backend := backends.NewSimulatedBackend(alloc, 10000000)
-	backend.Commit()
-
-	// Deploy contract
-	_, _, contract, err := DeployVoting(auth, backend)
+// DeactivatePoll deactivates a poll
+func (c *Client) DeactivatePoll(pollID uint64) error {
+	tx, err := c.DeactivatePollTx(pollID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to deploy contract: %v", err)
+		return err
 	}
-	backend.Commit()
 
-	return &Client{
-		client:       nil,
-		contract:     contract,
-		auth:         auth,
-		contractAddr: address,
-	}, nil
+	_, err = c.waitMined(tx)
+	return err
 }
@@ -0,0 +1,263 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"voting-dapp/backend/internal/models"
+)
+
+// ConnConfig configures how the client follows the chain head
+type ConnConfig struct {
+	// MinBackoff is the delay before the first reconnection attempt after
+	// the connection drops. It doubles after each failed attempt.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between reconnection attempts
+	MaxBackoff time.Duration
+	// PollInterval is how often the head is polled on transports without
+	// subscriptions, such as HTTP
+	PollInterval time.Duration
+}
+
+// connState tracks the node connection and the listeners woken by new heads
+type connState struct {
+	mu        sync.Mutex
+	cfg       ConnConfig
+	health    models.NodeHealth
+	listeners map[chan struct{}]struct{}
+}
+
+func newConnState(transport string) *connState {
+	return &connState{
+		cfg: ConnConfig{
+			MinBackoff:   time.Second,
+			MaxBackoff:   30 * time.Second,
+			PollInterval: 2 * time.Second,
+		},
+		health:    models.NodeHealth{Transport: transport},
+		listeners: make(map[chan struct{}]struct{}),
+	}
+}
+
+// transportOf returns the transport the node at rpcURL is reached over
+func transportOf(rpcURL string) string {
+	u, err := url.Parse(rpcURL)
+	if err != nil {
+		return models.TransportIPC
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "ws", "wss":
+		return models.TransportWebSocket
+	case "http", "https":
+		return models.TransportHTTP
+	}
+	return models.TransportIPC
+}
+
+// SetConnConfig changes how the client follows the chain head. Zero fields
+// keep their current value.
+func (c *Client) SetConnConfig(cfg ConnConfig) {
+	c.conn.mu.Lock()
+	defer c.conn.mu.Unlock()
+	if cfg.MinBackoff > 0 {
+		c.conn.cfg.MinBackoff = cfg.MinBackoff
+	}
+	if cfg.MaxBackoff > 0 {
+		c.conn.cfg.MaxBackoff = cfg.MaxBackoff
+	}
+	if cfg.PollInterval > 0 {
+		c.conn.cfg.PollInterval = cfg.PollInterval
+	}
+}
+
+// Health returns the state of the node connection
+func (c *Client) Health() models.NodeHealth {
+	c.conn.mu.Lock()
+	health := c.conn.health
+	c.conn.mu.Unlock()
+
+	if c.pool != nil {
+		health.Endpoints = c.pool.Health()
+		health.Quorum = c.pool.cfg.Quorum
+	}
+	return health
+}
+
+// NotifyHeads returns a channel that receives a value after each new head.
+// Notifications are coalesced, so a slow reader sees one for several heads
+// and should read everything up to the current head when woken. The stop
+// function unregisters the channel.
+func (c *Client) NotifyHeads() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	c.conn.mu.Lock()
+	c.conn.listeners[ch] = struct{}{}
+	c.conn.mu.Unlock()
+
+	return ch, func() {
+		c.conn.mu.Lock()
+		delete(c.conn.listeners, ch)
+		c.conn.mu.Unlock()
+	}
+}
+
+// Run follows the chain head, and checks endpoint health when calls are
+// routed across several endpoints, until ctx is canceled. Heads are pushed over
+// a newHeads subscription where the transport supports one and polled
+// otherwise. When the connection drops, Run redials with exponential
+// backoff and resubscribes; readers woken by NotifyHeads then resume from
+// the last block they read, so no block is skipped or read twice.
+func (c *Client) Run(ctx context.Context) {
+	if c.pool != nil {
+		go c.pool.Run(ctx)
+	}
+
+	c.conn.mu.Lock()
+	backoff := c.conn.cfg.MinBackoff
+	c.conn.mu.Unlock()
+
+	for {
+		err := c.followHead(ctx, func() {
+			c.conn.mu.Lock()
+			backoff = c.conn.cfg.MinBackoff
+			c.conn.mu.Unlock()
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = errors.New("head subscription closed")
+		}
+		c.disconnected(err)
+		log.Printf("Node connection: %v; retrying in %s", err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		c.conn.mu.Lock()
+		if backoff *= 2; backoff > c.conn.cfg.MaxBackoff {
+			backoff = c.conn.cfg.MaxBackoff
+		}
+		c.conn.mu.Unlock()
+	}
+}
+
+// followHead subscribes to new heads, or polls for them if subscriptions
+// are unsupported, until the connection fails. connected is called once
+// the head has been read.
+func (c *Client) followHead(ctx context.Context, connected func()) error {
+	heads := make(chan *types.Header, 16)
+	sub, err := c.client.SubscribeNewHead(ctx, heads)
+	if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+		return c.pollHead(ctx, connected)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to new heads: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	// Blocks mined while disconnected are not pushed, so read the head now
+	// to wake readers that fell behind
+	head, err := c.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to read head: %v", err)
+	}
+	c.connected(head, true)
+	connected()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-sub.Err():
+			return err
+		case head := <-heads:
+			c.connected(head, true)
+		}
+	}
+}
+
+// pollHead reads the head every poll interval until a read fails
+func (c *Client) pollHead(ctx context.Context, connected func()) error {
+	c.conn.mu.Lock()
+	interval := c.conn.cfg.PollInterval
+	c.conn.mu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for first := true; ; first = false {
+		head, err := c.client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to read head: %v", err)
+		}
+		c.connected(head, false)
+		if first {
+			connected()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// connected records a head read from the node and wakes listeners if it is
+// new or the connection was just restored, since reads may have failed
+// while it was down
+func (c *Client) connected(head *types.Header, subscribed bool) {
+	c.conn.mu.Lock()
+	defer c.conn.mu.Unlock()
+
+	h := &c.conn.health
+	reconnected := !h.Connected && h.HeadSeenAt != nil
+	if reconnected {
+		h.Reconnects++
+	}
+	h.Connected = true
+	h.Subscribed = subscribed
+	h.LastError = ""
+
+	number := head.Number.Uint64()
+	if h.HeadSeenAt != nil && number <= h.HeadBlock && !reconnected {
+		return
+	}
+	now := time.Now()
+	h.HeadSeenAt = &now
+	if number > h.HeadBlock {
+		h.HeadBlock = number
+	}
+
+	for ch := range c.conn.listeners {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// disconnected records a failed connection
+func (c *Client) disconnected(err error) {
+	c.conn.mu.Lock()
+	defer c.conn.mu.Unlock()
+
+	c.conn.health.Connected = false
+	c.conn.health.Subscribed = false
+	c.conn.health.LastError = err.Error()
+}
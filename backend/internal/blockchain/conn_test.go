@@ -0,0 +1,279 @@
+package blockchain
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"voting-dapp/backend/internal/models"
+)
+
+// testEthAPI serves the eth methods the client follows the chain with from
+// a simulated backend
+type testEthAPI struct {
+	backend *backends.SimulatedBackend
+}
+
+type testFilterArgs struct {
+	Address   []common.Address `json:"address"`
+	FromBlock rpc.BlockNumber  `json:"fromBlock"`
+	ToBlock   rpc.BlockNumber  `json:"toBlock"`
+}
+
+func (api *testEthAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, full bool) (*types.Header, error) {
+	var n *big.Int
+	if number >= 0 {
+		n = big.NewInt(number.Int64())
+	}
+	return api.backend.HeaderByNumber(ctx, n)
+}
+
+func (api *testEthAPI) GetLogs(ctx context.Context, args testFilterArgs) ([]types.Log, error) {
+	return api.backend.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: big.NewInt(args.FromBlock.Int64()),
+		ToBlock:   big.NewInt(args.ToBlock.Int64()),
+		Addresses: args.Address,
+	})
+}
+
+func (api *testEthAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(api.backend.Blockchain().Config().ChainID)
+}
+
+func (api *testEthAPI) BlockNumber(ctx context.Context) (hexutil.Uint64, error) {
+	header, err := api.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(header.Number.Uint64()), nil
+}
+
+type testCallArgs struct {
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to"`
+	Input hexutil.Bytes   `json:"input"`
+}
+
+func (api *testEthAPI) Call(ctx context.Context, args testCallArgs, number rpc.BlockNumber) (hexutil.Bytes, error) {
+	var n *big.Int
+	if number >= 0 {
+		n = big.NewInt(number.Int64())
+	}
+	return api.backend.CallContract(ctx, ethereum.CallMsg{From: args.From, To: args.To, Data: args.Input}, n)
+}
+
+func (api *testEthAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	heads := make(chan *types.Header, 16)
+	headSub, err := api.backend.SubscribeNewHead(context.Background(), heads)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := notifier.CreateSubscription()
+	go func() {
+		defer headSub.Unsubscribe()
+		for {
+			select {
+			case head := <-heads:
+				notifier.Notify(sub.ID, head)
+			case <-sub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// testNode serves a simulated backend over HTTP and WebSocket and can be
+// taken down, closing every connection, and brought back up
+type testNode struct {
+	backend *backends.SimulatedBackend
+
+	mu  sync.Mutex
+	srv *rpc.Server
+}
+
+func (n *testNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	srv := n.srv
+	n.mu.Unlock()
+	if srv == nil {
+		http.Error(w, "node is down", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Header.Get("Upgrade") == "websocket" {
+		srv.WebsocketHandler([]string{"*"}).ServeHTTP(w, r)
+		return
+	}
+	srv.ServeHTTP(w, r)
+}
+
+func (n *testNode) up(t *testing.T) {
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("eth", &testEthAPI{backend: n.backend}); err != nil {
+		t.Fatal(err)
+	}
+	n.mu.Lock()
+	n.srv = srv
+	n.mu.Unlock()
+}
+
+func (n *testNode) down() {
+	n.mu.Lock()
+	srv := n.srv
+	n.srv = nil
+	n.mu.Unlock()
+	srv.Stop()
+}
+
+// startTestNode serves backend from a new test node and returns its URL
+func startTestNode(t *testing.T, backend *backends.SimulatedBackend) (*testNode, string) {
+	node := &testNode{backend: backend}
+	node.up(t)
+	server := httptest.NewServer(node)
+	t.Cleanup(server.Close)
+	return node, server.URL
+}
+
+// createTestPoll creates a poll starting an hour after the head and mines it
+func createTestPoll(t *testing.T, client *Client, sim *Simulation, options ...string) {
+	t.Helper()
+	header, err := sim.Backend.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := int64(header.Time) + 3600
+	if _, err := client.CreatePollTx("Poll", "", options, start, start+3600); err != nil {
+		t.Fatal(err)
+	}
+	sim.Backend.Commit()
+}
+
+// waitFor polls cond until it holds, failing after a timeout
+func waitFor(t *testing.T, desc string, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(10 * time.Second); !cond(); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", desc)
+		}
+	}
+}
+
+func TestClientResubscribesAfterConnectionDrops(t *testing.T) {
+	simClient, sim, err := NewSimulatedClient(SimulatedOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(simClient.Close)
+
+	node, url := startTestNode(t, sim.Backend)
+	client, err := NewClient("ws"+strings.TrimPrefix(url, "http"), sim.Contract.Hex(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	client.SetConnConfig(ConnConfig{MinBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go client.Run(ctx)
+
+	// Follow the contract's logs the way the event stream does: read from
+	// the last block read whenever a new head arrives
+	head, err := simClient.BlockNumber(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	poller := client.NewLogPoller(head+1, 0)
+	heads, stop := client.NotifyHeads()
+	defer stop()
+
+	var mu sync.Mutex
+	var polls []uint64
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-heads:
+			}
+			for {
+				header, logs, err := poller.Poll(ctx)
+				if err != nil || header == nil {
+					break
+				}
+				for _, l := range logs {
+					if ev, err := client.ParseEvent(l); err == nil {
+						if created, ok := ev.(*VotingPollCreated); ok {
+							mu.Lock()
+							polls = append(polls, created.PollId.Uint64())
+							mu.Unlock()
+						}
+					}
+				}
+			}
+		}
+	}()
+	seen := func(n int) func() bool {
+		return func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(polls) >= n
+		}
+	}
+	createPoll := func() { createTestPoll(t, simClient, sim, "Yes", "No") }
+
+	waitFor(t, "subscription", func() bool {
+		h := client.Health()
+		return h.Connected && h.Subscribed
+	})
+	if h := client.Health(); h.Transport != models.TransportWebSocket {
+		t.Errorf("transport = %s, want %s", h.Transport, models.TransportWebSocket)
+	}
+	createPoll()
+	waitFor(t, "first poll", seen(1))
+
+	// Polls created while the node is unreachable are read after reconnecting
+	node.down()
+	waitFor(t, "disconnect", func() bool { return !client.Health().Connected })
+	if h := client.Health(); h.LastError == "" {
+		t.Error("disconnected health has no error")
+	}
+	createPoll()
+	createPoll()
+
+	node.up(t)
+	waitFor(t, "reconnect", func() bool { return client.Health().Connected })
+	waitFor(t, "missed polls", seen(3))
+	createPoll()
+	waitFor(t, "poll after reconnect", seen(4))
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i, id := range polls {
+		if id != uint64(i+1) {
+			t.Fatalf("polls read = %v, want each of 1-4 once", polls)
+		}
+	}
+	if h := client.Health(); h.Reconnects != 1 || !h.Subscribed {
+		t.Errorf("health after reconnect = %+v", h)
+	}
+}
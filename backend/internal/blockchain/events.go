@@ -0,0 +1,74 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// BlockNumber returns the number of the latest block
+func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	header, err := c.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	return header.Number.Uint64(), nil
+}
+
+// HeaderByNumber returns the header of a canonical block
+func (c *Client) HeaderByNumber(ctx context.Context, number uint64) (*types.Header, error) {
+	return c.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+}
+
+// FilterContractLogs returns every log emitted by the contract between
+// from and to inclusive, in chain order
+func (c *Client) FilterContractLogs(ctx context.Context, from, to uint64) ([]types.Log, error) {
+	return c.client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{c.contractAddr},
+	})
+}
+
+// ParseEvent decodes a contract log into its binding event type, such as
+// *VotingPollCreated or *VotingVoted
+func (c *Client) ParseEvent(log types.Log) (interface{}, error) {
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("log has no topics")
+	}
+
+	parsed, err := VotingMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	event, err := parsed.EventByID(log.Topics[0])
+	if err != nil {
+		return nil, err
+	}
+
+	switch event.Name {
+	case "PollCreated":
+		return c.contract.ParsePollCreated(log)
+	case "Voted":
+		return c.contract.ParseVoted(log)
+	case "VotingPowerAssigned":
+		return c.contract.ParseVotingPowerAssigned(log)
+	case "PollCanceled":
+		return c.contract.ParsePollCanceled(log)
+	case "PollActivated":
+		return c.contract.ParsePollActivated(log)
+	case "PollDeactivated":
+		return c.contract.ParsePollDeactivated(log)
+	case "AdminTransferred":
+		return c.contract.ParseAdminTransferred(log)
+	case "RoleGranted":
+		return c.contract.ParseRoleGranted(log)
+	case "RoleRevoked":
+		return c.contract.ParseRoleRevoked(log)
+	}
+	return nil, fmt.Errorf("unknown event %s", event.Name)
+}
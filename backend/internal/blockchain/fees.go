@@ -0,0 +1,177 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ErrFeeTooHigh is returned instead of sending a transaction when the fees
+// the network currently requires exceed the configured caps
+var ErrFeeTooHigh = errors.New("network fees exceed the configured maximum")
+
+// FeePolicy configures the fees and gas limit of each transaction
+type FeePolicy struct {
+	// HistoryBlocks is how many recent blocks the tip is sampled from
+	HistoryBlocks uint64
+	// RewardPercentile is the percentile of the priority fees paid in each
+	// sampled block; the median across blocks is offered as the tip
+	RewardPercentile float64
+	// BaseFeeMultiplier scales the next block's base fee in the fee cap, so
+	// a transaction stays valid while the base fee rises for a few blocks
+	BaseFeeMultiplier float64
+	// GasMultiplier scales the estimated gas of each call into its limit
+	GasMultiplier float64
+	// MaxFeePerGas is the most a transaction may pay per gas. A send is
+	// refused when the base fee plus tip exceeds it. Nil means no cap.
+	MaxFeePerGas *big.Int
+	// MaxTipPerGas caps the tip offered. Nil means no cap.
+	MaxTipPerGas *big.Int
+}
+
+// DefaultFeePolicy returns the policy used unless one is set
+func DefaultFeePolicy() FeePolicy {
+	return FeePolicy{
+		HistoryBlocks:     10,
+		RewardPercentile:  50,
+		BaseFeeMultiplier: 2,
+		GasMultiplier:     1.2,
+	}
+}
+
+// feeHistoryReader is implemented by backends that serve eth_feeHistory
+type feeHistoryReader interface {
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+}
+
+// SetFeePolicy sets how transaction fees and gas limits are chosen. Zero
+// fields fall back to the defaults.
+func (c *Client) SetFeePolicy(policy FeePolicy) {
+	defaults := DefaultFeePolicy()
+	if policy.HistoryBlocks == 0 {
+		policy.HistoryBlocks = defaults.HistoryBlocks
+	}
+	if policy.RewardPercentile <= 0 {
+		policy.RewardPercentile = defaults.RewardPercentile
+	}
+	if policy.BaseFeeMultiplier < 1 {
+		policy.BaseFeeMultiplier = defaults.BaseFeeMultiplier
+	}
+	if policy.GasMultiplier < 1 {
+		policy.GasMultiplier = defaults.GasMultiplier
+	}
+	c.fees = policy
+}
+
+// setFees fills in the fees of a transaction from the current network
+// conditions, as dynamic fees where the chain supports them
+func (c *Client) setFees(ctx context.Context, opts *bind.TransactOpts) error {
+	head, err := c.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to read head: %v", err)
+	}
+
+	// Chains without a base fee only take legacy transactions
+	if head.BaseFee == nil {
+		price, err := c.client.SuggestGasPrice(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get gas price: %v", err)
+		}
+		if c.fees.MaxFeePerGas != nil && price.Cmp(c.fees.MaxFeePerGas) > 0 {
+			return fmt.Errorf("%w: gas price %s gwei, maximum %s gwei", ErrFeeTooHigh, gwei(price), gwei(c.fees.MaxFeePerGas))
+		}
+		opts.GasPrice = price
+		return nil
+	}
+
+	baseFee, tip := head.BaseFee, (*big.Int)(nil)
+	if reader, ok := c.client.(feeHistoryReader); ok {
+		history, err := reader.FeeHistory(ctx, c.fees.HistoryBlocks, nil, []float64{c.fees.RewardPercentile})
+		if err != nil {
+			return fmt.Errorf("failed to get fee history: %v", err)
+		}
+		if n := len(history.BaseFee); n > 0 {
+			baseFee = history.BaseFee[n-1]
+		}
+		tip = medianReward(history)
+	}
+	if tip == nil {
+		if tip, err = c.client.SuggestGasTipCap(ctx); err != nil {
+			return fmt.Errorf("failed to get priority fee: %v", err)
+		}
+	}
+
+	opts.GasTipCap, opts.GasFeeCap, err = c.fees.dynamicFees(baseFee, tip)
+	return err
+}
+
+// medianReward returns the median of the sampled priority fees, or nil if
+// the sampled blocks held no transactions
+func medianReward(history *ethereum.FeeHistory) *big.Int {
+	var rewards []*big.Int
+	for i, block := range history.Reward {
+		if len(block) == 0 || block[0] == nil {
+			continue
+		}
+		// Empty blocks report a zero reward
+		if i < len(history.GasUsedRatio) && history.GasUsedRatio[i] == 0 {
+			continue
+		}
+		rewards = append(rewards, block[0])
+	}
+	if len(rewards) == 0 {
+		return nil
+	}
+	sort.Slice(rewards, func(i, j int) bool { return rewards[i].Cmp(rewards[j]) < 0 })
+	return new(big.Int).Set(rewards[len(rewards)/2])
+}
+
+// dynamicFees returns the tip and fee cap to offer given the next block's
+// base fee and the suggested tip. The fee cap allows the base fee to grow by
+// the policy's multiplier, limited by the maximum fee, and the send is
+// refused if even the current base fee plus tip is above that maximum.
+func (p FeePolicy) dynamicFees(baseFee, tip *big.Int) (*big.Int, *big.Int, error) {
+	tip = new(big.Int).Set(tip)
+	if p.MaxTipPerGas != nil && tip.Cmp(p.MaxTipPerGas) > 0 {
+		tip.Set(p.MaxTipPerGas)
+	}
+
+	required := new(big.Int).Add(baseFee, tip)
+	if p.MaxFeePerGas != nil && required.Cmp(p.MaxFeePerGas) > 0 {
+		return nil, nil, fmt.Errorf("%w: base fee %s gwei plus tip %s gwei, maximum %s gwei",
+			ErrFeeTooHigh, gwei(baseFee), gwei(tip), gwei(p.MaxFeePerGas))
+	}
+
+	feeCap := scale(baseFee, p.BaseFeeMultiplier)
+	feeCap.Add(feeCap, tip)
+	if p.MaxFeePerGas != nil && feeCap.Cmp(p.MaxFeePerGas) > 0 {
+		feeCap.Set(p.MaxFeePerGas)
+	}
+	return tip, feeCap, nil
+}
+
+// scale multiplies an amount by a factor, rounding up
+func scale(amount *big.Int, factor float64) *big.Int {
+	product := new(big.Float).Mul(new(big.Float).SetInt(amount), big.NewFloat(factor))
+	scaled, accuracy := product.Int(nil)
+	if accuracy == big.Below {
+		scaled.Add(scaled, big.NewInt(1))
+	}
+	return scaled
+}
+
+// gwei formats a wei amount in gwei
+func gwei(wei *big.Int) string {
+	return new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(params.GWei)).Text('f', -1)
+}
+
+// GweiToWei converts a gwei amount, such as a configured fee cap, to wei
+func GweiToWei(amount float64) *big.Int {
+	return scale(big.NewInt(params.GWei), amount)
+}
@@ -0,0 +1,163 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// feeTestBackend serves a fixed head, fee history and gas price
+type feeTestBackend struct {
+	Backend
+	head     *types.Header
+	history  *ethereum.FeeHistory
+	gasPrice *big.Int
+}
+
+func (b *feeTestBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return b.head, nil
+}
+
+func (b *feeTestBackend) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return b.history, nil
+}
+
+func (b *feeTestBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return b.gasPrice, nil
+}
+
+func gweis(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(params.GWei))
+}
+
+func TestSetFeesFromFeeHistory(t *testing.T) {
+	backend := &feeTestBackend{
+		head: &types.Header{Number: big.NewInt(100), BaseFee: gweis(10)},
+		history: &ethereum.FeeHistory{
+			// The empty block's zero reward is not sampled
+			Reward:       [][]*big.Int{{gweis(1)}, {gweis(3)}, {big.NewInt(0)}, {gweis(2)}},
+			BaseFee:      []*big.Int{gweis(9), gweis(10), gweis(11), gweis(10), gweis(12)},
+			GasUsedRatio: []float64{0.4, 0.9, 0, 0.5},
+		},
+	}
+	c := &Client{client: backend, fees: DefaultFeePolicy()}
+
+	var opts bind.TransactOpts
+	if err := c.setFees(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if opts.GasTipCap.Cmp(gweis(2)) != 0 {
+		t.Errorf("tip = %s, want the 2 gwei median", opts.GasTipCap)
+	}
+	// Twice the next block's base fee plus the tip
+	if opts.GasFeeCap.Cmp(gweis(26)) != 0 {
+		t.Errorf("fee cap = %s, want 26 gwei", opts.GasFeeCap)
+	}
+	if opts.GasPrice != nil {
+		t.Errorf("dynamic fee transaction has gas price %s", opts.GasPrice)
+	}
+
+	// A chain without a base fee gets a legacy gas price
+	backend.head = &types.Header{Number: big.NewInt(100)}
+	backend.gasPrice = gweis(5)
+	opts = bind.TransactOpts{}
+	if err := c.setFees(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if opts.GasPrice.Cmp(gweis(5)) != 0 || opts.GasFeeCap != nil {
+		t.Errorf("legacy fees: price %s, fee cap %s", opts.GasPrice, opts.GasFeeCap)
+	}
+	c.fees.MaxFeePerGas = gweis(4)
+	if err := c.setFees(context.Background(), &opts); !errors.Is(err, ErrFeeTooHigh) {
+		t.Errorf("legacy price over the cap: %v", err)
+	}
+}
+
+func TestDynamicFees(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxFee      *big.Int
+		maxTip      *big.Int
+		wantTip     *big.Int
+		wantFeeCap  *big.Int
+		wantRefusal bool
+	}{
+		{name: "uncapped", wantTip: gweis(2), wantFeeCap: gweis(22)},
+		{name: "fee cap limited", maxFee: gweis(15), wantTip: gweis(2), wantFeeCap: gweis(15)},
+		{name: "tip capped", maxTip: gweis(1), wantTip: gweis(1), wantFeeCap: gweis(21)},
+		{name: "too expensive", maxFee: gweis(11), wantRefusal: true},
+		{name: "capped tip fits", maxFee: gweis(11), maxTip: gweis(1), wantTip: gweis(1), wantFeeCap: gweis(11)},
+	}
+	for _, tt := range tests {
+		policy := DefaultFeePolicy()
+		policy.MaxFeePerGas, policy.MaxTipPerGas = tt.maxFee, tt.maxTip
+
+		tip, feeCap, err := policy.dynamicFees(gweis(10), gweis(2))
+		if tt.wantRefusal {
+			if !errors.Is(err, ErrFeeTooHigh) {
+				t.Errorf("%s: err = %v, want ErrFeeTooHigh", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if tip.Cmp(tt.wantTip) != 0 || feeCap.Cmp(tt.wantFeeCap) != 0 {
+			t.Errorf("%s: tip %s, fee cap %s, want %s and %s", tt.name, tip, feeCap, tt.wantTip, tt.wantFeeCap)
+		}
+	}
+}
+
+func TestTransactEstimatesGasAndRefusesExpensiveFees(t *testing.T) {
+	client, sim, err := NewSimulatedClient(SimulatedOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+
+	head, err := sim.Backend.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := int64(head.Time) + 3600
+	tx, err := client.CreatePollTx("Fees", "", []string{"Yes", "No"}, start, start+3600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.Backend.Commit()
+
+	if tx.Type() != types.DynamicFeeTxType {
+		t.Errorf("transaction type = %d, want dynamic fee", tx.Type())
+	}
+	receipt, err := sim.Backend.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if min := receipt.GasUsed * 6 / 5; tx.Gas() < min || tx.Gas() > 2*receipt.GasUsed {
+		t.Errorf("gas limit %d for %d gas used, want about 1.2x", tx.Gas(), receipt.GasUsed)
+	}
+
+	// A refused send does not use up the nonce
+	policy := DefaultFeePolicy()
+	policy.MaxFeePerGas = big.NewInt(1)
+	client.SetFeePolicy(policy)
+	if _, err := client.CreatePollTx("Refused", "", []string{"Yes", "No"}, start, start+3600); !errors.Is(err, ErrFeeTooHigh) {
+		t.Fatalf("send over the fee cap: %v", err)
+	}
+
+	client.SetFeePolicy(DefaultFeePolicy())
+	next, err := client.CreatePollTx("Next", "", []string{"Yes", "No"}, start, start+3600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.Nonce() != tx.Nonce()+1 {
+		t.Errorf("nonce after refusal = %d, want %d", next.Nonce(), tx.Nonce()+1)
+	}
+}
@@ -0,0 +1,6 @@
+package blockchain
+
+// The bindings are generated from the Hardhat artifacts; compile the
+// contracts first with `npx hardhat compile` in contracts/.
+//go:generate go run ./bindgen -artifact ../../../contracts/artifacts/src/Voting.sol/Voting.json -pkg blockchain -type Voting -out voting.go
+//go:generate go run ./bindgen -artifact ../../../contracts/artifacts/src/Multicall3.sol/Multicall3.json -pkg blockchain -type Multicall3 -out multicall3.go
@@ -0,0 +1,53 @@
+package blockchain
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// networkNames names the chains the backend is commonly deployed on
+var networkNames = map[uint64]string{
+	1:        "mainnet",
+	10:       "optimism",
+	56:       "bsc",
+	100:      "gnosis",
+	137:      "polygon",
+	1337:     "localhost",
+	8453:     "base",
+	17000:    "holesky",
+	31337:    "hardhat",
+	42161:    "arbitrum",
+	59144:    "linea",
+	80002:    "polygon-amoy",
+	84532:    "base-sepolia",
+	421614:   "arbitrum-sepolia",
+	11155111: "sepolia",
+	11155420: "optimism-sepolia",
+}
+
+// NetworkName returns the name of the chain with the given ID, or
+// "chain-<id>" for chains without a well-known name
+func NetworkName(chainID *big.Int) string {
+	if chainID.IsUint64() {
+		if name, ok := networkNames[chainID.Uint64()]; ok {
+			return name
+		}
+	}
+	return "chain-" + chainID.String()
+}
+
+// ChainID returns the chain ID the client signs transactions for, as
+// reported by the node
+func (c *Client) ChainID() *big.Int {
+	return new(big.Int).Set(c.chainID)
+}
+
+// ExpectChainID fails if the node is not on the chain with the given ID
+func (c *Client) ExpectChainID(expected uint64) error {
+	if c.chainID.IsUint64() && c.chainID.Uint64() == expected {
+		return nil
+	}
+	want := new(big.Int).SetUint64(expected)
+	return fmt.Errorf("node is on chain %s (%s), expected %s (%s)",
+		c.chainID, NetworkName(c.chainID), want, NetworkName(want))
+}
@@ -0,0 +1,47 @@
+package blockchain
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestClientReadsChainIDFromNode(t *testing.T) {
+	simClient, sim, err := NewSimulatedClient(SimulatedOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(simClient.Close)
+
+	_, url := startTestNode(t, sim.Backend)
+	client, err := NewClient(url, sim.Contract.Hex(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+
+	if id := client.ChainID(); id.Cmp(big.NewInt(1337)) != 0 {
+		t.Errorf("chain ID = %s, want 1337", id)
+	}
+	if err := client.ExpectChainID(1337); err != nil {
+		t.Error(err)
+	}
+	err = client.ExpectChainID(11155111)
+	if err == nil || !strings.Contains(err.Error(), "sepolia") {
+		t.Errorf("mismatched chain: %v", err)
+	}
+}
+
+func TestNetworkName(t *testing.T) {
+	tests := map[int64]string{
+		1:        "mainnet",
+		11155111: "sepolia",
+		31337:    "hardhat",
+		424242:   "chain-424242",
+	}
+	for id, want := range tests {
+		if got := NetworkName(big.NewInt(id)); got != want {
+			t.Errorf("NetworkName(%d) = %s, want %s", id, got, want)
+		}
+	}
+}
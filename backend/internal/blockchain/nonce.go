@@ -0,0 +1,261 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// maxNonceRetries bounds how often a send is retried after a nonce conflict
+const maxNonceRetries = 3
+
+// NonceSource is the subset of the node API the nonce manager depends on
+type NonceSource interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// NonceManager hands out sequential nonces for a single sending account.
+// It is safe for concurrent use.
+type NonceManager struct {
+	source  NonceSource
+	account common.Address
+
+	// sendMu serializes signing and submission so transactions reach the
+	// node in nonce order
+	sendMu sync.Mutex
+
+	mu       sync.Mutex
+	synced   bool
+	next     uint64
+	inflight map[uint64]struct{}
+	released []uint64
+}
+
+// NewNonceManager creates a nonce manager for the given account
+func NewNonceManager(source NonceSource, account common.Address) *NonceManager {
+	return &NonceManager{
+		source:   source,
+		account:  account,
+		inflight: make(map[uint64]struct{}),
+	}
+}
+
+// Account returns the address the manager allocates nonces for
+func (m *NonceManager) Account() common.Address {
+	return m.account
+}
+
+// Next allocates the next nonce. Nonces given back through Release are
+// reused first, lowest first, so that no gaps are left behind.
+func (m *NonceManager) Next(ctx context.Context) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.synced {
+		pending, err := m.source.PendingNonceAt(ctx, m.account)
+		if err != nil {
+			return 0, err
+		}
+		m.next = pending
+		m.synced = true
+	}
+
+	var nonce uint64
+	if len(m.released) > 0 {
+		nonce = m.released[0]
+		m.released = m.released[1:]
+	} else {
+		nonce = m.next
+		m.next++
+	}
+	m.inflight[nonce] = struct{}{}
+	return nonce, nil
+}
+
+// Done marks a nonce as accepted by the node
+func (m *NonceManager) Done(nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.inflight, nonce)
+}
+
+// Release returns a nonce whose transaction never reached the node so that
+// it is handed out again by the next call to Next
+func (m *NonceManager) Release(nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.inflight[nonce]; !ok {
+		return
+	}
+	delete(m.inflight, nonce)
+	m.addReleased(nonce)
+}
+
+// Resync reconciles the local counter with the node's pending nonce. Nonces
+// below the node's value are considered used; nonces between the node's
+// value and the local counter that are not in flight belong to transactions
+// the node dropped and are queued for reuse.
+func (m *NonceManager) Resync(ctx context.Context) error {
+	pending, err := m.source.PendingNonceAt(ctx, m.account)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	kept := m.released[:0]
+	for _, nonce := range m.released {
+		if nonce >= pending && nonce < m.next {
+			kept = append(kept, nonce)
+		}
+	}
+	m.released = kept
+
+	if !m.synced || pending >= m.next {
+		m.next = pending
+		m.released = nil
+		m.synced = true
+		return nil
+	}
+
+	for nonce := pending; nonce < m.next; nonce++ {
+		if _, ok := m.inflight[nonce]; ok {
+			continue
+		}
+		m.addReleased(nonce)
+	}
+	return nil
+}
+
+// addReleased inserts a nonce into the sorted reuse queue; callers hold mu
+func (m *NonceManager) addReleased(nonce uint64) {
+	i := sort.Search(len(m.released), func(i int) bool { return m.released[i] >= nonce })
+	if i < len(m.released) && m.released[i] == nonce {
+		return
+	}
+	m.released = append(m.released, 0)
+	copy(m.released[i+1:], m.released[i:])
+	m.released[i] = nonce
+}
+
+// removeReleased drops a nonce from the reuse queue; callers hold mu
+func (m *NonceManager) removeReleased(nonce uint64) {
+	i := sort.Search(len(m.released), func(i int) bool { return m.released[i] >= nonce })
+	if i < len(m.released) && m.released[i] == nonce {
+		m.released = append(m.released[:i], m.released[i+1:]...)
+	}
+}
+
+// discard forgets an in-flight nonce without queueing it for reuse
+func (m *NonceManager) discard(nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.inflight, nonce)
+}
+
+// Send allocates a nonce, lets send sign and submit a transaction with it,
+// and recovers from nonce conflicts by resyncing with the node and retrying
+func (m *NonceManager) Send(ctx context.Context, opts *bind.TransactOpts, send func(*bind.TransactOpts) (*types.Transaction, error)) (*types.Transaction, error) {
+	m.sendMu.Lock()
+	defer m.sendMu.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		nonce, err := m.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		txOpts := *opts
+		txOpts.Nonce = new(big.Int).SetUint64(nonce)
+		txOpts.Context = ctx
+
+		tx, err := send(&txOpts)
+		if err == nil {
+			m.Done(nonce)
+			return tx, nil
+		}
+
+		if !IsNonceError(err) || attempt >= maxNonceRetries {
+			m.Release(nonce)
+			return nil, err
+		}
+
+		m.discard(nonce)
+		if err := m.Resync(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// FillGaps compares the local counter with the node's pending nonce and,
+// while the node is missing a nonce that was handed out, lets fill sign and
+// submit a transaction with it, lowest first. Transactions queued behind a
+// transaction the node dropped are mined once its nonce is filled. It
+// returns the transactions fill sent.
+func (m *NonceManager) FillGaps(ctx context.Context, opts *bind.TransactOpts, fill func(*bind.TransactOpts) (*types.Transaction, error)) ([]*types.Transaction, error) {
+	m.sendMu.Lock()
+	defer m.sendMu.Unlock()
+
+	var filled []*types.Transaction
+	for {
+		pending, err := m.source.PendingNonceAt(ctx, m.account)
+		if err != nil {
+			return filled, err
+		}
+
+		m.mu.Lock()
+		_, inflight := m.inflight[pending]
+		gap := m.synced && pending < m.next && !inflight
+		if gap {
+			m.removeReleased(pending)
+		}
+		m.mu.Unlock()
+		if !gap {
+			return filled, nil
+		}
+		if n := len(filled); n > 0 && filled[n-1].Nonce() >= pending {
+			return filled, fmt.Errorf("nonce %d is still missing after filling it", pending)
+		}
+
+		txOpts := *opts
+		txOpts.Nonce = new(big.Int).SetUint64(pending)
+		txOpts.Context = ctx
+		tx, err := fill(&txOpts)
+		if err != nil {
+			m.mu.Lock()
+			m.addReleased(pending)
+			m.mu.Unlock()
+			return filled, err
+		}
+		filled = append(filled, tx)
+	}
+}
+
+// IsNonceError reports whether a send failed because the nonce was already
+// used or is out of order with the node's view of the account
+func IsNonceError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{
+		"nonce too low",
+		"nonce too high",
+		"already known",
+		"invalid transaction nonce",
+		"replacement transaction underpriced",
+	} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,438 @@
+package blockchain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var testRecipient = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+func newNonceTestBackend(t *testing.T) (*backends.SimulatedBackend, *ecdsa.PrivateKey, *bind.TransactOpts) {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alloc := core.GenesisAlloc{
+		auth.From: {Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))},
+	}
+	backend := backends.NewSimulatedBackend(alloc, 10000000)
+	t.Cleanup(func() { backend.Close() })
+	return backend, key, auth
+}
+
+// sendTransfer returns a send function that transfers one wei using the
+// nonce from the provided options
+func sendTransfer(backend *backends.SimulatedBackend) func(*bind.TransactOpts) (*types.Transaction, error) {
+	return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		tx := types.NewTx(&types.LegacyTx{
+			Nonce:    opts.Nonce.Uint64(),
+			To:       &testRecipient,
+			Value:    big.NewInt(1),
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(10 * params.GWei),
+		})
+		signed, err := opts.Signer(opts.From, tx)
+		if err != nil {
+			return nil, err
+		}
+		if err := backend.SendTransaction(opts.Context, signed); err != nil {
+			return nil, err
+		}
+		return signed, nil
+	}
+}
+
+func TestNonceManagerSequentialSends(t *testing.T) {
+	backend, _, auth := newNonceTestBackend(t)
+	m := NewNonceManager(backend, auth.From)
+	ctx := context.Background()
+
+	for i := uint64(0); i < 3; i++ {
+		tx, err := m.Send(ctx, auth, sendTransfer(backend))
+		if err != nil {
+			t.Fatalf("send %d: %v", i, err)
+		}
+		if tx.Nonce() != i {
+			t.Fatalf("send %d: got nonce %d", i, tx.Nonce())
+		}
+		backend.Commit()
+	}
+
+	nonce, err := backend.NonceAt(ctx, auth.From, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce != 3 {
+		t.Fatalf("account nonce = %d, want 3", nonce)
+	}
+}
+
+func TestNonceManagerConcurrentSends(t *testing.T) {
+	backend, _, auth := newNonceTestBackend(t)
+	m := NewNonceManager(backend, auth.From)
+	ctx := context.Background()
+
+	const senders = 16
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		nonces []uint64
+	)
+	for i := 0; i < senders; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tx, err := m.Send(ctx, auth, sendTransfer(backend))
+			if err != nil {
+				t.Errorf("send: %v", err)
+				return
+			}
+			mu.Lock()
+			nonces = append(nonces, tx.Nonce())
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	backend.Commit()
+
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	for i, nonce := range nonces {
+		if nonce != uint64(i) {
+			t.Fatalf("nonces = %v, want 0..%d", nonces, senders-1)
+		}
+	}
+
+	mined, err := backend.NonceAt(ctx, auth.From, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mined != senders {
+		t.Fatalf("account nonce = %d, want %d", mined, senders)
+	}
+}
+
+func TestNonceManagerConcurrentNext(t *testing.T) {
+	backend, _, auth := newNonceTestBackend(t)
+	m := NewNonceManager(backend, auth.From)
+
+	const workers = 50
+	seen := make(chan uint64, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonce, err := m.Next(context.Background())
+			if err != nil {
+				t.Errorf("next: %v", err)
+				return
+			}
+			seen <- nonce
+		}()
+	}
+	wg.Wait()
+	close(seen)
+
+	unique := make(map[uint64]bool)
+	for nonce := range seen {
+		if unique[nonce] {
+			t.Fatalf("nonce %d handed out twice", nonce)
+		}
+		unique[nonce] = true
+	}
+	for i := uint64(0); i < workers; i++ {
+		if !unique[i] {
+			t.Fatalf("nonce %d never handed out", i)
+		}
+	}
+}
+
+func TestNonceManagerResyncsOnNonceTooLow(t *testing.T) {
+	backend, key, auth := newNonceTestBackend(t)
+	m := NewNonceManager(backend, auth.From)
+	ctx := context.Background()
+
+	// Prime the manager, then consume the nonce behind its back.
+	if _, err := m.Send(ctx, auth, sendTransfer(backend)); err != nil {
+		t.Fatal(err)
+	}
+	backend.Commit()
+
+	external, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	if err != nil {
+		t.Fatal(err)
+	}
+	external.Nonce = big.NewInt(1)
+	external.Context = ctx
+	if _, err := sendTransfer(backend)(external); err != nil {
+		t.Fatal(err)
+	}
+	backend.Commit()
+
+	tx, err := m.Send(ctx, auth, sendTransfer(backend))
+	if err != nil {
+		t.Fatalf("send after external use: %v", err)
+	}
+	if tx.Nonce() != 2 {
+		t.Fatalf("got nonce %d, want 2", tx.Nonce())
+	}
+}
+
+func TestNonceManagerFillsDroppedGap(t *testing.T) {
+	backend, _, auth := newNonceTestBackend(t)
+	m := NewNonceManager(backend, auth.From)
+	ctx := context.Background()
+
+	// Nonce 0 is accepted locally but the transaction never lands.
+	dropped, err := m.Next(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Done(dropped)
+
+	first, err := m.Send(ctx, auth, sendTransfer(backend))
+	if err != nil {
+		t.Fatalf("send into gap: %v", err)
+	}
+	if first.Nonce() != dropped {
+		t.Fatalf("got nonce %d, want gap nonce %d", first.Nonce(), dropped)
+	}
+
+	second, err := m.Send(ctx, auth, sendTransfer(backend))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Nonce() != 1 {
+		t.Fatalf("got nonce %d, want 1", second.Nonce())
+	}
+	backend.Commit()
+
+	mined, err := backend.NonceAt(ctx, auth.From, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mined != 2 {
+		t.Fatalf("account nonce = %d, want 2", mined)
+	}
+}
+
+// droppingPool is a transaction pool that can lose transactions. Like a
+// node's, its pending nonce is the first nonce it has no transaction for.
+type droppingPool struct {
+	mu  sync.Mutex
+	txs map[uint64]*types.Transaction
+}
+
+func newDroppingPool() *droppingPool {
+	return &droppingPool{txs: make(map[uint64]*types.Transaction)}
+}
+
+func (p *droppingPool) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var nonce uint64
+	for p.txs[nonce] != nil {
+		nonce++
+	}
+	return nonce, nil
+}
+
+// send returns a send function that adds a transfer to to the pool
+func (p *droppingPool) send(to common.Address) func(*bind.TransactOpts) (*types.Transaction, error) {
+	return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		tx, err := opts.Signer(opts.From, types.NewTx(&types.LegacyTx{
+			Nonce:    opts.Nonce.Uint64(),
+			To:       &to,
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(params.GWei),
+		}))
+		if err != nil {
+			return nil, err
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.txs[tx.Nonce()] != nil {
+			return nil, errors.New("replacement transaction underpriced")
+		}
+		p.txs[tx.Nonce()] = tx
+		return tx, nil
+	}
+}
+
+func (p *droppingPool) drop(nonces ...uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, nonce := range nonces {
+		delete(p.txs, nonce)
+	}
+}
+
+func TestNonceManagerFillsDroppedTransaction(t *testing.T) {
+	_, _, auth := newNonceTestBackend(t)
+	pool := newDroppingPool()
+	m := NewNonceManager(pool, auth.From)
+	ctx := context.Background()
+	fill := pool.send(auth.From)
+
+	if filled, err := m.FillGaps(ctx, auth, fill); err != nil || len(filled) != 0 {
+		t.Fatalf("before any send: filled %d, %v", len(filled), err)
+	}
+	for i := 0; i < 5; i++ {
+		if _, err := m.Send(ctx, auth, pool.send(testRecipient)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if filled, err := m.FillGaps(ctx, auth, fill); err != nil || len(filled) != 0 {
+		t.Fatalf("without a gap: filled %d, %v", len(filled), err)
+	}
+
+	// The node loses transactions in the middle of the sequence; the ones
+	// after them stay queued until their nonces are filled
+	pool.drop(1, 3)
+	filled, err := m.FillGaps(ctx, auth, fill)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filled) != 2 || filled[0].Nonce() != 1 || filled[1].Nonce() != 3 {
+		t.Fatalf("filled %d transactions, want nonces 1 and 3", len(filled))
+	}
+	for _, tx := range filled {
+		if *tx.To() != auth.From || tx.Value().Sign() != 0 {
+			t.Errorf("nonce %d filled with a transfer of %s to %s, want a self-transfer", tx.Nonce(), tx.Value(), tx.To().Hex())
+		}
+	}
+	if pending, _ := pool.PendingNonceAt(ctx, auth.From); pending != 5 {
+		t.Errorf("pending nonce = %d, want 5", pending)
+	}
+
+	tx, err := m.Send(ctx, auth, pool.send(testRecipient))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Nonce() != 5 {
+		t.Errorf("next send got nonce %d, want 5", tx.Nonce())
+	}
+}
+
+func TestNonceManagerFillFailureRequeuesNonce(t *testing.T) {
+	_, _, auth := newNonceTestBackend(t)
+	pool := newDroppingPool()
+	m := NewNonceManager(pool, auth.From)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := m.Send(ctx, auth, pool.send(testRecipient)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pool.drop(1)
+
+	failing := func(*bind.TransactOpts) (*types.Transaction, error) {
+		return nil, errors.New("insufficient funds")
+	}
+	if _, err := m.FillGaps(ctx, auth, failing); err == nil {
+		t.Fatal("expected filling to fail")
+	}
+
+	// The next send takes the missing nonce instead
+	tx, err := m.Send(ctx, auth, pool.send(testRecipient))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Nonce() != 1 {
+		t.Errorf("got nonce %d, want the missing nonce 1", tx.Nonce())
+	}
+}
+
+func TestRepairNoncesSendsSelfTransfer(t *testing.T) {
+	client, sim, err := NewSimulatedClient(SimulatedOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	ctx := context.Background()
+
+	// A nonce handed out whose transaction never reached the node
+	lost, err := client.nonces.Next(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.nonces.Done(lost)
+
+	filled, err := client.RepairNonces(ctx, sim.Admin.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filled) != 1 || filled[0].Nonce() != lost || !IsCancelTx(filled[0]) {
+		t.Fatalf("filled %d transactions, want a self-transfer at nonce %d", len(filled), lost)
+	}
+	sim.Backend.Commit()
+	if mined, err := client.NonceAt(ctx, sim.Admin.Address); err != nil || mined != lost+1 {
+		t.Errorf("account nonce = %d, %v, want %d", mined, err, lost+1)
+	}
+	if filled, err := client.RepairNonces(ctx, sim.Admin.Address); err != nil || len(filled) != 0 {
+		t.Errorf("after repair: filled %d, %v", len(filled), err)
+	}
+	if _, err := client.RepairNonces(ctx, testRecipient); err == nil {
+		t.Error("repaired an account the client does not send from")
+	}
+}
+
+func TestNonceManagerReusesReleasedNonce(t *testing.T) {
+	backend, _, auth := newNonceTestBackend(t)
+	m := NewNonceManager(backend, auth.From)
+	ctx := context.Background()
+
+	failing := func(*bind.TransactOpts) (*types.Transaction, error) {
+		return nil, errors.New("execution reverted")
+	}
+	if _, err := m.Send(ctx, auth, failing); err == nil {
+		t.Fatal("expected send to fail")
+	}
+
+	tx, err := m.Send(ctx, auth, sendTransfer(backend))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Nonce() != 0 {
+		t.Fatalf("got nonce %d, want released nonce 0", tx.Nonce())
+	}
+}
+
+func TestIsNonceError(t *testing.T) {
+	cases := map[string]bool{
+		"nonce too low": true,
+		"already known": true,
+		"invalid transaction nonce: got 3, want 1":     true,
+		"replacement transaction underpriced":          true,
+		"execution reverted: Only admin can call this": false,
+	}
+	for msg, want := range cases {
+		if got := IsNonceError(errors.New(msg)); got != want {
+			t.Errorf("IsNonceError(%q) = %v, want %v", msg, got, want)
+		}
+	}
+	if IsNonceError(nil) {
+		t.Error("IsNonceError(nil) = true")
+	}
+}
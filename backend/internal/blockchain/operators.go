@@ -0,0 +1,162 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"voting-dapp/backend/internal/models"
+)
+
+// operator is an account the client sends write transactions from. Each
+// has its own nonce manager, so accounts do not wait on each other.
+type operator struct {
+	auth   *bind.TransactOpts
+	nonces *NonceManager
+}
+
+// AddOperators adds accounts that write transactions are dispatched across
+// in turn with the client's own. Each must be allowed to manage the contract
+// on the admin's behalf; VerifyOperators checks that they are. Operators are
+// meant to be added before the client sends transactions.
+func (c *Client) AddOperators(signers ...Signer) error {
+	if c.auth == nil {
+		return fmt.Errorf("no private key configured for transactions")
+	}
+	for _, signer := range signers {
+		address := signer.Address()
+		if c.operatorFor(address) != nil {
+			return fmt.Errorf("operator %s added twice", address.Hex())
+		}
+		c.operators = append(c.operators, &operator{
+			auth:   transactOpts(signer, c.chainID),
+			nonces: NewNonceManager(c.client, address),
+		})
+	}
+	return nil
+}
+
+// primary returns the client's own account
+func (c *Client) primary() *operator {
+	return &operator{auth: c.auth, nonces: c.nonces}
+}
+
+// nextOperator picks the account for the next write transaction, taking the
+// client's own account and its operators in turn
+func (c *Client) nextOperator() *operator {
+	n := uint64(len(c.operators)) + 1
+	i := (c.dispatched.Add(1) - 1) % n
+	if i == 0 {
+		return c.primary()
+	}
+	return c.operators[i-1]
+}
+
+// operatorFor returns the account with the given address, or nil if the
+// client does not send from it
+func (c *Client) operatorFor(address common.Address) *operator {
+	if c.auth != nil && c.auth.From == address {
+		return c.primary()
+	}
+	for _, op := range c.operators {
+		if op.auth.From == address {
+			return op
+		}
+	}
+	return nil
+}
+
+// GrantOperatorTxs grants an account the poll-manager and power-assigner
+// roles, so that write transactions may be dispatched from it, without
+// waiting for the grants to be mined
+func (c *Client) GrantOperatorTxs(account string) ([]*types.Transaction, error) {
+	txs := make([]*types.Transaction, 0, len(operatorRoles))
+	for _, role := range operatorRoles {
+		tx, err := c.GrantRoleTx(role, account)
+		if err != nil {
+			return txs, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+// isOperator reports whether an account holds every role the client's
+// write transactions need
+func (c *Client) isOperator(opts *bind.CallOpts, address common.Address) (bool, error) {
+	for _, role := range operatorRoles {
+		held, err := c.contract.HasRole(opts, roleIDs[role], address)
+		if err != nil || !held {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// VerifyOperators fails if any account the client sends from is neither
+// the contract admin nor holds the poll-manager and power-assigner roles
+func (c *Client) VerifyOperators(ctx context.Context) error {
+	statuses, err := c.Operators(ctx)
+	if err != nil {
+		return err
+	}
+	for _, status := range statuses {
+		if !status.Authorized {
+			return fmt.Errorf("account %s is neither the contract admin nor holds the %s and %s roles", status.Address, RolePollManager, RolePowerAssigner)
+		}
+	}
+	return nil
+}
+
+// Operators reports the balance, nonces and authorization of each account
+// the client sends transactions from, its own account first
+func (c *Client) Operators(ctx context.Context) ([]models.OperatorStatus, error) {
+	if c.auth == nil {
+		return []models.OperatorStatus{}, nil
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	admin, err := c.contract.Admin(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin: %v", err)
+	}
+
+	accounts := append([]*operator{c.primary()}, c.operators...)
+	statuses := make([]models.OperatorStatus, 0, len(accounts))
+	for _, op := range accounts {
+		address := op.auth.From
+		authorized, err := c.isOperator(opts, address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check operator %s: %v", address.Hex(), err)
+		}
+		balance, err := c.client.BalanceAt(ctx, address, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get balance of %s: %v", address.Hex(), err)
+		}
+		mined, err := c.client.NonceAt(ctx, address, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get nonce of %s: %v", address.Hex(), err)
+		}
+		pending, err := c.client.PendingNonceAt(ctx, address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pending nonce of %s: %v", address.Hex(), err)
+		}
+
+		status := models.OperatorStatus{
+			Address:      address.Hex(),
+			Admin:        address == admin,
+			Authorized:   authorized,
+			Balance:      balance.String(),
+			Nonce:        mined,
+			PendingNonce: pending,
+		}
+		if pending > mined {
+			status.Pending = pending - mined
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
@@ -0,0 +1,134 @@
+package blockchain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestOperatorsShareWrites(t *testing.T) {
+	client, sim, err := NewSimulatedClient(SimulatedOptions{Operators: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	ctx := context.Background()
+
+	head, err := sim.Backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := int64(head.Time) + 3600
+
+	// Writes go to the admin and each operator in turn, each account
+	// numbering its own transactions
+	accounts := []common.Address{sim.Admin.Address, sim.Operators[0].Address, sim.Operators[1].Address}
+	nonces := make(map[common.Address]uint64)
+	for _, account := range accounts {
+		if nonces[account], err = sim.Backend.PendingNonceAt(ctx, account); err != nil {
+			t.Fatal(err)
+		}
+	}
+	signer := types.LatestSignerForChainID(client.ChainID())
+	voter := sim.Admin.Address.Hex()
+	for i := 0; i < 2*len(accounts); i++ {
+		tx, err := client.AssignVotingPowerTx(voter, uint64(i+1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := accounts[i%len(accounts)]
+		if from != want {
+			t.Fatalf("transaction %d sent by %s, want %s", i, from.Hex(), want.Hex())
+		}
+		if tx.Nonce() != nonces[from] {
+			t.Errorf("transaction %d from %s has nonce %d, want %d", i, from.Hex(), tx.Nonce(), nonces[from])
+		}
+		nonces[from]++
+	}
+
+	// Polls record their sender as the creator, so they all come from the admin
+	for i := 0; i < len(accounts); i++ {
+		tx, err := client.CreatePollTx("Poll", "", []string{"Yes", "No"}, start, start+3600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if from, err := types.Sender(signer, tx); err != nil || from != sim.Admin.Address {
+			t.Errorf("poll %d sent by %s, %v, want the admin", i, from.Hex(), err)
+		}
+	}
+
+	statuses, err := client.Operators(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != len(accounts) {
+		t.Fatalf("got %d operators, want %d", len(statuses), len(accounts))
+	}
+	for i, status := range statuses {
+		if status.Address != accounts[i].Hex() || !status.Authorized || status.Admin != (i == 0) {
+			t.Errorf("operator %d = %+v", i, status)
+		}
+		want := uint64(2)
+		if i == 0 {
+			want += uint64(len(accounts))
+		}
+		if status.Pending != want || status.Balance == "0" {
+			t.Errorf("operator %s has %d pending and balance %s", status.Address, status.Pending, status.Balance)
+		}
+	}
+
+	sim.Backend.Commit()
+	if err := client.VerifyOperators(ctx); err != nil {
+		t.Error(err)
+	}
+	statuses, err = client.Operators(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, status := range statuses {
+		if status.Pending != 0 {
+			t.Errorf("operator %s has %d pending after mining", status.Address, status.Pending)
+		}
+	}
+
+	// An account the admin has not authorized is caught
+	stranger, err := simulatedAccount(2000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddOperators(NewKeySigner(stranger.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.VerifyOperators(ctx); err == nil || !strings.Contains(err.Error(), stranger.Address.Hex()) {
+		t.Errorf("unauthorized operator: %v", err)
+	}
+
+	// Operators need both roles, not just one
+	if _, err := client.GrantRoleTx(RolePollManager, stranger.Address.Hex()); err != nil {
+		t.Fatal(err)
+	}
+	sim.Backend.Commit()
+	if err := client.VerifyOperators(ctx); err == nil {
+		t.Error("operator with only the poll-manager role accepted")
+	}
+	if roles, err := client.GetRoles(stranger.Address.Hex()); err != nil || roles.Operator {
+		t.Errorf("roles = %+v, %v, want not an operator", roles, err)
+	}
+	if _, err := client.GrantOperatorTxs(stranger.Address.Hex()); err != nil {
+		t.Fatal(err)
+	}
+	sim.Backend.Commit()
+	if err := client.VerifyOperators(ctx); err != nil {
+		t.Errorf("operator granted both roles: %v", err)
+	}
+	if roles, err := client.GetRoles(stranger.Address.Hex()); err != nil || !roles.Operator {
+		t.Errorf("roles = %+v, %v, want an operator", roles, err)
+	}
+}
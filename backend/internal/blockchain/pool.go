@@ -0,0 +1,551 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"voting-dapp/backend/internal/models"
+)
+
+// PoolConfig configures how calls are routed across RPC endpoints
+type PoolConfig struct {
+	// HealthInterval is how often every endpoint's head is checked
+	HealthInterval time.Duration
+	// MaxLag is how many blocks an endpoint may trail the highest head
+	// before it is considered unhealthy
+	MaxLag uint64
+	// Quorum is how many endpoints must return the same result at the same
+	// block for critical reads. Quorum reads are off below 2.
+	Quorum int
+}
+
+// endpoint is one RPC node of a Pool
+type endpoint struct {
+	url       string
+	name      string
+	transport string
+
+	dialMu sync.Mutex
+	client *ethclient.Client
+
+	mu        sync.Mutex
+	healthy   bool
+	head      uint64
+	failures  int
+	lastErr   string
+	checkedAt time.Time
+}
+
+// Pool routes node calls across several RPC endpoints. Calls go to the
+// first healthy endpoint in configuration order and fail over to the next
+// when an endpoint cannot be reached or returns an error such as a rate
+// limit; answers the node itself gives, such as reverts, are passed
+// through. It implements Backend.
+type Pool struct {
+	endpoints []*endpoint
+	cfg       PoolConfig
+}
+
+var _ Backend = (*Pool)(nil)
+
+// NewPool creates a pool over rpcURLs. Endpoints are dialed on first use,
+// so an unreachable node does not prevent startup.
+func NewPool(rpcURLs []string, cfg PoolConfig) (*Pool, error) {
+	if len(rpcURLs) == 0 {
+		return nil, fmt.Errorf("no RPC endpoints configured")
+	}
+	if cfg.Quorum > len(rpcURLs) {
+		return nil, fmt.Errorf("quorum of %d exceeds the %d configured RPC endpoints", cfg.Quorum, len(rpcURLs))
+	}
+	if cfg.HealthInterval <= 0 {
+		cfg.HealthInterval = 10 * time.Second
+	}
+	if cfg.MaxLag == 0 {
+		cfg.MaxLag = 5
+	}
+
+	p := &Pool{cfg: cfg}
+	for _, u := range rpcURLs {
+		p.endpoints = append(p.endpoints, &endpoint{
+			url:       u,
+			name:      redactURL(u),
+			transport: transportOf(u),
+			healthy:   true,
+		})
+	}
+	return p, nil
+}
+
+// redactURL keeps the scheme and host of an endpoint URL
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "endpoint"
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// Close closes every endpoint connection
+func (p *Pool) Close() {
+	for _, e := range p.endpoints {
+		e.dialMu.Lock()
+		if e.client != nil {
+			e.client.Close()
+			e.client = nil
+		}
+		e.dialMu.Unlock()
+	}
+}
+
+// Health returns the state of every endpoint
+func (p *Pool) Health() []models.EndpointHealth {
+	health := make([]models.EndpointHealth, len(p.endpoints))
+	for i, e := range p.endpoints {
+		e.mu.Lock()
+		health[i] = models.EndpointHealth{
+			URL:       e.name,
+			Transport: e.transport,
+			Healthy:   e.healthy,
+			HeadBlock: e.head,
+			Failures:  e.failures,
+			LastError: e.lastErr,
+		}
+		if !e.checkedAt.IsZero() {
+			checkedAt := e.checkedAt
+			health[i].CheckedAt = &checkedAt
+		}
+		e.mu.Unlock()
+	}
+	return health
+}
+
+// Run checks every endpoint's head each health interval until ctx is
+// canceled. An endpoint is unhealthy while it cannot be reached or trails
+// the highest head by more than the allowed lag.
+func (p *Pool) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.HealthInterval)
+	defer ticker.Stop()
+
+	for {
+		p.check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check reads every endpoint's head and updates its health
+func (p *Pool) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.HealthInterval)
+	defer cancel()
+
+	heads := p.heads(ctx, p.endpoints)
+	if ctx.Err() != nil {
+		return
+	}
+
+	var best uint64
+	for _, h := range heads {
+		if h.err == nil && h.number > best {
+			best = h.number
+		}
+	}
+	now := time.Now()
+	for _, h := range heads {
+		e := h.endpoint
+		e.mu.Lock()
+		e.checkedAt = now
+		switch {
+		case h.err != nil:
+			e.healthy = false
+			e.failures++
+			e.lastErr = h.err.Error()
+		case h.number+p.cfg.MaxLag < best:
+			e.healthy = false
+			e.head = h.number
+			e.lastErr = fmt.Sprintf("%d blocks behind", best-h.number)
+		default:
+			e.healthy = true
+			e.head = h.number
+			e.failures = 0
+			e.lastErr = ""
+		}
+		e.mu.Unlock()
+	}
+}
+
+// endpointHead is an endpoint's latest block number or the error reading it
+type endpointHead struct {
+	endpoint *endpoint
+	number   uint64
+	err      error
+}
+
+// heads reads the latest block number of each endpoint concurrently
+func (p *Pool) heads(ctx context.Context, endpoints []*endpoint) []endpointHead {
+	heads := make([]endpointHead, len(endpoints))
+	var wg sync.WaitGroup
+	for i, e := range endpoints {
+		wg.Add(1)
+		go func(i int, e *endpoint) {
+			defer wg.Done()
+			heads[i].endpoint = e
+			client, err := e.conn(ctx)
+			if err != nil {
+				heads[i].err = err
+				return
+			}
+			heads[i].number, heads[i].err = client.BlockNumber(ctx)
+		}(i, e)
+	}
+	wg.Wait()
+	return heads
+}
+
+// conn returns the endpoint's client, dialing it if needed
+func (e *endpoint) conn(ctx context.Context) (*ethclient.Client, error) {
+	e.dialMu.Lock()
+	defer e.dialMu.Unlock()
+	if e.client == nil {
+		client, err := ethclient.DialContext(ctx, e.url)
+		if err != nil {
+			return nil, err
+		}
+		e.client = client
+	}
+	return e.client, nil
+}
+
+// failed marks the endpoint unhealthy until its next successful check
+func (e *endpoint) failed(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.healthy = false
+	e.failures++
+	e.lastErr = err.Error()
+}
+
+// candidates returns the healthy endpoints in configuration order followed
+// by the unhealthy ones, which are tried only when every healthy one fails
+func (p *Pool) candidates() []*endpoint {
+	var healthy, unhealthy []*endpoint
+	for _, e := range p.endpoints {
+		e.mu.Lock()
+		ok := e.healthy
+		e.mu.Unlock()
+		if ok {
+			healthy = append(healthy, e)
+		} else {
+			unhealthy = append(unhealthy, e)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// revertErrorCode is the JSON-RPC error code nodes answer a reverted call with
+const revertErrorCode = 3
+
+// answered marks an error as an endpoint's answer to a call, one that every
+// other endpoint would give as well
+type answered struct {
+	error
+}
+
+func (a answered) Unwrap() error {
+	return a.error
+}
+
+// isTransportError reports whether err means the endpoint did not answer
+// the call and another one should be tried: it is unreachable, rate limited,
+// behind on the block asked for or failing internally. Only reverts,
+// missing results and errors marked as answered are answers.
+func isTransportError(err error) bool {
+	if errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	var a answered
+	if errors.As(err, &a) {
+		return false
+	}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) && dataErr.ErrorData() != nil {
+		return false
+	}
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != revertErrorCode
+}
+
+// poolCall runs fn against each candidate endpoint until one answers
+func poolCall[T any](ctx context.Context, p *Pool, fn func(*ethclient.Client) (T, error)) (T, error) {
+	var zero T
+	var failures []string
+	for _, e := range p.candidates() {
+		client, err := e.conn(ctx)
+		if err == nil {
+			var v T
+			if v, err = fn(client); err == nil || !isTransportError(err) {
+				var a answered
+				if errors.As(err, &a) {
+					err = a.error
+				}
+				return v, err
+			}
+		}
+		if ctx.Err() != nil {
+			return zero, ctx.Err()
+		}
+		e.failed(err)
+		failures = append(failures, fmt.Sprintf("%s: %v", e.name, err))
+	}
+	return zero, fmt.Errorf("all RPC endpoints failed: %s", strings.Join(failures, "; "))
+}
+
+// poolSubscribe opens a subscription on the first candidate endpoint that
+// supports them
+func poolSubscribe(ctx context.Context, p *Pool, fn func(*ethclient.Client) (ethereum.Subscription, error)) (ethereum.Subscription, error) {
+	var failures []string
+	for _, e := range p.candidates() {
+		client, err := e.conn(ctx)
+		if err == nil {
+			var sub ethereum.Subscription
+			if sub, err = fn(client); err == nil {
+				return sub, nil
+			}
+			if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+				continue
+			}
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		e.failed(err)
+		failures = append(failures, fmt.Sprintf("%s: %v", e.name, err))
+	}
+	if len(failures) == 0 {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	return nil, fmt.Errorf("all RPC endpoints failed: %s", strings.Join(failures, "; "))
+}
+
+// CodeAt returns the code of an account
+func (p *Pool) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return poolCall(ctx, p, func(c *ethclient.Client) ([]byte, error) { return c.CodeAt(ctx, account, blockNumber) })
+}
+
+// CallContract executes a contract call
+func (p *Pool) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return poolCall(ctx, p, func(c *ethclient.Client) ([]byte, error) { return c.CallContract(ctx, call, blockNumber) })
+}
+
+// BatchCallContext sends a batch of JSON-RPC requests to one endpoint
+func (p *Pool) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	_, err := poolCall(ctx, p, func(c *ethclient.Client) (struct{}, error) {
+		return struct{}{}, c.Client().BatchCallContext(ctx, b)
+	})
+	return err
+}
+
+// HeaderByNumber returns a block header, or the latest one if number is nil
+func (p *Pool) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return poolCall(ctx, p, func(c *ethclient.Client) (*types.Header, error) { return c.HeaderByNumber(ctx, number) })
+}
+
+// PendingCodeAt returns the code of an account in the pending state
+func (p *Pool) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return poolCall(ctx, p, func(c *ethclient.Client) ([]byte, error) { return c.PendingCodeAt(ctx, account) })
+}
+
+// PendingNonceAt returns the account nonce in the pending state
+func (p *Pool) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return poolCall(ctx, p, func(c *ethclient.Client) (uint64, error) { return c.PendingNonceAt(ctx, account) })
+}
+
+// NonceAt returns the account nonce at a block
+func (p *Pool) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return poolCall(ctx, p, func(c *ethclient.Client) (uint64, error) { return c.NonceAt(ctx, account, blockNumber) })
+}
+
+// BalanceAt returns the balance of an account at the given block, the
+// latest if nil
+func (p *Pool) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return poolCall(ctx, p, func(c *ethclient.Client) (*big.Int, error) { return c.BalanceAt(ctx, account, blockNumber) })
+}
+
+// SuggestGasPrice returns a gas price for a legacy transaction
+func (p *Pool) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return poolCall(ctx, p, func(c *ethclient.Client) (*big.Int, error) { return c.SuggestGasPrice(ctx) })
+}
+
+// ChainID returns the chain ID of the network
+func (p *Pool) ChainID(ctx context.Context) (*big.Int, error) {
+	return poolCall(ctx, p, func(c *ethclient.Client) (*big.Int, error) { return c.ChainID(ctx) })
+}
+
+// SuggestGasTipCap returns a priority fee for a dynamic fee transaction
+func (p *Pool) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return poolCall(ctx, p, func(c *ethclient.Client) (*big.Int, error) { return c.SuggestGasTipCap(ctx) })
+}
+
+// EstimateGas estimates the gas a call needs
+func (p *Pool) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return poolCall(ctx, p, func(c *ethclient.Client) (uint64, error) { return c.EstimateGas(ctx, call) })
+}
+
+// SendTransaction submits a signed transaction. Resending it to another
+// endpoint after a failure is safe since it has the same hash; a rejection
+// of its nonce is the answer every endpoint would give.
+func (p *Pool) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	_, err := poolCall(ctx, p, func(c *ethclient.Client) (struct{}, error) {
+		err := c.SendTransaction(ctx, tx)
+		if IsNonceError(err) {
+			err = answered{err}
+		}
+		return struct{}{}, err
+	})
+	return err
+}
+
+// FeeHistory returns the base fees and priority fees of recent blocks
+func (p *Pool) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return poolCall(ctx, p, func(c *ethclient.Client) (*ethereum.FeeHistory, error) {
+		return c.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+}
+
+// FilterLogs returns the logs matching a query
+func (p *Pool) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	return poolCall(ctx, p, func(c *ethclient.Client) ([]types.Log, error) { return c.FilterLogs(ctx, query) })
+}
+
+// SubscribeFilterLogs subscribes to logs matching a query
+func (p *Pool) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return poolSubscribe(ctx, p, func(c *ethclient.Client) (ethereum.Subscription, error) { return c.SubscribeFilterLogs(ctx, query, ch) })
+}
+
+// SubscribeNewHead subscribes to new chain heads
+func (p *Pool) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return poolSubscribe(ctx, p, func(c *ethclient.Client) (ethereum.Subscription, error) { return c.SubscribeNewHead(ctx, ch) })
+}
+
+// TransactionReceipt returns the receipt of a mined transaction
+func (p *Pool) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return poolCall(ctx, p, func(c *ethclient.Client) (*types.Receipt, error) { return c.TransactionReceipt(ctx, txHash) })
+}
+
+// TransactionByHash returns a transaction and whether it is still pending
+func (p *Pool) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	type result struct {
+		tx      *types.Transaction
+		pending bool
+	}
+	r, err := poolCall(ctx, p, func(c *ethclient.Client) (result, error) {
+		tx, pending, err := c.TransactionByHash(ctx, hash)
+		return result{tx, pending}, err
+	})
+	return r.tx, r.pending, err
+}
+
+// quorumCaller is a contract caller whose calls only succeed when enough
+// endpoints agree on the result
+type quorumCaller struct {
+	pool *Pool
+}
+
+// CodeAt returns the code of an account from any endpoint
+func (q *quorumCaller) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return q.pool.CodeAt(ctx, account, blockNumber)
+}
+
+// CallContract runs the call on every endpoint that has reached the block
+// and returns the output, or revert, that a quorum of them returned. Unless
+// blockNumber is set, the call is pinned to the newest block a quorum of
+// endpoints has reached, so that every answer is for the same state.
+func (q *quorumCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	p, quorum := q.pool, q.pool.cfg.Quorum
+
+	var endpoints []*endpoint
+	if blockNumber == nil {
+		var heads []endpointHead
+		for _, h := range p.heads(ctx, p.endpoints) {
+			if h.err == nil {
+				heads = append(heads, h)
+			}
+		}
+		if len(heads) < quorum {
+			return nil, fmt.Errorf("quorum of %d not reached: only %d RPC endpoints reachable", quorum, len(heads))
+		}
+		sort.Slice(heads, func(i, j int) bool { return heads[i].number > heads[j].number })
+		pinned := heads[quorum-1].number
+		blockNumber = new(big.Int).SetUint64(pinned)
+		for _, h := range heads {
+			if h.number >= pinned {
+				endpoints = append(endpoints, h.endpoint)
+			}
+		}
+	} else {
+		endpoints = p.endpoints
+	}
+
+	type answer struct {
+		output []byte
+		err    error
+	}
+	answers := make([]answer, len(endpoints))
+	var wg sync.WaitGroup
+	for i, e := range endpoints {
+		wg.Add(1)
+		go func(i int, e *endpoint) {
+			defer wg.Done()
+			client, err := e.conn(ctx)
+			if err != nil {
+				answers[i].err = err
+				return
+			}
+			answers[i].output, answers[i].err = client.CallContract(ctx, call, blockNumber)
+		}(i, e)
+	}
+	wg.Wait()
+
+	// Tally identical outputs and identical node errors; unreachable
+	// endpoints do not count either way
+	key := func(a answer) (string, bool) {
+		if a.err == nil {
+			return "ok:" + common.Bytes2Hex(a.output), true
+		}
+		if !isTransportError(a.err) {
+			return "err:" + a.err.Error(), true
+		}
+		return "", false
+	}
+	votes := make(map[string]int)
+	var unreachable int
+	for _, a := range answers {
+		if k, ok := key(a); ok {
+			votes[k]++
+		} else {
+			unreachable++
+		}
+	}
+	for _, a := range answers {
+		if k, ok := key(a); ok && votes[k] >= quorum {
+			return a.output, a.err
+		}
+	}
+	return nil, fmt.Errorf("quorum of %d not reached at block %s: %d distinct answers, %d endpoints unreachable",
+		quorum, blockNumber, len(votes), unreachable)
+}
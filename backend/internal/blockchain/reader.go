@@ -0,0 +1,321 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"voting-dapp/backend/internal/models"
+)
+
+// ErrUnknownBlock is returned when reading at a block the node does not have
+var ErrUnknownBlock = errors.New("unknown block")
+
+// Reader reads the contract's state as of one block, so that every call
+// made through it, and every call within one of its methods, sees the same
+// state. The client's own reader is not pinned and reads the latest block.
+type Reader struct {
+	client *Client
+	number *big.Int
+	block  models.BlockRef
+	time   int64
+}
+
+// At returns a reader of the contract's state as of a block, or as of the
+// current head if number is nil
+func (c *Client) At(number *big.Int) (*Reader, error) {
+	header, err := c.client.HeaderByNumber(context.Background(), number)
+	if errors.Is(err, ethereum.NotFound) || (err == nil && header == nil) {
+		return nil, fmt.Errorf("%w %v", ErrUnknownBlock, number)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block: %v", err)
+	}
+
+	return &Reader{
+		client: c,
+		number: header.Number,
+		block: models.BlockRef{
+			BlockNumber: header.Number.Uint64(),
+			BlockHash:   header.Hash().Hex(),
+		},
+		time: int64(header.Time),
+	}, nil
+}
+
+// BlockAtTime returns the number of the last block mined at or before
+// timestamp, found by binary search over block headers
+func (c *Client) BlockAtTime(timestamp int64) (*big.Int, error) {
+	ctx := context.Background()
+	headerTime := func(number *big.Int) (int64, *big.Int, error) {
+		header, err := c.client.HeaderByNumber(ctx, number)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to get block: %v", err)
+		}
+		return int64(header.Time), header.Number, nil
+	}
+
+	headTime, head, err := headerTime(nil)
+	if err != nil {
+		return nil, err
+	}
+	if headTime <= timestamp {
+		return head, nil
+	}
+	genesisTime, _, err := headerTime(new(big.Int))
+	if err != nil {
+		return nil, err
+	}
+	if genesisTime > timestamp {
+		return nil, fmt.Errorf("%w before time %d", ErrUnknownBlock, timestamp)
+	}
+
+	// Block lo is mined at or before timestamp and block hi after it
+	lo, hi := uint64(0), head.Uint64()
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		t, _, err := headerTime(new(big.Int).SetUint64(mid))
+		if err != nil {
+			return nil, err
+		}
+		if t <= timestamp {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return new(big.Int).SetUint64(lo), nil
+}
+
+// Block returns the block the reader reads at; it is zero for the client's
+// own reader
+func (r *Reader) Block() models.BlockRef {
+	return r.block
+}
+
+// BlockTime returns the timestamp of the block the reader reads at, which
+// for the client's own reader is the current head
+func (r *Reader) BlockTime() (int64, error) {
+	if r.number != nil {
+		return r.time, nil
+	}
+	header, err := r.client.client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block: %v", err)
+	}
+	return int64(header.Time), nil
+}
+
+// opts returns the options of calls made at the reader's block
+func (r *Reader) opts() *bind.CallOpts {
+	return &bind.CallOpts{BlockNumber: r.number}
+}
+
+// GetPoll retrieves poll details
+func (r *Reader) GetPoll(pollID uint64) (*models.Poll, error) {
+	poll, err := r.client.contract.GetPoll(r.opts(), big.NewInt(int64(pollID)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Poll{
+		ID:          pollID,
+		Title:       poll.Title,
+		Description: poll.Description,
+		Options:     poll.Options,
+		StartTime:   poll.StartTime.Int64(),
+		EndTime:     poll.EndTime.Int64(),
+		Creator:     poll.Creator.Hex(),
+		IsActive:    poll.IsActive,
+		IsCanceled:  poll.IsCanceled,
+		TotalVotes:  poll.TotalVotes.Uint64(),
+	}, nil
+}
+
+// GetPollResults retrieves poll results
+func (r *Reader) GetPollResults(pollID uint64) (*models.PollResults, error) {
+	results, err := r.client.criticalCaller().GetPollResults(r.opts(), big.NewInt(int64(pollID)))
+	if err != nil {
+		return nil, err
+	}
+
+	voteCounts := make([]uint64, len(results.VoteCountsArray))
+	for i, count := range results.VoteCountsArray {
+		voteCounts[i] = count.Uint64()
+	}
+
+	return &models.PollResults{
+		PollID:     pollID,
+		Options:    results.OptionNames,
+		VoteCounts: voteCounts,
+		TotalVotes: results.TotalVotes.Uint64(),
+	}, nil
+}
+
+// GetVotingPower gets voting power for an address
+func (r *Reader) GetVotingPower(voter string) (uint64, error) {
+	voterAddr := common.HexToAddress(voter)
+	power, err := r.client.contract.VotingPower(r.opts(), voterAddr)
+	if err != nil {
+		return 0, err
+	}
+	return power.Uint64(), nil
+}
+
+// GetVoterStatus gets voter status for a poll. The status and voting power
+// are read in one batch, so they agree even on the latest block.
+func (r *Reader) GetVoterStatus(pollID uint64, voter string) (*models.VoterStatus, error) {
+	statuses, err := r.GetVoterStatuses([]uint64{pollID}, voter)
+	if failed, ok := AsPartialRead(err); ok {
+		return nil, errors.New(failed[0].Error)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return statuses[pollID], nil
+}
+
+// GetPollStatus gets the status of a poll
+func (r *Reader) GetPollStatus(pollID uint64) (string, error) {
+	return r.client.criticalCaller().GetPollStatus(r.opts(), big.NewInt(int64(pollID)))
+}
+
+// GetAllPollIds gets all poll IDs
+func (r *Reader) GetAllPollIds() ([]uint64, error) {
+	ids, err := r.client.contract.GetAllPollIds(r.opts())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]uint64, len(ids))
+	for i, id := range ids {
+		result[i] = id.Uint64()
+	}
+	return result, nil
+}
+
+// GetPollsByCreator gets the IDs of the polls an address created
+func (r *Reader) GetPollsByCreator(creator string) ([]uint64, error) {
+	ids, err := r.client.contract.GetPollsByCreator(r.opts(), common.HexToAddress(creator))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]uint64, len(ids))
+	for i, id := range ids {
+		result[i] = id.Uint64()
+	}
+	return result, nil
+}
+
+// GetPolls reads several polls at one block. Polls that cannot be read are
+// left out and reported in a *PartialReadError.
+func (r *Reader) GetPolls(pollIDs []uint64) ([]*models.Poll, error) {
+	calls := make([]viewCall, len(pollIDs))
+	for i, id := range pollIDs {
+		calls[i] = viewCall{method: "getPoll", args: []interface{}{new(big.Int).SetUint64(id)}}
+	}
+	results, err := r.client.batchCall(r.client.batchCaller(false), r.number, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	polls := make([]*models.Poll, 0, len(pollIDs))
+	var failures []models.ReadFailure
+	for i, id := range pollIDs {
+		var out pollOutput
+		if err := unpack("getPoll", results[i], &out); err != nil {
+			failures = append(failures, models.ReadFailure{PollID: id, Error: err.Error()})
+			continue
+		}
+		polls = append(polls, &models.Poll{
+			ID:          id,
+			Title:       out.Title,
+			Description: out.Description,
+			Options:     out.Options,
+			StartTime:   out.StartTime.Int64(),
+			EndTime:     out.EndTime.Int64(),
+			Creator:     out.Creator.Hex(),
+			IsActive:    out.IsActive,
+			IsCanceled:  out.IsCanceled,
+			TotalVotes:  out.TotalVotes.Uint64(),
+		})
+	}
+	return polls, PartialRead(failures)
+}
+
+// GetPollsResults reads the results of several polls at one block. Results
+// that cannot be read are left out and reported in a *PartialReadError.
+func (r *Reader) GetPollsResults(pollIDs []uint64) ([]*models.PollResults, error) {
+	calls := make([]viewCall, len(pollIDs))
+	for i, id := range pollIDs {
+		calls[i] = viewCall{method: "getPollResults", args: []interface{}{new(big.Int).SetUint64(id)}}
+	}
+	results, err := r.client.batchCall(r.client.batchCaller(true), r.number, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	pollResults := make([]*models.PollResults, 0, len(pollIDs))
+	var failures []models.ReadFailure
+	for i, id := range pollIDs {
+		var out resultsOutput
+		if err := unpack("getPollResults", results[i], &out); err != nil {
+			failures = append(failures, models.ReadFailure{PollID: id, Error: err.Error()})
+			continue
+		}
+		voteCounts := make([]uint64, len(out.VoteCountsArray))
+		for j, count := range out.VoteCountsArray {
+			voteCounts[j] = count.Uint64()
+		}
+		pollResults = append(pollResults, &models.PollResults{
+			PollID:     id,
+			Options:    out.OptionNames,
+			VoteCounts: voteCounts,
+			TotalVotes: out.TotalVotes.Uint64(),
+		})
+	}
+	return pollResults, PartialRead(failures)
+}
+
+// GetVoterStatuses reads a voter's status in several polls, and their
+// voting power, at one block. Statuses are keyed by poll ID; those that
+// cannot be read are left out and reported in a *PartialReadError.
+func (r *Reader) GetVoterStatuses(pollIDs []uint64, voter string) (map[uint64]*models.VoterStatus, error) {
+	voterAddr := common.HexToAddress(voter)
+	calls := make([]viewCall, 0, len(pollIDs)+1)
+	calls = append(calls, viewCall{method: "votingPower", args: []interface{}{voterAddr}})
+	for _, id := range pollIDs {
+		calls = append(calls, viewCall{method: "getVoterStatus", args: []interface{}{new(big.Int).SetUint64(id), voterAddr}})
+	}
+	results, err := r.client.batchCall(r.client.batchCaller(false), r.number, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	var power *big.Int
+	if err := unpack("votingPower", results[0], &power); err != nil {
+		return nil, fmt.Errorf("failed to get voting power: %v", err)
+	}
+
+	statuses := make(map[uint64]*models.VoterStatus, len(pollIDs))
+	var failures []models.ReadFailure
+	for i, id := range pollIDs {
+		var out voterStatusOutput
+		if err := unpack("getVoterStatus", results[i+1], &out); err != nil {
+			failures = append(failures, models.ReadFailure{PollID: id, Error: err.Error()})
+			continue
+		}
+		statuses[id] = &models.VoterStatus{
+			HasVoted:    out.HasVotedStatus,
+			OptionIndex: out.OptionIndex.Uint64(),
+			VotingPower: power.Uint64(),
+		}
+	}
+	return statuses, PartialRead(failures)
+}
@@ -0,0 +1,176 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// EIP-712 type hashes of the contract's domain and signed Vote struct
+var (
+	domainTypeHash = crypto.Keccak256Hash([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	voteTypeHash   = crypto.Keccak256Hash([]byte("Vote(uint256 pollId,uint256 optionIndex,uint256 nonce,uint256 deadline)"))
+)
+
+// Signed votes the relayer refuses wrap one of these errors: the first when
+// the signature was not made by the voter, the second when the vote is
+// expired, reuses a nonce or would be reverted by the contract
+var (
+	ErrInvalidVoteSignature = errors.New("invalid vote signature")
+	ErrVoteRejected         = errors.New("vote rejected")
+)
+
+// SignedVote is a vote the voter authorized by signing it as EIP-712 typed
+// data, to be relayed through voteBySig
+type SignedVote struct {
+	PollID      uint64
+	OptionIndex uint64
+	Voter       common.Address
+	Nonce       uint64
+	Deadline    int64
+	// Signature is the 65 byte [R || S || V] signature, with V as 0/1 or 27/28
+	Signature []byte
+}
+
+// VoteNonce returns the nonce a voter must sign into their next relayed vote
+func (c *Client) VoteNonce(voter string) (uint64, error) {
+	nonce, err := c.contract.Nonces(nil, common.HexToAddress(voter))
+	if err != nil {
+		return 0, err
+	}
+	return nonce.Uint64(), nil
+}
+
+// VoteDigest returns the EIP-712 digest a voter signs to authorize a vote
+func (c *Client) VoteDigest(pollID, optionIndex, nonce uint64, deadline int64) (common.Hash, error) {
+	domain, err := c.contract.DOMAINSEPARATOR(nil)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get domain separator: %v", err)
+	}
+	return HashVote(domain, pollID, optionIndex, nonce, deadline), nil
+}
+
+// VerifySignedVote checks that a signed vote was produced by its voter, has
+// not expired and carries the voter's current nonce
+func (c *Client) VerifySignedVote(vote *SignedVote) error {
+	if vote.Deadline < 0 || time.Now().Unix() > vote.Deadline {
+		return fmt.Errorf("%w: signature expired", ErrVoteRejected)
+	}
+
+	digest, err := c.VoteDigest(vote.PollID, vote.OptionIndex, vote.Nonce, vote.Deadline)
+	if err != nil {
+		return err
+	}
+	if err := CheckVoteSigner(digest, vote); err != nil {
+		return err
+	}
+
+	nonce, err := c.VoteNonce(vote.Voter.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to get vote nonce: %v", err)
+	}
+	if vote.Nonce != nonce {
+		return fmt.Errorf("%w: invalid nonce %d, expected %d", ErrVoteRejected, vote.Nonce, nonce)
+	}
+	return nil
+}
+
+// DomainSeparator computes the contract's EIP-712 domain separator without
+// calling it
+func DomainSeparator(chainID *big.Int, contract common.Address) [32]byte {
+	return crypto.Keccak256Hash(
+		domainTypeHash.Bytes(),
+		crypto.Keccak256([]byte("Voting")),
+		crypto.Keccak256([]byte("1")),
+		common.LeftPadBytes(chainID.Bytes(), 32),
+		common.LeftPadBytes(contract.Bytes(), 32),
+	)
+}
+
+// HashVote returns the EIP-712 digest of a vote under the given domain
+func HashVote(domain [32]byte, pollID, optionIndex, nonce uint64, deadline int64) common.Hash {
+	structHash := crypto.Keccak256(
+		voteTypeHash.Bytes(),
+		common.LeftPadBytes(new(big.Int).SetUint64(pollID).Bytes(), 32),
+		common.LeftPadBytes(new(big.Int).SetUint64(optionIndex).Bytes(), 32),
+		common.LeftPadBytes(new(big.Int).SetUint64(nonce).Bytes(), 32),
+		common.LeftPadBytes(big.NewInt(deadline).Bytes(), 32),
+	)
+	return crypto.Keccak256Hash([]byte("\x19\x01"), domain[:], structHash)
+}
+
+// CheckVoteSigner checks that the vote's signature over digest was made by
+// its voter
+func CheckVoteSigner(digest common.Hash, vote *SignedVote) error {
+	if len(vote.Signature) != crypto.SignatureLength {
+		return fmt.Errorf("%w: invalid signature length %d", ErrInvalidVoteSignature, len(vote.Signature))
+	}
+
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, vote.Signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pub, err := crypto.SigToPub(digest.Bytes(), sig)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidVoteSignature, err)
+	}
+	if signer := crypto.PubkeyToAddress(*pub); signer != vote.Voter {
+		return fmt.Errorf("%w: signature was made by %s, not %s", ErrInvalidVoteSignature, signer.Hex(), vote.Voter.Hex())
+	}
+	return nil
+}
+
+// VoteBySigTx verifies a signed vote and relays it without waiting for it
+// to be mined. The relayer pays the gas; the vote is attributed to the signer.
+func (c *Client) VoteBySigTx(vote *SignedVote) (*types.Transaction, error) {
+	if c.auth == nil {
+		return nil, fmt.Errorf("no private key configured for transactions")
+	}
+	if err := c.VerifySignedVote(vote); err != nil {
+		return nil, err
+	}
+
+	var r, s [32]byte
+	copy(r[:], vote.Signature[:32])
+	copy(s[:], vote.Signature[32:64])
+	v := vote.Signature[crypto.RecoveryIDOffset]
+	if v < 27 {
+		v += 27
+	}
+
+	tx, err := c.transact(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.contract.VoteBySig(opts,
+			new(big.Int).SetUint64(vote.PollID),
+			new(big.Int).SetUint64(vote.OptionIndex),
+			new(big.Int).SetUint64(vote.Nonce),
+			big.NewInt(vote.Deadline),
+			v, r, s,
+		)
+	})
+	if isRevert(err) {
+		return nil, fmt.Errorf("%w: %v", ErrVoteRejected, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to relay vote: %w", err)
+	}
+
+	return tx, nil
+}
+
+// VoteBySig verifies and relays a signed vote
+func (c *Client) VoteBySig(vote *SignedVote) error {
+	tx, err := c.VoteBySigTx(vote)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.waitMined(tx)
+	return err
+}
@@ -0,0 +1,230 @@
+package blockchain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// newRelayTestClient deploys the contract on a simulated backend, opens a
+// poll that has already started and gives voterKey's account voting power
+func newRelayTestClient(t *testing.T, voterKey *ecdsa.PrivateKey) (*Client, *backends.SimulatedBackend) {
+	t.Helper()
+
+	adminKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth, err := bind.NewKeyedTransactorWithChainID(adminKey, big.NewInt(1337))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		auth.From: {Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))},
+	}, 10000000)
+	t.Cleanup(func() { backend.Close() })
+
+	addr, _, contract, err := DeployVoting(auth, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.Commit()
+
+	head, err := backend.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := int64(head.Time) + 20
+	if _, err := contract.CreatePoll(auth, "Poll", "", []string{"A", "B"}, big.NewInt(start), big.NewInt(start+86400)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := contract.AssignVotingPower(auth, crypto.PubkeyToAddress(voterKey.PublicKey), big.NewInt(7)); err != nil {
+		t.Fatal(err)
+	}
+	backend.Commit()
+	if err := backend.AdjustTime(time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	backend.Commit()
+
+	return &Client{
+		client:       backend,
+		contract:     contract,
+		auth:         auth,
+		nonces:       NewNonceManager(backend, auth.From),
+		contractAddr: addr,
+		txTimeout:    DefaultTxTimeout,
+		fees:         DefaultFeePolicy(),
+	}, backend
+}
+
+func signVote(t *testing.T, c *Client, key *ecdsa.PrivateKey, pollID, optionIndex, nonce uint64, deadline int64) *SignedVote {
+	t.Helper()
+
+	digest, err := c.VoteDigest(pollID, optionIndex, nonce, deadline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := crypto.Sign(digest.Bytes(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+
+	return &SignedVote{
+		PollID:      pollID,
+		OptionIndex: optionIndex,
+		Voter:       crypto.PubkeyToAddress(key.PublicKey),
+		Nonce:       nonce,
+		Deadline:    deadline,
+		Signature:   sig,
+	}
+}
+
+func TestVoteBySigAttributesVoteToSigner(t *testing.T) {
+	voterKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, backend := newRelayTestClient(t, voterKey)
+	voter := crypto.PubkeyToAddress(voterKey.PublicKey)
+	deadline := time.Now().Add(time.Hour).Unix()
+
+	vote := signVote(t, c, voterKey, 1, 1, 0, deadline)
+	if _, err := c.VoteBySigTx(vote); err != nil {
+		t.Fatalf("relay: %v", err)
+	}
+	backend.Commit()
+
+	it, err := c.contract.FilterVoted(nil, nil, []common.Address{voter})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer it.Close()
+	if !it.Next() {
+		t.Fatal("no Voted event for signer")
+	}
+	if it.Event.OptionIndex.Uint64() != 1 || it.Event.Weight.Uint64() != 7 {
+		t.Fatalf("got option %d weight %d, want option 1 weight 7", it.Event.OptionIndex, it.Event.Weight)
+	}
+
+	adminVoted, err := c.contract.HasVoted(nil, big.NewInt(1), c.auth.From)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if adminVoted {
+		t.Fatal("vote was attributed to the relayer")
+	}
+
+	nonce, err := c.VoteNonce(voter.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce != 1 {
+		t.Fatalf("vote nonce = %d, want 1", nonce)
+	}
+
+	// The same signature cannot be relayed twice.
+	if _, err := c.VoteBySigTx(vote); err == nil || !strings.Contains(err.Error(), "invalid nonce") {
+		t.Fatalf("replay: got %v, want invalid nonce", err)
+	}
+}
+
+func TestVerifySignedVoteRejectsInvalidVotes(t *testing.T) {
+	voterKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ := newRelayTestClient(t, voterKey)
+	deadline := time.Now().Add(time.Hour).Unix()
+
+	wrongSigner := signVote(t, c, otherKey, 1, 0, 0, deadline)
+	wrongSigner.Voter = crypto.PubkeyToAddress(voterKey.PublicKey)
+
+	tampered := signVote(t, c, voterKey, 1, 0, 0, deadline)
+	tampered.OptionIndex = 1
+
+	cases := map[string]struct {
+		vote *SignedVote
+		want string
+		is   error
+	}{
+		"valid":        {signVote(t, c, voterKey, 1, 0, 0, deadline), "", nil},
+		"expired":      {signVote(t, c, voterKey, 1, 0, 0, time.Now().Add(-time.Minute).Unix()), "expired", ErrVoteRejected},
+		"wrong nonce":  {signVote(t, c, voterKey, 1, 0, 5, deadline), "invalid nonce", ErrVoteRejected},
+		"wrong signer": {wrongSigner, "signature was made by", ErrInvalidVoteSignature},
+		"tampered":     {tampered, "signature was made by", ErrInvalidVoteSignature},
+		"short":        {&SignedVote{Voter: wrongSigner.Voter, Deadline: deadline, Signature: []byte{1}}, "invalid signature length", ErrInvalidVoteSignature},
+	}
+	for name, tc := range cases {
+		err := c.VerifySignedVote(tc.vote)
+		if tc.want == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.want) || !errors.Is(err, tc.is) {
+			t.Errorf("%s: got %v, want %v containing %q", name, err, tc.is, tc.want)
+		}
+	}
+}
+
+func TestVoteBySigContractRejectsExpiredSignature(t *testing.T) {
+	voterKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ := newRelayTestClient(t, voterKey)
+
+	nonce, err := c.contract.Nonces(nil, crypto.PubkeyToAddress(voterKey.PublicKey))
+	if err != nil || nonce.Sign() != 0 {
+		t.Fatalf("initial nonce = %v, %v", nonce, err)
+	}
+
+	// Skip the client-side checks to make sure the contract enforces the deadline too.
+	vote := signVote(t, c, voterKey, 1, 0, 0, 1)
+	var r, s [32]byte
+	copy(r[:], vote.Signature[:32])
+	copy(s[:], vote.Signature[32:64])
+	_, err = c.transact(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.contract.VoteBySig(opts, big.NewInt(1), big.NewInt(0), big.NewInt(0), big.NewInt(1), vote.Signature[64], r, s)
+	})
+	if err == nil || !strings.Contains(err.Error(), "Signature expired") {
+		t.Fatalf("got %v, want Signature expired", err)
+	}
+}
+
+func TestDomainSeparatorMatchesContract(t *testing.T) {
+	voterKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ := newRelayTestClient(t, voterKey)
+
+	want, err := c.contract.DOMAINSEPARATOR(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := DomainSeparator(big.NewInt(1337), common.HexToAddress(c.GetContractAddress()))
+	if got != want {
+		t.Fatalf("domain separator = %x, contract has %x", got, want)
+	}
+}
@@ -0,0 +1,178 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// replacementBump is how much a replacement raises each fee of the
+// transaction it replaces. Nodes reject replacements that pay less than 10%
+// more, so this leaves some margin.
+const replacementBump = 1.125
+
+// SpeedUpTx re-sends a pending transaction with the same nonce and call but
+// higher fees, so that it is mined in place of the original
+func (c *Client) SpeedUpTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	replacement, err := c.replace(ctx, tx, tx.To(), tx.Value(), tx.Data(), tx.Gas())
+	if err != nil {
+		return nil, fmt.Errorf("failed to speed up transaction: %w", err)
+	}
+	return replacement, nil
+}
+
+// CancelTx replaces a pending transaction with a zero-value transfer from the
+// sender to itself, so that its nonce is used up without the original call
+func (c *Client) CancelTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel transaction: %v", err)
+	}
+	replacement, err := c.replace(ctx, tx, &from, new(big.Int), nil, params.TxGas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel transaction: %w", err)
+	}
+	return replacement, nil
+}
+
+// IsCancelTx reports whether a transaction is a cancellation sent by CancelTx
+func IsCancelTx(tx *types.Transaction) bool {
+	if tx.To() == nil || len(tx.Data()) != 0 || tx.Value().Sign() != 0 {
+		return false
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	return err == nil && from == *tx.To()
+}
+
+// RepairNonces fills every nonce of account that was handed out but that
+// the node no longer has, such as one of a transaction dropped from its
+// pool, with a zero-value transfer to itself, so that the transactions
+// queued behind it can be mined. It returns the transfers sent.
+func (c *Client) RepairNonces(ctx context.Context, account common.Address) ([]*types.Transaction, error) {
+	op := c.operatorFor(account)
+	if op == nil {
+		return nil, fmt.Errorf("%s is not one of the client's accounts", account.Hex())
+	}
+	filled, err := op.nonces.FillGaps(ctx, op.auth, c.selfTransfer)
+	if err != nil {
+		return filled, fmt.Errorf("failed to fill nonce gap: %v", err)
+	}
+	return filled, nil
+}
+
+// selfTransfer sends a zero-value transfer from the account of opts to
+// itself at opts.Nonce, paying the current network fees
+func (c *Client) selfTransfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	if err := c.setFees(opts.Context, opts); err != nil {
+		return nil, err
+	}
+
+	var inner types.TxData
+	if opts.GasPrice != nil {
+		inner = &types.LegacyTx{
+			Nonce:    opts.Nonce.Uint64(),
+			GasPrice: opts.GasPrice,
+			Gas:      params.TxGas,
+			To:       &opts.From,
+			Value:    new(big.Int),
+		}
+	} else {
+		inner = &types.DynamicFeeTx{
+			ChainID:   c.chainID,
+			Nonce:     opts.Nonce.Uint64(),
+			GasTipCap: opts.GasTipCap,
+			GasFeeCap: opts.GasFeeCap,
+			Gas:       params.TxGas,
+			To:        &opts.From,
+			Value:     new(big.Int),
+		}
+	}
+
+	signed, err := opts.Signer(opts.From, types.NewTx(inner))
+	if err != nil {
+		return nil, err
+	}
+	if err := c.client.SendTransaction(opts.Context, signed); err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
+
+// replace signs and sends a transaction with the nonce of old, paying the
+// current network fees or the old fees raised by replacementBump, whichever
+// is higher
+func (c *Client) replace(ctx context.Context, old *types.Transaction, to *common.Address, value *big.Int, data []byte, gas uint64) (*types.Transaction, error) {
+	if c.auth == nil {
+		return nil, fmt.Errorf("no private key configured for transactions")
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(old.ChainId()), old)
+	if err != nil {
+		return nil, err
+	}
+	op := c.operatorFor(from)
+	if op == nil {
+		return nil, fmt.Errorf("transaction %s was sent by %s, which is not one of the client's accounts", old.Hash().Hex(), from.Hex())
+	}
+
+	current := *op.auth
+	if err := c.setFees(ctx, &current); err != nil {
+		return nil, err
+	}
+
+	var inner types.TxData
+	if current.GasPrice != nil {
+		price := maxBig(current.GasPrice, scale(old.GasPrice(), replacementBump))
+		if c.fees.MaxFeePerGas != nil && price.Cmp(c.fees.MaxFeePerGas) > 0 {
+			return nil, fmt.Errorf("%w: replacement gas price %s gwei, maximum %s gwei", ErrFeeTooHigh, gwei(price), gwei(c.fees.MaxFeePerGas))
+		}
+		inner = &types.LegacyTx{
+			Nonce:    old.Nonce(),
+			GasPrice: price,
+			Gas:      gas,
+			To:       to,
+			Value:    value,
+			Data:     data,
+		}
+	} else {
+		tip := maxBig(current.GasTipCap, scale(old.GasTipCap(), replacementBump))
+		feeCap := maxBig(current.GasFeeCap, scale(old.GasFeeCap(), replacementBump), tip)
+		if c.fees.MaxFeePerGas != nil && feeCap.Cmp(c.fees.MaxFeePerGas) > 0 {
+			return nil, fmt.Errorf("%w: replacement fee cap %s gwei, maximum %s gwei", ErrFeeTooHigh, gwei(feeCap), gwei(c.fees.MaxFeePerGas))
+		}
+		inner = &types.DynamicFeeTx{
+			ChainID:   old.ChainId(),
+			Nonce:     old.Nonce(),
+			GasTipCap: tip,
+			GasFeeCap: feeCap,
+			Gas:       gas,
+			To:        to,
+			Value:     value,
+			Data:      data,
+		}
+	}
+
+	signed, err := op.auth.Signer(from, types.NewTx(inner))
+	if err != nil {
+		return nil, err
+	}
+	if err := c.client.SendTransaction(ctx, signed); err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
+
+// maxBig returns the largest of its arguments
+func maxBig(first *big.Int, rest ...*big.Int) *big.Int {
+	max := first
+	for _, n := range rest {
+		if n.Cmp(max) > 0 {
+			max = n
+		}
+	}
+	return new(big.Int).Set(max)
+}
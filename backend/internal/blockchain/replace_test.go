@@ -0,0 +1,90 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestReplacePendingTransactions(t *testing.T) {
+	client, sim, err := NewSimulatedClient(SimulatedOptions{Voters: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	ctx := context.Background()
+
+	head, err := sim.Backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := int64(head.Time) + 3600
+	// send submits a poll and drops it from the pending block, the way a
+	// congested node leaves an underpriced transaction unmined
+	send := func(title string) *types.Transaction {
+		t.Helper()
+		tx, err := client.CreatePollTx(title, "", []string{"Yes", "No"}, start, start+3600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sim.Backend.Rollback()
+		return tx
+	}
+
+	stuck := send("Stuck")
+	fast, err := client.SpeedUpTx(ctx, stuck)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.Backend.Commit()
+
+	if fast.Nonce() != stuck.Nonce() || fast.Hash() == stuck.Hash() {
+		t.Errorf("replacement has nonce %d and hash %s, original %d and %s", fast.Nonce(), fast.Hash(), stuck.Nonce(), stuck.Hash())
+	}
+	if min := scale(stuck.GasTipCap(), replacementBump); fast.GasTipCap().Cmp(min) < 0 {
+		t.Errorf("replacement tip %s, want at least %s", fast.GasTipCap(), min)
+	}
+	if min := scale(stuck.GasFeeCap(), replacementBump); fast.GasFeeCap().Cmp(min) < 0 {
+		t.Errorf("replacement fee cap %s, want at least %s", fast.GasFeeCap(), min)
+	}
+	if _, err := sim.Backend.TransactionReceipt(ctx, stuck.Hash()); !errors.Is(err, ethereum.NotFound) {
+		t.Errorf("original transaction mined: %v", err)
+	}
+	if receipt, err := sim.Backend.TransactionReceipt(ctx, fast.Hash()); err != nil || receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("replacement not mined: %v", err)
+	}
+
+	// A canceled poll is never created; its nonce goes to a self-transfer
+	unwanted := send("Unwanted")
+	cancel, err := client.CancelTx(ctx, unwanted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.Backend.Commit()
+
+	if !IsCancelTx(cancel) || IsCancelTx(unwanted) || IsCancelTx(fast) {
+		t.Error("IsCancelTx does not tell the cancellation apart")
+	}
+	if cancel.Nonce() != unwanted.Nonce() || *cancel.To() != sim.Admin.Address {
+		t.Errorf("cancellation has nonce %d and recipient %s", cancel.Nonce(), cancel.To())
+	}
+	ids, err := client.GetAllPollIds()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 {
+		t.Errorf("polls = %v, want only the sped up one", ids)
+	}
+
+	// Transactions from other accounts cannot be replaced
+	voter, err := newClient(sim.Backend, client.ChainID(), sim.Contract.Hex(), NewKeySigner(sim.Voters[0].Key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := voter.SpeedUpTx(ctx, fast); err == nil {
+		t.Error("voter sped up the admin's transaction")
+	}
+}
@@ -0,0 +1,193 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"voting-dapp/backend/internal/models"
+)
+
+// Contract roles. The admin holds every role implicitly; the others are
+// granted and revoked by the admin.
+const (
+	RoleAdmin         = "admin"
+	RolePollManager   = "poll-manager"
+	RolePowerAssigner = "power-assigner"
+)
+
+// roleIDs maps the grantable roles to their identifiers in the contract
+var roleIDs = map[string]common.Hash{
+	RolePollManager:   crypto.Keccak256Hash([]byte("POLL_MANAGER_ROLE")),
+	RolePowerAssigner: crypto.Keccak256Hash([]byte("POWER_ASSIGNER_ROLE")),
+}
+
+// operatorRoles are the roles an account needs for the backend to dispatch
+// any write transaction from it
+var operatorRoles = []string{RolePollManager, RolePowerAssigner}
+
+// ErrUnknownRole is returned when granting, revoking or checking a role the
+// contract does not define
+var ErrUnknownRole = errors.New("unknown role")
+
+// IsGrantableRole reports whether role is one the admin grants and revokes
+func IsGrantableRole(role string) bool {
+	_, ok := roleIDs[role]
+	return ok
+}
+
+// roleID returns the contract identifier of a grantable role
+func roleID(role string) ([32]byte, error) {
+	id, ok := roleIDs[role]
+	if !ok {
+		return [32]byte{}, fmt.Errorf("%w %q, want %s or %s", ErrUnknownRole, role, RolePollManager, RolePowerAssigner)
+	}
+	return id, nil
+}
+
+// TransferAdminTx hands the admin role to another account without waiting
+// for the change to be mined. The client's own account must be the admin,
+// and loses every admin right once the transfer is mined.
+func (c *Client) TransferAdminTx(newAdmin string) (*types.Transaction, error) {
+	if c.auth == nil {
+		return nil, fmt.Errorf("no private key configured for transactions")
+	}
+
+	address := common.HexToAddress(newAdmin)
+	tx, err := c.transactFrom(c.primary(), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.contract.TransferAdmin(opts, address)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to transfer admin: %w", err)
+	}
+
+	return tx, nil
+}
+
+// TransferAdmin hands the admin role to another account
+func (c *Client) TransferAdmin(newAdmin string) error {
+	tx, err := c.TransferAdminTx(newAdmin)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.waitMined(tx)
+	return err
+}
+
+// GrantRoleTx grants a role to an account without waiting for it to be mined
+func (c *Client) GrantRoleTx(role, account string) (*types.Transaction, error) {
+	if c.auth == nil {
+		return nil, fmt.Errorf("no private key configured for transactions")
+	}
+	id, err := roleID(role)
+	if err != nil {
+		return nil, err
+	}
+
+	address := common.HexToAddress(account)
+	tx, err := c.transactFrom(c.primary(), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.contract.GrantRole(opts, id, address)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to grant role: %w", err)
+	}
+
+	return tx, nil
+}
+
+// GrantRole grants a role to an account
+func (c *Client) GrantRole(role, account string) error {
+	tx, err := c.GrantRoleTx(role, account)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.waitMined(tx)
+	return err
+}
+
+// RevokeRoleTx revokes a role from an account without waiting for it to be mined
+func (c *Client) RevokeRoleTx(role, account string) (*types.Transaction, error) {
+	if c.auth == nil {
+		return nil, fmt.Errorf("no private key configured for transactions")
+	}
+	id, err := roleID(role)
+	if err != nil {
+		return nil, err
+	}
+
+	address := common.HexToAddress(account)
+	tx, err := c.transactFrom(c.primary(), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.contract.RevokeRole(opts, id, address)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke role: %w", err)
+	}
+
+	return tx, nil
+}
+
+// RevokeRole revokes a role from an account
+func (c *Client) RevokeRole(role, account string) error {
+	tx, err := c.RevokeRoleTx(role, account)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.waitMined(tx)
+	return err
+}
+
+// HasRole reports whether an account holds a role, counting the admin as
+// holding every role
+func (c *Client) HasRole(role, account string) (bool, error) {
+	address := common.HexToAddress(account)
+	if role == RoleAdmin {
+		admin, err := c.contract.Admin(nil)
+		if err != nil {
+			return false, err
+		}
+		return address == admin, nil
+	}
+
+	id, err := roleID(role)
+	if err != nil {
+		return false, err
+	}
+	return c.contract.HasRole(nil, id, address)
+}
+
+// GetRoles returns the roles an account holds
+func (c *Client) GetRoles(account string) (*models.AccountRoles, error) {
+	address := common.HexToAddress(account)
+	admin, err := c.contract.Admin(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := &models.AccountRoles{
+		Address:  address.Hex(),
+		Roles:    []string{},
+		Operator: true,
+	}
+	if address == admin {
+		roles.Roles = append(roles.Roles, RoleAdmin)
+	}
+	for _, role := range operatorRoles {
+		held, err := c.contract.Roles(nil, roleIDs[role], address)
+		if err != nil {
+			return nil, err
+		}
+		if held {
+			roles.Roles = append(roles.Roles, role)
+		} else {
+			roles.Operator = false
+		}
+	}
+	return roles, nil
+}
@@ -0,0 +1,62 @@
+package blockchain
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+func TestRolesMatchContract(t *testing.T) {
+	client, sim, err := NewSimulatedClient(SimulatedOptions{Voters: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+
+	pollManager, err := client.contract.POLLMANAGERROLE(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	powerAssigner, err := client.contract.POWERASSIGNERROLE(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if roleIDs[RolePollManager] != pollManager || roleIDs[RolePowerAssigner] != powerAssigner {
+		t.Fatal("role identifiers differ from the contract's")
+	}
+
+	admin, voter := sim.Admin.Address.Hex(), sim.Voters[0].Address.Hex()
+	if ok, err := client.HasRole(RolePowerAssigner, admin); err != nil || !ok {
+		t.Errorf("admin holds power-assigner = %v, %v; want true", ok, err)
+	}
+
+	if _, err := client.GrantRoleTx(RolePowerAssigner, voter); err != nil {
+		t.Fatal(err)
+	}
+	sim.Backend.Commit()
+	for role, want := range map[string]bool{RoleAdmin: false, RolePollManager: false, RolePowerAssigner: true} {
+		if ok, err := client.HasRole(role, voter); err != nil || ok != want {
+			t.Errorf("voter holds %s = %v, %v; want %v", role, ok, err, want)
+		}
+	}
+
+	// The contract lets a role holder act within its role only
+	createTestPoll(t, client, sim, "Yes", "No")
+	auth, err := bind.NewKeyedTransactorWithChainID(sim.Voters[0].Key, client.ChainID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.contract.AssignVotingPower(auth, sim.Voters[0].Address, big.NewInt(3)); err != nil {
+		t.Errorf("power assigner assigning power: %v", err)
+	}
+	if _, err := client.contract.CancelPoll(auth, big.NewInt(1)); err == nil || !strings.Contains(err.Error(), "missing the required role") {
+		t.Errorf("power assigner canceling a poll: %v", err)
+	}
+
+	if _, err := client.GrantRoleTx(RoleAdmin, voter); !errors.Is(err, ErrUnknownRole) {
+		t.Errorf("granting admin: %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package blockchain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Signer signs the client's transactions for a single account
+type Signer interface {
+	// Address returns the account transactions are signed for
+	Address() common.Address
+	// SignTx returns tx signed for the chain with the given ID
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// transactOpts returns transaction options that sign with signer
+func transactOpts(signer Signer, chainID *big.Int) *bind.TransactOpts {
+	from := signer.Address()
+	return &bind.TransactOpts{
+		From: from,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != from {
+				return nil, bind.ErrNotAuthorized
+			}
+			return signer.SignTx(context.Background(), tx, chainID)
+		},
+		Value:   big.NewInt(0),
+		Context: context.Background(),
+	}
+}
+
+// KeySigner signs with a private key held in memory
+type KeySigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewKeySigner creates a signer for a private key
+func NewKeySigner(key *ecdsa.PrivateKey) *KeySigner {
+	return &KeySigner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+// KeySignerFromHex creates a signer for a hex-encoded private key. Keys in
+// the environment are readable by anything that can inspect the process, so
+// this is meant for development.
+func KeySignerFromHex(hexKey string) (*KeySigner, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %v", err)
+	}
+	return NewKeySigner(key), nil
+}
+
+// NewKeystoreSigner decrypts a go-ethereum JSON keystore file and signs
+// with the key it holds
+func NewKeystoreSigner(path, passphrase string) (*KeySigner, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %v", err)
+	}
+	key, err := keystore.DecryptKey(data, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore: %v", err)
+	}
+	return NewKeySigner(key.PrivateKey), nil
+}
+
+// Address returns the key's account
+func (s *KeySigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx signs tx with the key
+func (s *KeySigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
+
+// Remote signer methods
+const (
+	// RemoteSignClef is Clef's signing method, which asks its operator or
+	// rules to approve each transaction
+	RemoteSignClef = "account_signTransaction"
+	// RemoteSignNode is the signing method of nodes holding unlocked accounts
+	RemoteSignNode = "eth_signTransaction"
+)
+
+// RemoteSigner signs by calling an external signer such as Clef over JSON-RPC,
+// so that the key never enters this process
+type RemoteSigner struct {
+	rpc     *rpc.Client
+	address common.Address
+	method  string
+}
+
+// NewRemoteSigner connects to the signer at url, which signs for address
+// with method, RemoteSignClef if empty
+func NewRemoteSigner(url string, address common.Address, method string) (*RemoteSigner, error) {
+	if method == "" {
+		method = RemoteSignClef
+	}
+	client, err := rpc.Dial(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to signer: %v", err)
+	}
+	return &RemoteSigner{rpc: client, address: address, method: method}, nil
+}
+
+// Address returns the account the remote signer signs for
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx asks the remote signer to sign tx and checks that the transaction
+// it returns is the one requested
+func (s *RemoteSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	data := hexutil.Bytes(tx.Data())
+	args := apitypes.SendTxArgs{
+		From:    common.NewMixedcaseAddress(s.address),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    &data,
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if to := tx.To(); to != nil {
+		recipient := common.NewMixedcaseAddress(*to)
+		args.To = &recipient
+	}
+	if tx.Type() == types.LegacyTxType {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	} else {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	}
+
+	var result struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := s.rpc.CallContext(ctx, &result, s.method, args); err != nil {
+		return nil, fmt.Errorf("remote signer refused transaction: %v", err)
+	}
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(result.Raw); err != nil {
+		return nil, fmt.Errorf("invalid transaction from remote signer: %v", err)
+	}
+
+	// The signer must not have changed what it was asked to sign
+	txSigner := types.LatestSignerForChainID(chainID)
+	from, err := types.Sender(txSigner, signed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature from remote signer: %v", err)
+	}
+	if from != s.address || txSigner.Hash(signed) != txSigner.Hash(tx) {
+		return nil, fmt.Errorf("remote signer returned a different transaction")
+	}
+	return signed, nil
+}
+
+// Close disconnects from the remote signer
+func (s *RemoteSigner) Close() {
+	s.rpc.Close()
+}
@@ -0,0 +1,111 @@
+package blockchain
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// testClef answers account_signTransaction the way Clef does once a
+// transaction is approved, optionally tampering with it first
+type testClef struct {
+	key     *ecdsa.PrivateKey
+	chainID *big.Int
+	tamper  bool
+}
+
+func (c *testClef) SignTransaction(args apitypes.SendTxArgs) (map[string]hexutil.Bytes, error) {
+	if args.Data != nil && args.Input == nil {
+		args.Input = args.Data
+	}
+	if c.tamper {
+		args.Value = hexutil.Big(*big.NewInt(1))
+	}
+	tx, err := types.SignTx(args.ToTransaction(), types.LatestSignerForChainID(c.chainID), c.key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]hexutil.Bytes{"raw": raw}, nil
+}
+
+func TestRemoteSignerSignsThroughClef(t *testing.T) {
+	simClient, sim, err := NewSimulatedClient(SimulatedOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(simClient.Close)
+
+	clef := &testClef{key: sim.Admin.Key, chainID: simClient.ChainID()}
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("account", clef); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(srv)
+	t.Cleanup(server.Close)
+
+	signer, err := NewRemoteSigner(server.URL, sim.Admin.Address, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(signer.Close)
+	client, err := newClient(sim.Backend, simClient.ChainID(), sim.Contract.Hex(), signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createTestPoll(t, client, sim, "Yes", "No")
+	ids, err := client.GetAllPollIds()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 {
+		t.Fatalf("polls = %v, want the one signed remotely", ids)
+	}
+
+	// A signer that changes the transaction is caught
+	clef.tamper = true
+	if _, err := client.CancelPollTx(ids[0]); err == nil || !strings.Contains(err.Error(), "different transaction") {
+		t.Errorf("tampered transaction: %v", err)
+	}
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	account, err := simulatedAccount(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := &keystore.Key{Address: account.Address, PrivateKey: account.Key}
+	data, err := keystore.EncryptKey(key, "correct horse", keystore.LightScryptN, keystore.LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "admin.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := NewKeystoreSigner(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer.Address() != account.Address {
+		t.Errorf("address = %s, want %s", signer.Address().Hex(), account.Address.Hex())
+	}
+	if _, err := NewKeystoreSigner(path, "wrong"); err == nil {
+		t.Error("decrypted keystore with the wrong passphrase")
+	}
+}
@@ -0,0 +1,311 @@
+package blockchain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"log"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+
+	"voting-dapp/backend/internal/models"
+)
+
+// simulatedBlockSpacing is the timestamp gap the simulated backend puts
+// between a block and its parent. Empty blocks can be retimed, but a block
+// holding transactions is always mined this long after its parent.
+const simulatedBlockSpacing = 10
+
+// simulatedMaxDrift is how far ahead of the wall clock a block may be mined;
+// the backend refuses blocks more than 15 seconds in the future
+const simulatedMaxDrift = 5
+
+// SimulatedOptions configures a simulated chain
+type SimulatedOptions struct {
+	// Voters is the number of funded voter accounts to create
+	Voters int
+	// VotingPower is the voting power assigned to each voter
+	VotingPower uint64
+	// Operators is the number of funded operator accounts to create and
+	// dispatch write transactions across alongside the admin
+	Operators int
+	// StartTime is the timestamp of the first block. It defaults to shortly
+	// before now; tests that mine blocks by hand set it further back so the
+	// chain can advance without running ahead of the clock.
+	StartTime time.Time
+}
+
+// SimulatedAccount is a funded account on the simulated chain
+type SimulatedAccount struct {
+	Address common.Address
+	Key     *ecdsa.PrivateKey
+}
+
+// Simulation is an in-process chain with the Voting contract and a
+// multicall aggregator deployed
+type Simulation struct {
+	Backend   *backends.SimulatedBackend
+	Contract  common.Address
+	Multicall common.Address
+	Admin     SimulatedAccount
+	Voters    []SimulatedAccount
+	Operators []SimulatedAccount
+}
+
+// simulatedOperatorIndex is the account index the operators start at, far
+// enough from the voters' that the two never overlap
+const simulatedOperatorIndex = 1000
+
+// simulatedAccount derives a deterministic account so that addresses and
+// keys stay the same across restarts
+func simulatedAccount(index int) (SimulatedAccount, error) {
+	seed := crypto.Keccak256([]byte(fmt.Sprintf("voting-dapp simulated account %d", index)))
+	key, err := crypto.ToECDSA(seed)
+	if err != nil {
+		return SimulatedAccount{}, err
+	}
+	return SimulatedAccount{Address: crypto.PubkeyToAddress(key.PublicKey), Key: key}, nil
+}
+
+// NewSimulatedClient boots an in-process simulated chain, deploys the
+// contract from a funded admin account, authorizes funded operator accounts,
+// assigns voting power to funded voter accounts and returns a client for it
+func NewSimulatedClient(opts SimulatedOptions) (*Client, *Simulation, error) {
+	admin, err := simulatedAccount(0)
+	if err != nil {
+		return nil, nil, err
+	}
+	sim := &Simulation{Admin: admin}
+
+	balance := new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))
+	alloc := core.GenesisAlloc{admin.Address: {Balance: balance}}
+	for i := 1; i <= opts.Voters; i++ {
+		voter, err := simulatedAccount(i)
+		if err != nil {
+			return nil, nil, err
+		}
+		sim.Voters = append(sim.Voters, voter)
+		alloc[voter.Address] = core.GenesisAccount{Balance: balance}
+	}
+	for i := 0; i < opts.Operators; i++ {
+		operator, err := simulatedAccount(simulatedOperatorIndex + i)
+		if err != nil {
+			return nil, nil, err
+		}
+		sim.Operators = append(sim.Operators, operator)
+		alloc[operator.Address] = core.GenesisAccount{Balance: balance}
+	}
+
+	// Start far enough in the past that the setup blocks, each mined
+	// simulatedBlockSpacing after its parent, do not run ahead of the clock
+	start := time.Now().Unix() - 4*simulatedBlockSpacing
+	if !opts.StartTime.IsZero() {
+		start = opts.StartTime.Unix()
+	}
+	sim.Backend = backends.NewSimulatedBackend(alloc, 30000000)
+	if err := sim.retime(start); err != nil {
+		sim.Backend.Close()
+		return nil, nil, err
+	}
+	sim.Backend.Commit()
+
+	chainID := sim.Backend.Blockchain().Config().ChainID
+	auth := transactOpts(NewKeySigner(admin.Key), chainID)
+
+	// Deploy contract
+	contractAddr, _, _, err := DeployVoting(auth, sim.Backend)
+	if err != nil {
+		sim.Backend.Close()
+		return nil, nil, fmt.Errorf("failed to deploy contract: %v", err)
+	}
+	multicallAddr, _, _, err := DeployMulticall3(auth, sim.Backend)
+	if err != nil {
+		sim.Backend.Close()
+		return nil, nil, fmt.Errorf("failed to deploy multicall contract: %v", err)
+	}
+	sim.Backend.Commit()
+	sim.Contract = contractAddr
+	sim.Multicall = multicallAddr
+
+	// Read through a backend that can also call at past blocks
+	backend := &simulatedBackend{SimulatedBackend: sim.Backend}
+	contract, err := NewVoting(contractAddr, backend)
+	if err != nil {
+		sim.Backend.Close()
+		return nil, nil, fmt.Errorf("failed to initialize contract: %v", err)
+	}
+
+	client := &Client{
+		client:       backend,
+		closer:       func() { sim.Backend.Close() },
+		contract:     contract,
+		auth:         auth,
+		nonces:       NewNonceManager(sim.Backend, admin.Address),
+		contractAddr: contractAddr,
+		chainID:      chainID,
+		txTimeout:    DefaultTxTimeout,
+		fees:         DefaultFeePolicy(),
+		conn:         newConnState(models.TransportSimulated),
+		multicall:    multicallAddr,
+	}
+	client.Reader = &Reader{client: client}
+
+	if len(sim.Operators) > 0 {
+		signers := make([]Signer, len(sim.Operators))
+		for i, op := range sim.Operators {
+			if _, err := client.GrantOperatorTxs(op.Address.Hex()); err != nil {
+				client.Close()
+				return nil, nil, err
+			}
+			signers[i] = NewKeySigner(op.Key)
+		}
+		sim.Backend.Commit()
+		if err := client.AddOperators(signers...); err != nil {
+			client.Close()
+			return nil, nil, err
+		}
+	}
+
+	if len(sim.Voters) > 0 && opts.VotingPower > 0 {
+		voters := make([]string, len(sim.Voters))
+		powers := make([]uint64, len(sim.Voters))
+		for i, v := range sim.Voters {
+			voters[i] = v.Address.Hex()
+			powers[i] = opts.VotingPower
+		}
+		if _, err := client.BatchAssignVotingPowerTx(voters, powers); err != nil {
+			client.Close()
+			return nil, nil, err
+		}
+		sim.Backend.Commit()
+	}
+
+	return client, sim, nil
+}
+
+// Run mines a block every interval until ctx is canceled
+func (s *Simulation) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.step(); err != nil {
+				log.Printf("Simulated chain: %v", err)
+			}
+		}
+	}
+}
+
+// step mines the pending block if doing so keeps the chain in step with the
+// wall clock. A block is mined simulatedBlockSpacing after its parent, so
+// empty blocks are retimed to trail the clock by that much; the pending
+// block, which gas estimation and calls run against, then carries about the
+// current time.
+func (s *Simulation) step() error {
+	head, err := s.Backend.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to read head: %v", err)
+	}
+	now := time.Now().Unix()
+
+	// Adjusting by zero only succeeds while the pending block is empty
+	if s.Backend.AdjustTime(0) == nil {
+		if target := now - simulatedBlockSpacing; target > int64(head.Time) {
+			if err := s.retime(target); err != nil {
+				return fmt.Errorf("failed to retime pending block: %v", err)
+			}
+			s.Backend.Commit()
+		}
+		return nil
+	}
+
+	if int64(head.Time)+simulatedBlockSpacing <= now+simulatedMaxDrift {
+		s.Backend.Commit()
+	}
+	return nil
+}
+
+// retime sets the timestamp of the pending block, which must be later than
+// the head. It fails if the pending block already holds transactions.
+func (s *Simulation) retime(timestamp int64) error {
+	head, err := s.Backend.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+	offset := timestamp - int64(head.Time) - simulatedBlockSpacing
+	return s.Backend.AdjustTime(time.Duration(offset) * time.Second)
+}
+
+// simulatedBackend is the simulated backend with calls at past blocks, which
+// it refuses by itself, run against the state of that block
+type simulatedBackend struct {
+	*backends.SimulatedBackend
+}
+
+// HeaderByNumber returns a block header, or the latest one if number is nil.
+// Unlike the simulated backend's, it does not answer for the pending block.
+func (b *simulatedBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number != nil && number.Cmp(b.Blockchain().CurrentHeader().Number) > 0 {
+		return nil, ethereum.NotFound
+	}
+	return b.SimulatedBackend.HeaderByNumber(ctx, number)
+}
+
+// CallContract runs a call at the head, or at a past block if blockNumber
+// is set
+func (b *simulatedBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	chain := b.Blockchain()
+	if blockNumber == nil || !blockNumber.IsUint64() {
+		return b.SimulatedBackend.CallContract(ctx, call, blockNumber)
+	}
+	header := chain.GetHeaderByNumber(blockNumber.Uint64())
+	if header == nil {
+		return nil, ethereum.NotFound
+	}
+	if header.Hash() == chain.CurrentHeader().Hash() {
+		return b.SimulatedBackend.CallContract(ctx, call, nil)
+	}
+
+	stateDB, err := chain.StateAt(header.Root)
+	if err != nil {
+		return nil, fmt.Errorf("state of block %d is not available: %v", header.Number, err)
+	}
+	value := call.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	msg := &core.Message{
+		From:              call.From,
+		To:                call.To,
+		Value:             value,
+		GasLimit:          10 * header.GasLimit,
+		GasPrice:          new(big.Int),
+		GasFeeCap:         new(big.Int),
+		GasTipCap:         new(big.Int),
+		Data:              call.Data,
+		SkipAccountChecks: true,
+	}
+	evm := vm.NewEVM(core.NewEVMBlockContext(header, chain, nil), core.NewEVMTxContext(msg), stateDB, chain.Config(), vm.Config{NoBaseFee: true})
+	result, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(math.MaxUint64))
+	if err != nil {
+		return nil, err
+	}
+	if revert := result.Revert(); len(revert) > 0 {
+		return nil, revertError(revert)
+	}
+	return result.Return(), result.Err
+}
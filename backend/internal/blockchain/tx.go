@@ -0,0 +1,148 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// waitMined waits for a transaction to be mined and fails if it reverted
+func (c *Client) waitMined(tx *types.Transaction) (*types.Receipt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.txTimeout)
+	defer cancel()
+
+	receipt, err := bind.WaitMined(ctx, c.client, tx)
+	if err != nil {
+		if ctx.Err() != nil {
+			c.repairAfterTimeout(tx)
+		}
+		return nil, fmt.Errorf("failed to get receipt: %v", err)
+	}
+
+	if receipt.Status == types.ReceiptStatusFailed {
+		if reason, err := c.RevertReason(ctx, tx, receipt); err == nil && reason != "" {
+			return nil, fmt.Errorf("transaction failed: %s", reason)
+		}
+		return nil, fmt.Errorf("transaction failed")
+	}
+
+	return receipt, nil
+}
+
+// repairAfterTimeout fills nonces the node lost from the sender of a
+// transaction that was not mined in time, in case it waits behind one
+func (c *Client) repairAfterTimeout(tx *types.Transaction) {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.txTimeout)
+	defer cancel()
+
+	filled, err := c.RepairNonces(ctx, from)
+	for _, fill := range filled {
+		log.Printf("Filled nonce %d of %s with %s", fill.Nonce(), from.Hex(), fill.Hash().Hex())
+	}
+	if err != nil {
+		log.Printf("Failed to repair nonces of %s: %v", from.Hex(), err)
+	}
+}
+
+// TransactionReceipt returns the receipt of a mined transaction, or nil if
+// the transaction is not mined yet
+func (c *Client) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	receipt, err := c.client.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	return receipt, err
+}
+
+// NonceAt returns the number of transactions mined from an account
+func (c *Client) NonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return c.client.NonceAt(ctx, account, nil)
+}
+
+// RevertReason replays a failed transaction on top of the parent of the
+// block it was mined in and decodes the revert message
+func (c *Client) RevertReason(ctx context.Context, tx *types.Transaction, receipt *types.Receipt) (string, error) {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return "", err
+	}
+
+	msg := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	parent := new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))
+	_, callErr := c.client.CallContract(ctx, msg, parent)
+	if callErr == nil {
+		return "", nil
+	}
+	return decodeRevert(callErr), nil
+}
+
+// decodeRevert extracts the Error(string) message from a call error
+func decodeRevert(err error) string {
+	var dataErr interface{ ErrorData() interface{} }
+	if errors.As(err, &dataErr) {
+		if hexData, ok := dataErr.ErrorData().(string); ok {
+			if data, decErr := hexutil.Decode(hexData); decErr == nil {
+				if reason, unpackErr := abi.UnpackRevert(data); unpackErr == nil {
+					return reason
+				}
+			}
+		}
+	}
+	return strings.TrimPrefix(err.Error(), "execution reverted: ")
+}
+
+// isRevert reports whether err is a call or gas estimate the contract
+// reverted
+func isRevert(err error) bool {
+	if err == nil {
+		return false
+	}
+	var dataErr interface{ ErrorData() interface{} }
+	return errors.As(err, &dataErr) || strings.Contains(err.Error(), "execution reverted")
+}
+
+// PollIDFromReceipt returns the ID of the poll created by a createPoll transaction
+func (c *Client) PollIDFromReceipt(receipt *types.Receipt) (uint64, error) {
+	for _, log := range receipt.Logs {
+		if log.Address != c.contractAddr {
+			continue
+		}
+		event, err := c.contract.ParsePollCreated(*log)
+		if err != nil {
+			continue
+		}
+		return event.PollId.Uint64(), nil
+	}
+	return 0, fmt.Errorf("no PollCreated event in transaction %s", receipt.TxHash.Hex())
+}
+
+// SendTransaction submits a signed transaction, such as one the node dropped
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return c.client.SendTransaction(ctx, tx)
+}
+
+// TransactionByHash returns a transaction known to the node
+func (c *Client) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, error) {
+	tx, _, err := c.client.TransactionByHash(ctx, hash)
+	return tx, err
+}
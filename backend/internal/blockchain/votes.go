@@ -0,0 +1,109 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"voting-dapp/backend/internal/models"
+)
+
+// GetVotesByVoter returns every vote cast by an address across all polls,
+// oldest first
+func (c *Client) GetVotesByVoter(voter string) ([]*models.Vote, error) {
+	return c.filterVotes(nil, []common.Address{common.HexToAddress(voter)})
+}
+
+// GetPollVotes returns the individual ballots of a poll, oldest first
+func (c *Client) GetPollVotes(pollID uint64) ([]*models.Vote, error) {
+	return c.filterVotes([]*big.Int{new(big.Int).SetUint64(pollID)}, nil)
+}
+
+// LogRange bounds how reads over the contract's history query its logs
+type LogRange struct {
+	// StartBlock is the first block searched, such as the deployment block
+	StartBlock uint64
+	// BatchSize is the most blocks searched per query. Zero means 2000.
+	BatchSize uint64
+}
+
+// SetLogRange sets the blocks vote history reads search and how many they
+// search per query, since public nodes reject unbounded log queries
+func (c *Client) SetLogRange(r LogRange) {
+	c.logRange = r
+}
+
+// filterVotes collects Voted events matching the given topics and stamps
+// each vote with the time of the block it was mined in. The blocks from
+// the start of the log range to the head are searched in batches.
+func (c *Client) filterVotes(pollIDs []*big.Int, voters []common.Address) ([]*models.Vote, error) {
+	ctx := context.Background()
+	head, err := c.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter votes: %v", err)
+	}
+	batchSize := c.logRange.BatchSize
+	if batchSize == 0 {
+		batchSize = 2000
+	}
+
+	votes := []*models.Vote{}
+	timestamps := make(map[uint64]int64)
+	for from := c.logRange.StartBlock; from <= head; from += batchSize {
+		to := from + batchSize - 1
+		if to > head {
+			to = head
+		}
+		found, err := c.filterVotesIn(&bind.FilterOpts{Start: from, End: &to, Context: ctx}, pollIDs, voters, timestamps)
+		if err != nil {
+			return nil, err
+		}
+		votes = append(votes, found...)
+	}
+	return votes, nil
+}
+
+// filterVotesIn collects the matching Voted events of one block range,
+// caching block timestamps in timestamps
+func (c *Client) filterVotesIn(opts *bind.FilterOpts, pollIDs []*big.Int, voters []common.Address, timestamps map[uint64]int64) ([]*models.Vote, error) {
+	it, err := c.contract.FilterVoted(opts, pollIDs, voters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter votes in blocks %d-%d: %v", opts.Start, *opts.End, err)
+	}
+	defer it.Close()
+
+	var votes []*models.Vote
+	for it.Next() {
+		e := it.Event
+		if e.Raw.Removed {
+			continue
+		}
+
+		ts, ok := timestamps[e.Raw.BlockNumber]
+		if !ok {
+			header, err := c.HeaderByNumber(opts.Context, e.Raw.BlockNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load block %d: %v", e.Raw.BlockNumber, err)
+			}
+			ts = int64(header.Time)
+			timestamps[e.Raw.BlockNumber] = ts
+		}
+
+		votes = append(votes, &models.Vote{
+			PollID:      e.PollId.Uint64(),
+			OptionIndex: e.OptionIndex.Uint64(),
+			Voter:       e.Voter.Hex(),
+			Timestamp:   ts,
+			Weight:      e.Weight.Uint64(),
+			TxHash:      e.Raw.TxHash.Hex(),
+			BlockNumber: e.Raw.BlockNumber,
+		})
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("failed to filter votes in blocks %d-%d: %v", opts.Start, *opts.End, err)
+	}
+	return votes, nil
+}
@@ -0,0 +1,95 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// rangeLimitedBackend rejects log queries over more than max blocks, as
+// public RPC endpoints do, and records the ranges it answered
+type rangeLimitedBackend struct {
+	Backend
+	max    uint64
+	ranges [][2]uint64
+}
+
+func (b *rangeLimitedBackend) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	if q.FromBlock == nil || q.ToBlock == nil || q.ToBlock.Uint64()-q.FromBlock.Uint64()+1 > b.max {
+		return nil, fmt.Errorf("query exceeds max block range %d", b.max)
+	}
+	b.ranges = append(b.ranges, [2]uint64{q.FromBlock.Uint64(), q.ToBlock.Uint64()})
+	return b.Backend.FilterLogs(ctx, q)
+}
+
+func TestVoteHistoryQueriesBoundedRanges(t *testing.T) {
+	client, sim, err := NewSimulatedClient(SimulatedOptions{Voters: 2, VotingPower: 5, StartTime: time.Now().Add(-24 * time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	ctx := context.Background()
+	createTestPoll(t, client, sim, "Yes", "No")
+	if err := sim.Backend.AdjustTime(90 * time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	sim.Backend.Commit()
+
+	backend := &rangeLimitedBackend{Backend: sim.Backend, max: 3}
+	for i, voter := range sim.Voters {
+		c, err := newClient(backend, client.ChainID(), sim.Contract.Hex(), NewKeySigner(voter.Key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.VoteTx(1, uint64(i)); err != nil {
+			t.Fatal(err)
+		}
+		for j := 0; j < 4; j++ {
+			sim.Backend.Commit()
+		}
+	}
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := newClient(backend, client.ChainID(), sim.Contract.Hex(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reader.GetPollVotes(1); err == nil {
+		t.Fatal("history read from block 0 to the head in one query was accepted")
+	}
+
+	reader.SetLogRange(LogRange{StartBlock: 2, BatchSize: 3})
+	backend.ranges = nil
+	votes, err := reader.GetPollVotes(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(votes) != 2 || votes[0].Voter != sim.Voters[0].Address.Hex() || votes[1].OptionIndex != 1 || votes[0].Timestamp == 0 {
+		t.Errorf("votes = %+v", votes)
+	}
+	next := uint64(2)
+	for _, r := range backend.ranges {
+		if r[0] != next {
+			t.Fatalf("queried blocks %v, want the ranges to continue from block %d", backend.ranges, next)
+		}
+		next = r[1] + 1
+	}
+	if next != head+1 {
+		t.Errorf("queried blocks %v, want up to the head %d", backend.ranges, head)
+	}
+
+	byVoter, err := reader.GetVotesByVoter(sim.Voters[1].Address.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byVoter) != 1 || byVoter[0].PollID != 1 || byVoter[0].Weight != 5 {
+		t.Errorf("votes by voter = %+v", byVoter)
+	}
+}
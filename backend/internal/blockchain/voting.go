@@ -0,0 +1,2628 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package blockchain
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// VotingVote is an auto generated low-level Go binding around an user-defined struct.
+type VotingVote struct {
+	PollId      *big.Int
+	OptionIndex *big.Int
+	Voter       common.Address
+	Timestamp   *big.Int
+}
+
+// VotingMetaData contains all meta data concerning the Voting contract.
+var VotingMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousAdmin\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newAdmin\",\"type\":\"address\"}],\"name\":\"AdminTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"pollId\",\"type\":\"uint256\"}],\"name\":\"PollActivated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"pollId\",\"type\":\"uint256\"}],\"name\":\"PollCanceled\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"pollId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"title\",\"type\":\"string\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"creator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"startTime\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"endTime\",\"type\":\"uint256\"}],\"name\":\"PollCreated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"pollId\",\"type\":\"uint256\"}],\"name\":\"PollDeactivated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"RoleGranted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"RoleRevoked\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"pollId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"voter\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"optionIndex\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"weight\",\"type\":\"uint256\"}],\"name\":\"Voted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"voter\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"power\",\"type\":\"uint256\"}],\"name\":\"VotingPowerAssigned\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"DOMAIN_SEPARATOR\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"DOMAIN_TYPEHASH\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"POLL_MANAGER_ROLE\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"POWER_ASSIGNER_ROLE\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"VOTE_TYPEHASH\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_pollId\",\"type\":\"uint256\"}],\"name\":\"activatePoll\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"admin\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_voter\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_power\",\"type\":\"uint256\"}],\"name\":\"assignVotingPower\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"_voters\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_powers\",\"type\":\"uint256[]\"}],\"name\":\"batchAssignVotingPower\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_pollId\",\"type\":\"uint256\"}],\"name\":\"cancelPoll\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"_title\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"_description\",\"type\":\"string\"},{\"internalType\":\"string[]\",\"name\":\"_options\",\"type\":\"string[]\"},{\"internalType\":\"uint256\",\"name\":\"_startTime\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_endTime\",\"type\":\"uint256\"}],\"name\":\"createPoll\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_pollId\",\"type\":\"uint256\"}],\"name\":\"deactivatePoll\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getAllPollIds\",\"outputs\":[{\"internalType\":\"uint256[]\",\"name\":\"\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_pollId\",\"type\":\"uint256\"}],\"name\":\"getPoll\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"title\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"description\",\"type\":\"string\"},{\"internalType\":\"string[]\",\"name\":\"options\",\"type\":\"string[]\"},{\"internalType\":\"uint256\",\"name\":\"startTime\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"endTime\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"creator\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"isActive\",\"type\":\"bool\"},{\"internalType\":\"bool\",\"name\":\"isCanceled\",\"type\":\"bool\"},{\"internalType\":\"uint256\",\"name\":\"totalVotes\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_pollId\",\"type\":\"uint256\"}],\"name\":\"getPollResults\",\"outputs\":[{\"internalType\":\"string[]\",\"name\":\"optionNames\",\"type\":\"string[]\"},{\"internalType\":\"uint256[]\",\"name\":\"voteCountsArray\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256\",\"name\":\"totalVotes\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_pollId\",\"type\":\"uint256\"}],\"name\":\"getPollStatus\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"status\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_creator\",\"type\":\"address\"}],\"name\":\"getPollsByCreator\",\"outputs\":[{\"internalType\":\"uint256[]\",\"name\":\"\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_pollId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"_voter\",\"type\":\"address\"}],\"name\":\"getVote\",\"outputs\":[{\"components\":[{\"internalType\":\"uint256\",\"name\":\"pollId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"optionIndex\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"voter\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"}],\"internalType\":\"structVoting.Vote\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_pollId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"_voter\",\"type\":\"address\"}],\"name\":\"getVoterStatus\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"hasVotedStatus\",\"type\":\"bool\"},{\"internalType\":\"uint256\",\"name\":\"optionIndex\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"_account\",\"type\":\"address\"}],\"name\":\"grantRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"_account\",\"type\":\"address\"}],\"name\":\"hasRole\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"hasVoted\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"nonces\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"pollCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"polls\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"title\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"description\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"startTime\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"endTime\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"creator\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"isActive\",\"type\":\"bool\"},{\"internalType\":\"bool\",\"name\":\"isCanceled\",\"type\":\"bool\"},{\"internalType\":\"uint256\",\"name\":\"totalVotes\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"_account\",\"type\":\"address\"}],\"name\":\"revokeRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"roles\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_newAdmin\",\"type\":\"address\"}],\"name\":\"transferAdmin\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_pollId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_optionIndex\",\"type\":\"uint256\"}],\"name\":\"vote\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_pollId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_optionIndex\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_nonce\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_deadline\",\"type\":\"uint256\"},{\"internalType\":\"uint8\",\"name\":\"_v\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"_r\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"_s\",\"type\":\"bytes32\"}],\"name\":\"voteBySig\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"voteCounts\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"votes\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"pollId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"optionIndex\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"voter\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"votingPower\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	Bin: "0x608060405234801561001057600080fd5b50600080546001600160a01b03191633178155600155612ec8806100356000396000f3fe608060405234801561001057600080fd5b50600436106101fb5760003560e01c8063ac2f00741161011a578063d0ec1607116100ad578063ebb968961161007c578063ebb968961461059e578063ee0ca517146105b1578063f851a440146105c6578063f8fc08b9146105f1578063fb790a321461061f57600080fd5b8063d0ec1607146104d2578063d23254b4146104e5578063d2daa1c214610560578063d547741f1461058b57600080fd5b8063c07473f6116100e9578063c07473f614610477578063c3a74f1214610497578063ce3dbda1146104aa578063cff9fced146104bf57600080fd5b8063ac2f0074146103cd578063b0c095e3146103f5578063b384abef14610415578063bc3f931f1461042857600080fd5b80633644e515116101925780638652297311610161578063865229731461036057806391d14854146103875780639207891d1461039a578063942f56bd146103a357600080fd5b80633644e515146102e757806343859632146102ef57806375829def1461032d5780637ecebe001461034057600080fd5b806320606b70116101ce57806320606b70146102785780632f2ff15d1461029f57806333b4097b146102b257806334b4bd60146102d457600080fd5b806304f81b351461020057806305f48977146102155780630732d0751461023d5780631a8cbcaa14610250575b600080fd5b61021361020e3660046125ec565b610632565b005b61022a600080516020612e5383398151915281565b6040519081526020015b60405180910390f35b61021361024b366004612651565b610a6c565b61026361025e366004612651565b610b18565b60405161023499989796959493929190612708565b61022a7f8b73c3c69bb8fe3d512ecc4cf759cc79239f7b179b0ffacaa9a75d522b39400f81565b6102136102ad3660046127a0565b610dbd565b6102c56102c0366004612651565b610f27565b60405161023493929190612807565b61022a6102e23660046128cb565b61112c565b61022a61144f565b61031d6102fd3660046127a0565b600460209081526000928352604080842090915290825290205460ff1681565b6040519015158152602001610234565b61021361033b366004612974565b61151b565b61022a61034e366004612974565b60076020526000908152604090205481565b61022a7f5b1665022c1eea2d7304d2a42a2c8b440b73df47e8b78a57e9e667bd7dadd29481565b61031d6103953660046127a0565b6115ee565b61022a60015481565b6103b66103b13660046127a0565b611636565b604080519215158352602083019190915201610234565b6103e06103db366004612651565b6116c7565b6040516102349998979695949392919061298f565b610408610403366004612651565b611833565b60405161023491906129bd565b6102136104233660046129d0565b61197b565b61043b6104363660046127a0565b611b2d565b604051610234919081518152602080830151908201526040808301516001600160a01b0316908201526060918201519181019190915260800190565b61022a610485366004612974565b60066020526000908152604090205481565b6102136104a53660046129f2565b611c51565b61022a600080516020612e7383398151915281565b6102136104cd366004612a1c565b611d2e565b6102136104e0366004612651565b611f26565b6105316104f33660046127a0565b60056020908152600092835260408084209091529082529020805460018201546002830154600390930154919290916001600160a01b039091169084565b604051610234949392919093845260208401929092526001600160a01b03166040830152606082015260800190565b61022a61056e3660046129d0565b600360209081526000928352604080842090915290825290205481565b6102136105993660046127a0565b611fd8565b6102136105ac366004612651565b612085565b6105b9612137565b6040516102349190612a88565b6000546105d9906001600160a01b031681565b6040516001600160a01b039091168152602001610234565b61031d6105ff3660046127a0565b600860209081526000928352604080842090915290825290205460ff1681565b6105b961062d366004612974565b6121c8565b8660008111801561064557506001548111155b61066a5760405162461bcd60e51b815260040161066190612a9b565b60405180910390fd5b6000888152600260205260409020600601548890600160a01b900460ff166106c95760405162461bcd60e51b8152602060048201526012602482015271506f6c6c206973206e6f742061637469766560701b6044820152606401610661565b600081815260026020526040902060060154600160a81b900460ff161561072b5760405162461bcd60e51b8152602060048201526016602482015275141bdb1b081a185cc81899595b8818d85b98d95b195960521b6044820152606401610661565b60008981526002602052604090206004015489904210156107895760405162461bcd60e51b8152602060048201526018602482015277141bdb1b081a185cc81b9bdd081cdd185c9d1959081e595d60421b6044820152606401610661565b6000818152600260205260409020600501544211156107db5760405162461bcd60e51b815260206004820152600e60248201526d141bdb1b081a185cc8195b99195960921b6044820152606401610661565b8642111561081f5760405162461bcd60e51b815260206004820152601160248201527014da59db985d1d5c9948195e1c1a5c9959607a1b6044820152606401610661565b7f7fffffffffffffffffffffffffffffff5d576e7357a4501ddfe92f46681b20a08411156108835760405162461bcd60e51b8152602060048201526011602482015270496e76616c6964207369676e617475726560781b6044820152606401610661565b604080517f5b1665022c1eea2d7304d2a42a2c8b440b73df47e8b78a57e9e667bd7dadd29460208201529081018b9052606081018a90526080810189905260a0810188905260009060c00160405160208183030381529060405280519060200120905060006108f061144f565b60405161190160f01b602082015260228101919091526042810183905260620160408051601f198184030181528282528051602091820120600080855291840180845281905260ff8c1692840192909252606083018a9052608083018990529092509060019060a0016020604051602081039080840390855afa15801561097b573d6000803e3d6000fd5b5050604051601f1901519150506001600160a01b0381166109d25760405162461bcd60e51b8152602060048201526011602482015270496e76616c6964207369676e617475726560781b6044820152606401610661565b6001600160a01b0381166000908152600760205260409020548b14610a295760405162461bcd60e51b815260206004820152600d60248201526c496e76616c6964206e6f6e636560981b6044820152606401610661565b6001600160a01b0381166000908152600760205260408120805491610a4d83612ade565b9190505550610a5d8d8d836122de565b50505050505050505050505050565b600080516020612e73833981519152610a8581336115ee565b610aa15760405162461bcd60e51b815260040161066190612af7565b81600081118015610ab457506001548111155b610ad05760405162461bcd60e51b815260040161066190612a9b565b600083815260026020526040808220600601805460ff60a01b191690555184917f5ae0578d893eb1f19cc15b9b2c5b1d5d9ccdcee8ddf1017525850fba90cb8d3a91a2505050565b606080606060008060008060008089600081118015610b3957506001548111155b610b555760405162461bcd60e51b815260040161066190612a9b565b60008b8152600260208190526040909120600481015460058201546006830154600784015460018501805495969095908701946003880194909390926001600160a01b0382169260ff600160a01b8404811693600160a81b90041691908990610bbd90612b3a565b80601f0160208091040260200160405190810160405280929190818152602001828054610be990612b3a565b8015610c365780601f10610c0b57610100808354040283529160200191610c36565b820191906000526020600020905b815481529060010190602001808311610c1957829003601f168201915b50505050509850878054610c4990612b3a565b80601f0160208091040260200160405190810160405280929190818152602001828054610c7590612b3a565b8015610cc25780601f10610c9757610100808354040283529160200191610cc2565b820191906000526020600020905b815481529060010190602001808311610ca557829003601f168201915b5050505050975086805480602002602001604051908101604052809291908181526020016000905b82821015610d96578382906000526020600020018054610d0990612b3a565b80601f0160208091040260200160405190810160405280929190818152602001828054610d3590612b3a565b8015610d825780601f10610d5757610100808354040283529160200191610d82565b820191906000526020600020905b815481529060010190602001808311610d6557829003601f168201915b505050505081526020019060010190610cea565b5050505096509a509a509a509a509a509a509a509a509a5050509193959799909294969850565b6000546001600160a01b03163314610de75760405162461bcd60e51b815260040161066190612b6e565b600080516020612e73833981519152821480610e105750600080516020612e5383398151915282145b610e4b5760405162461bcd60e51b815260206004820152600c60248201526b556e6b6e6f776e20726f6c6560a01b6044820152606401610661565b6001600160a01b038116610ea15760405162461bcd60e51b815260206004820152601760248201527f496e76616c6964206163636f756e7420616464726573730000000000000000006044820152606401610661565b60008281526008602090815260408083206001600160a01b038516845290915290205460ff16610f235760008281526008602090815260408083206001600160a01b0385168085529252808320805460ff1916600117905551909184917f2ae6a113c0ed5b78a53413ffbb7679881f11145ccfba4fb92e863dfcd5a1d2f39190a35b5050565b606080600083600081118015610f3f57506001548111155b610f5b5760405162461bcd60e51b815260040161066190612a9b565b600085815260026020526040902060038101548067ffffffffffffffff811115610f8757610f87612baf565b604051908082528060200260200182016040528015610fba57816020015b6060815260200190600190039081610fa55790505b5095508067ffffffffffffffff811115610fd657610fd6612baf565b604051908082528060200260200182016040528015610fff578160200160208202803683370190505b50945060005b8181101561111d5782600301818154811061102257611022612bc5565b90600052602060002001805461103790612b3a565b80601f016020809104026020016040519081016040528092919081815260200182805461106390612b3a565b80156110b05780601f10611085576101008083540402835291602001916110b0565b820191906000526020600020905b81548152906001019060200180831161109357829003601f168201915b50505050508782815181106110c7576110c7612bc5565b60209081029190910181019190915260008981526003825260408082208483529092522054865187908390811061110057611100612bc5565b60209081029190910101528061111581612ade565b915050611005565b50506007015493959294505050565b6000876111735760405162461bcd60e51b81526020600482015260156024820152745469746c652063616e6e6f7420626520656d70747960581b6044820152606401610661565b60028410156111c45760405162461bcd60e51b815260206004820152601b60248201527f4174206c656173742032206f7074696f6e7320726571756972656400000000006044820152606401610661565b8183106112085760405162461bcd60e51b8152602060048201526012602482015271496e76616c69642074696d652072616e676560701b6044820152606401610661565b428310156112585760405162461bcd60e51b815260206004820181905260248201527f53746172742074696d65206d75737420626520696e20746865206675747572656044820152606401610661565b6001805490600061126883612ade565b919050555060405180610140016040528060015481526020018a8a8080601f016020809104026020016040519081016040528093929190818152602001838380828437600092019190915250505090825250604080516020601f8b0181900481028201810190925289815291810191908a908a90819084018382808284376000920191909152505050908252506020016113028688612c0c565b815260208082018690526040808301869052336060840152600160808401819052600060a0850181905260c090940184905280548452600283529220835181559083015190918201906113559082612d2c565b506040820151600282019061136a9082612d2c565b5060608201518051611386916003840191602090910190612527565b506080820151600482015560a0820151600582015560c082015160068201805460e08501516101008601511515600160a81b0260ff60a81b19911515600160a01b026001600160a81b03199093166001600160a01b03909516949094179190911716919091179055610120909101516007909101556001546040513391907fca8cfbb6645913473bbcef27cf3f67a6711384974f848bc9208a5edc45b72f3790611437908d908d9089908990612dec565b60405180910390a35060015498975050505050505050565b6040805180820182526006815265566f74696e6760d01b6020918201528151808301835260018152603160f81b9082015281517f8b73c3c69bb8fe3d512ecc4cf759cc79239f7b179b0ffacaa9a75d522b39400f818301527e708f6dff7e47936b7b782b66cd2970ffdb146eb7167cefdda540e2e8d4c318818401527fc89efdaa54c0f20c7adf612882df0950f5a951637e0307cdcb4c672f298b8bc660608201524660808201523060a0808301919091528351808303909101815260c0909101909252815191012090565b6000546001600160a01b031633146115455760405162461bcd60e51b815260040161066190612b6e565b6001600160a01b0381166115935760405162461bcd60e51b8152602060048201526015602482015274496e76616c69642061646d696e206164647265737360581b6044820152606401610661565b600080546040516001600160a01b03808516939216917ff8ccb027dfcd135e000e9d45e6cc2d662578a8825d4c45b5e32e0adf67e79ec691a3600080546001600160a01b0319166001600160a01b0392909216919091179055565b600080546001600160a01b038381169116148061162d575060008381526008602090815260408083206001600160a01b038616845290915290205460ff165b90505b92915050565b6000808360008111801561164c57506001548111155b6116685760405162461bcd60e51b815260040161066190612a9b565b60008581526004602090815260408083206001600160a01b038816845290915290205460ff16925082156116bf5760008581526005602090815260408083206001600160a01b038816845290915290206001015491505b509250929050565b600260205260009081526040902080546001820180549192916116e990612b3a565b80601f016020809104026020016040519081016040528092919081815260200182805461171590612b3a565b80156117625780601f1061173757610100808354040283529160200191611762565b820191906000526020600020905b81548152906001019060200180831161174557829003601f168201915b50505050509080600201805461177790612b3a565b80601f01602080910402602001604051908101604052809291908181526020018280546117a390612b3a565b80156117f05780601f106117c5576101008083540402835291602001916117f0565b820191906000526020600020905b8154815290600101906020018083116117d357829003601f168201915b505050600484015460058501546006860154600790960154949591949093506001600160a01b038216925060ff600160a01b8304811692600160a81b9004169089565b60608160008111801561184857506001548111155b6118645760405162461bcd60e51b815260040161066190612a9b565b60008381526002602052604090206006810154600160a81b900460ff16156118af576040518060400160405280600881526020016710d85b98d95b195960c21b815250925050611975565b80600401544210156118e3576040518060400160405280600781526020016650656e64696e6760c81b815250925050611975565b80600501544211156119155760405180604001604052806005815260200164115b99195960da1b815250925050611975565b6006810154600160a01b900460ff1615611950576040518060400160405280600681526020016541637469766560d01b815250925050611975565b60405180604001604052806008815260200167496e61637469766560c01b8152509250505b50919050565b8160008111801561198e57506001548111155b6119aa5760405162461bcd60e51b815260040161066190612a9b565b6000838152600260205260409020600601548390600160a01b900460ff16611a095760405162461bcd60e51b8152602060048201526012602482015271506f6c6c206973206e6f742061637469766560701b6044820152606401610661565b600081815260026020526040902060060154600160a81b900460ff1615611a6b5760405162461bcd60e51b8152602060048201526016602482015275141bdb1b081a185cc81899595b8818d85b98d95b195960521b6044820152606401610661565b6000848152600260205260409020600401548490421015611ac95760405162461bcd60e51b8152602060048201526018602482015277141bdb1b081a185cc81b9bdd081cdd185c9d1959081e595d60421b6044820152606401610661565b600081815260026020526040902060050154421115611b1b5760405162461bcd60e51b815260206004820152600e60248201526d141bdb1b081a185cc8195b99195960921b6044820152606401610661565b611b268585336122de565b5050505050565b611b616040518060800160405280600081526020016000815260200160006001600160a01b03168152602001600081525090565b82600081118015611b7457506001548111155b611b905760405162461bcd60e51b815260040161066190612a9b565b60008481526004602090815260408083206001600160a01b038716845290915290205460ff16611bf85760405162461bcd60e51b8152602060048201526013602482015272159bdd195c881a185cc81b9bdd081d9bdd1959606a1b6044820152606401610661565b505060009182526005602090815260408084206001600160a01b03938416855282529283902083516080810185528154815260018201549281019290925260028101549092169281019290925260030154606082015290565b600080516020612e53833981519152611c6a81336115ee565b611c865760405162461bcd60e51b815260040161066190612af7565b6001600160a01b038316611cd45760405162461bcd60e51b8152602060048201526015602482015274496e76616c696420766f746572206164647265737360581b6044820152606401610661565b6001600160a01b03831660008181526006602052604090819020849055517f9f8423a58aeaafe36a6e48f9d7da061cfb6597f98ea34a45c53818a0031e5bbb90611d219085815260200190565b60405180910390a2505050565b600080516020612e53833981519152611d4781336115ee565b611d635760405162461bcd60e51b815260040161066190612af7565b838214611dab5760405162461bcd60e51b8152602060048201526016602482015275082e4e4c2f2e640d8cadccee8d040dad2e6dac2e8c6d60531b6044820152606401610661565b60005b84811015611f1e576000868683818110611dca57611dca612bc5565b9050602002016020810190611ddf9190612974565b6001600160a01b031603611e2d5760405162461bcd60e51b8152602060048201526015602482015274496e76616c696420766f746572206164647265737360581b6044820152606401610661565b838382818110611e3f57611e3f612bc5565b9050602002013560066000888885818110611e5c57611e5c612bc5565b9050602002016020810190611e719190612974565b6001600160a01b03168152602081019190915260400160002055858582818110611e9d57611e9d612bc5565b9050602002016020810190611eb29190612974565b6001600160a01b03167f9f8423a58aeaafe36a6e48f9d7da061cfb6597f98ea34a45c53818a0031e5bbb858584818110611eee57611eee612bc5565b90506020020135604051611f0491815260200190565b60405180910390a280611f1681612ade565b915050611dae565b505050505050565b600080516020612e73833981519152611f3f81336115ee565b611f5b5760405162461bcd60e51b815260040161066190612af7565b81600081118015611f6e57506001548111155b611f8a5760405162461bcd60e51b815260040161066190612a9b565b600083815260026020526040808220600601805460ff60a81b1916600160a81b1790555184917fcbf6c6edb69cb9a305bdb44565eee6d45abd4788040a52a368e5d93dc6d6d35c91a2505050565b6000546001600160a01b031633146120025760405162461bcd60e51b815260040161066190612b6e565b60008281526008602090815260408083206001600160a01b038516845290915290205460ff1615610f235760008281526008602090815260408083206001600160a01b0385168085529252808320805460ff1916905551909184917f155aaafb6329a2098580462df33ec4b7441b19729b9601c5fc17ae1cf99a8a529190a35050565b600080516020612e7383398151915261209e81336115ee565b6120ba5760405162461bcd60e51b815260040161066190612af7565b816000811180156120cd57506001548111155b6120e95760405162461bcd60e51b815260040161066190612a9b565b600083815260026020526040808220600601805460ff60a01b1916600160a01b1790555184917f4d863a7bb9fa1842bb9e4910f424611e539d2ba27a89dbd18d89ba8eb7a494c291a2505050565b6060600060015467ffffffffffffffff81111561215657612156612baf565b60405190808252806020026020018201604052801561217f578160200160208202803683370190505b50905060015b600154811161197557808261219b600183612e2c565b815181106121ab576121ab612bc5565b6020908102919091010152806121c081612ade565b915050612185565b6060600060015b600154811161221c576000818152600260205260409020600601546001600160a01b0380861691160361220a578161220681612ade565b9250505b8061221481612ade565b9150506121cf565b5060008167ffffffffffffffff81111561223857612238612baf565b604051908082528060200260200182016040528015612261578160200160208202803683370190505b509050600060015b60015481116122d4576000818152600260205260409020600601546001600160a01b038088169116036122c257808383815181106122a9576122a9612bc5565b6020908102919091010152816122be81612ade565b9250505b806122cc81612ade565b915050612269565b5090949350505050565b60008381526004602090815260408083206001600160a01b038516845290915290205460ff16156123415760405162461bcd60e51b815260206004820152600d60248201526c105b1c9958591e481d9bdd1959609a1b6044820152606401610661565b60008381526002602052604090206003015482106123985760405162461bcd60e51b8152602060048201526014602482015273092dcecc2d8d2c840dee0e8d2dedc40d2dcc8caf60631b6044820152606401610661565b6001600160a01b0381166000908152600660205260409020546123ef5760405162461bcd60e51b815260206004820152600f60248201526e2737903b37ba34b733903837bbb2b960891b6044820152606401610661565b6001600160a01b03811660008181526006602090815260408083205487845260048352818420948452938252808320805460ff19166001179055868352600382528083208684529091528120805483929061244b908490612e3f565b909155505060008481526002602052604081206007018054839290612471908490612e3f565b90915550506040805160808101825285815260208082018681526001600160a01b03868116848601818152426060870190815260008c81526005875288812084825287528890209651875593516001870155516002860180546001600160a01b031916919093161790915590516003909301929092558251868152908101849052909186917fb4c54afd73915f9f756c8dee39fe0b311937871a92261a47747f7a3d32f63a0c910160405180910390a350505050565b82805482825590600052602060002090810192821561256d579160200282015b8281111561256d578251829061255d9082612d2c565b5091602001919060010190612547565b5061257992915061257d565b5090565b80821115612579576000612591828261259a565b5060010161257d565b5080546125a690612b3a565b6000825580601f106125b6575050565b601f0160209004906000526020600020908101906125d491906125d7565b50565b5b8082111561257957600081556001016125d8565b600080600080600080600060e0888a03121561260757600080fd5b87359650602088013595506040880135945060608801359350608088013560ff8116811461263457600080fd5b9699959850939692959460a0840135945060c09093013592915050565b60006020828403121561266357600080fd5b5035919050565b6000815180845260005b8181101561269057602081850181015186830182015201612674565b506000602082860101526020601f19601f83011685010191505092915050565b600082825180855260208086019550808260051b84010181860160005b848110156126fb57601f198684030189526126e983835161266a565b988401989250908301906001016126cd565b5090979650505050505050565b600061012080835261271c8184018d61266a565b90508281036020840152612730818c61266a565b90508281036040840152612744818b6126b0565b6060840199909952505060808101959095526001600160a01b039390931660a085015290151560c0840152151560e0830152610100909101529392505050565b80356001600160a01b038116811461279b57600080fd5b919050565b600080604083850312156127b357600080fd5b823591506127c360208401612784565b90509250929050565b600081518084526020808501945080840160005b838110156127fc578151875295820195908201906001016127e0565b509495945050505050565b60608152600061281a60608301866126b0565b828103602084015261282c81866127cc565b915050826040830152949350505050565b60008083601f84011261284f57600080fd5b50813567ffffffffffffffff81111561286757600080fd5b60208301915083602082850101111561287f57600080fd5b9250929050565b60008083601f84011261289857600080fd5b50813567ffffffffffffffff8111156128b057600080fd5b6020830191508360208260051b850101111561287f57600080fd5b60008060008060008060008060a0898b0312156128e757600080fd5b883567ffffffffffffffff808211156128ff57600080fd5b61290b8c838d0161283d565b909a50985060208b013591508082111561292457600080fd5b6129308c838d0161283d565b909850965060408b013591508082111561294957600080fd5b506129568b828c01612886565b999c989b509699959896976060870135966080013595509350505050565b60006020828403121561298657600080fd5b61162d82612784565b60006101208b83528060208401526129a98184018c61266a565b90508281036040840152612744818b61266a565b60208152600061162d602083018461266a565b600080604083850312156129e357600080fd5b50508035926020909101359150565b60008060408385031215612a0557600080fd5b612a0e83612784565b946020939093013593505050565b60008060008060408587031215612a3257600080fd5b843567ffffffffffffffff80821115612a4a57600080fd5b612a5688838901612886565b90965094506020870135915080821115612a6f57600080fd5b50612a7c87828801612886565b95989497509550505050565b60208152600061162d60208301846127cc565b602080825260139082015272141bdb1b08191bd95cc81b9bdd08195e1a5cdd606a1b604082015260600190565b634e487b7160e01b600052601160045260246000fd5b600060018201612af057612af0612ac8565b5060010190565b60208082526023908201527f43616c6c6572206973206d697373696e672074686520726571756972656420726040820152626f6c6560e81b606082015260800190565b600181811c90821680612b4e57607f821691505b60208210810361197557634e487b7160e01b600052602260045260246000fd5b60208082526021908201527f4f6e6c792061646d696e2063616e2063616c6c20746869732066756e6374696f6040820152603760f91b606082015260800190565b634e487b7160e01b600052604160045260246000fd5b634e487b7160e01b600052603260045260246000fd5b604051601f8201601f1916810167ffffffffffffffff81118282101715612c0457612c04612baf565b604052919050565b600067ffffffffffffffff80841115612c2757612c27612baf565b8360051b6020612c38818301612bdb565b868152918501918181019036841115612c5057600080fd5b865b84811015612cd557803586811115612c6a5760008081fd5b8801601f3681830112612c7d5760008081fd5b813588811115612c8f57612c8f612baf565b612ca0818301601f19168801612bdb565b91508082523687828501011115612cb75760008081fd5b80878401888401376000908201870152845250918301918301612c52565b50979650505050505050565b601f821115612d2757600081815260208120601f850160051c81016020861015612d085750805b601f850160051c820191505b81811015611f1e57828155600101612d14565b505050565b815167ffffffffffffffff811115612d4657612d46612baf565b612d5a81612d548454612b3a565b84612ce1565b602080601f831160018114612d8f5760008415612d775750858301515b600019600386901b1c1916600185901b178555611f1e565b600085815260208120601f198616915b82811015612dbe57888601518255948401946001909101908401612d9f565b5085821015612ddc5787850151600019600388901b60f8161c191681555b5050505050600190811b01905550565b606081528360608201528385608083013760006080858301015260006080601f19601f870116830101905083602083015282604083015295945050505050565b8181038181111561163057611630612ac8565b8082018082111561163057611630612ac856fef01ddee12f733c3e98056007be26669f2c6d28bb720453a63f3a58c60fe3e667f7d93cb9f09c9f4c8548d99cf7a35b0bcfa92f8500317727364e2b555e9fb998a2646970667358221220542973101b932404780c34115a330b261e53eb8dfbc98a86b5d48d578d4ace7e64736f6c63430008150033",
+}
+
+// VotingABI is the input ABI used to generate the binding from.
+// Deprecated: Use VotingMetaData.ABI instead.
+var VotingABI = VotingMetaData.ABI
+
+// VotingBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use VotingMetaData.Bin instead.
+var VotingBin = VotingMetaData.Bin
+
+// DeployVoting deploys a new Ethereum contract, binding an instance of Voting to it.
+func DeployVoting(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *Voting, error) {
+	parsed, err := VotingMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(VotingBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &Voting{VotingCaller: VotingCaller{contract: contract}, VotingTransactor: VotingTransactor{contract: contract}, VotingFilterer: VotingFilterer{contract: contract}}, nil
+}
+
+// Voting is an auto generated Go binding around an Ethereum contract.
+type Voting struct {
+	VotingCaller     // Read-only binding to the contract
+	VotingTransactor // Write-only binding to the contract
+	VotingFilterer   // Log filterer for contract events
+}
+
+// VotingCaller is an auto generated read-only Go binding around an Ethereum contract.
+type VotingCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// VotingTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type VotingTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// VotingFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type VotingFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// VotingSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type VotingSession struct {
+	Contract     *Voting           // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// VotingCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type VotingCallerSession struct {
+	Contract *VotingCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts // Call options to use throughout this session
+}
+
+// VotingTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type VotingTransactorSession struct {
+	Contract     *VotingTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// VotingRaw is an auto generated low-level Go binding around an Ethereum contract.
+type VotingRaw struct {
+	Contract *Voting // Generic contract binding to access the raw methods on
+}
+
+// VotingCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type VotingCallerRaw struct {
+	Contract *VotingCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// VotingTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type VotingTransactorRaw struct {
+	Contract *VotingTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewVoting creates a new instance of Voting, bound to a specific deployed contract.
+func NewVoting(address common.Address, backend bind.ContractBackend) (*Voting, error) {
+	contract, err := bindVoting(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Voting{VotingCaller: VotingCaller{contract: contract}, VotingTransactor: VotingTransactor{contract: contract}, VotingFilterer: VotingFilterer{contract: contract}}, nil
+}
+
+// NewVotingCaller creates a new read-only instance of Voting, bound to a specific deployed contract.
+func NewVotingCaller(address common.Address, caller bind.ContractCaller) (*VotingCaller, error) {
+	contract, err := bindVoting(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingCaller{contract: contract}, nil
+}
+
+// NewVotingTransactor creates a new write-only instance of Voting, bound to a specific deployed contract.
+func NewVotingTransactor(address common.Address, transactor bind.ContractTransactor) (*VotingTransactor, error) {
+	contract, err := bindVoting(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingTransactor{contract: contract}, nil
+}
+
+// NewVotingFilterer creates a new log filterer instance of Voting, bound to a specific deployed contract.
+func NewVotingFilterer(address common.Address, filterer bind.ContractFilterer) (*VotingFilterer, error) {
+	contract, err := bindVoting(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingFilterer{contract: contract}, nil
+}
+
+// bindVoting binds a generic wrapper to an already deployed contract.
+func bindVoting(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := VotingMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Voting *VotingRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Voting.Contract.VotingCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Voting *VotingRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Voting.Contract.VotingTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Voting *VotingRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Voting.Contract.VotingTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Voting *VotingCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Voting.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Voting *VotingTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Voting.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Voting *VotingTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Voting.Contract.contract.Transact(opts, method, params...)
+}
+
+// DOMAINSEPARATOR is a free data retrieval call binding the contract method 0x3644e515.
+//
+// Solidity: function DOMAIN_SEPARATOR() view returns(bytes32)
+func (_Voting *VotingCaller) DOMAINSEPARATOR(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "DOMAIN_SEPARATOR")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// DOMAINSEPARATOR is a free data retrieval call binding the contract method 0x3644e515.
+//
+// Solidity: function DOMAIN_SEPARATOR() view returns(bytes32)
+func (_Voting *VotingSession) DOMAINSEPARATOR() ([32]byte, error) {
+	return _Voting.Contract.DOMAINSEPARATOR(&_Voting.CallOpts)
+}
+
+// DOMAINSEPARATOR is a free data retrieval call binding the contract method 0x3644e515.
+//
+// Solidity: function DOMAIN_SEPARATOR() view returns(bytes32)
+func (_Voting *VotingCallerSession) DOMAINSEPARATOR() ([32]byte, error) {
+	return _Voting.Contract.DOMAINSEPARATOR(&_Voting.CallOpts)
+}
+
+// DOMAINTYPEHASH is a free data retrieval call binding the contract method 0x20606b70.
+//
+// Solidity: function DOMAIN_TYPEHASH() view returns(bytes32)
+func (_Voting *VotingCaller) DOMAINTYPEHASH(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "DOMAIN_TYPEHASH")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// DOMAINTYPEHASH is a free data retrieval call binding the contract method 0x20606b70.
+//
+// Solidity: function DOMAIN_TYPEHASH() view returns(bytes32)
+func (_Voting *VotingSession) DOMAINTYPEHASH() ([32]byte, error) {
+	return _Voting.Contract.DOMAINTYPEHASH(&_Voting.CallOpts)
+}
+
+// DOMAINTYPEHASH is a free data retrieval call binding the contract method 0x20606b70.
+//
+// Solidity: function DOMAIN_TYPEHASH() view returns(bytes32)
+func (_Voting *VotingCallerSession) DOMAINTYPEHASH() ([32]byte, error) {
+	return _Voting.Contract.DOMAINTYPEHASH(&_Voting.CallOpts)
+}
+
+// POLLMANAGERROLE is a free data retrieval call binding the contract method 0xce3dbda1.
+//
+// Solidity: function POLL_MANAGER_ROLE() view returns(bytes32)
+func (_Voting *VotingCaller) POLLMANAGERROLE(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "POLL_MANAGER_ROLE")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// POLLMANAGERROLE is a free data retrieval call binding the contract method 0xce3dbda1.
+//
+// Solidity: function POLL_MANAGER_ROLE() view returns(bytes32)
+func (_Voting *VotingSession) POLLMANAGERROLE() ([32]byte, error) {
+	return _Voting.Contract.POLLMANAGERROLE(&_Voting.CallOpts)
+}
+
+// POLLMANAGERROLE is a free data retrieval call binding the contract method 0xce3dbda1.
+//
+// Solidity: function POLL_MANAGER_ROLE() view returns(bytes32)
+func (_Voting *VotingCallerSession) POLLMANAGERROLE() ([32]byte, error) {
+	return _Voting.Contract.POLLMANAGERROLE(&_Voting.CallOpts)
+}
+
+// POWERASSIGNERROLE is a free data retrieval call binding the contract method 0x05f48977.
+//
+// Solidity: function POWER_ASSIGNER_ROLE() view returns(bytes32)
+func (_Voting *VotingCaller) POWERASSIGNERROLE(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "POWER_ASSIGNER_ROLE")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// POWERASSIGNERROLE is a free data retrieval call binding the contract method 0x05f48977.
+//
+// Solidity: function POWER_ASSIGNER_ROLE() view returns(bytes32)
+func (_Voting *VotingSession) POWERASSIGNERROLE() ([32]byte, error) {
+	return _Voting.Contract.POWERASSIGNERROLE(&_Voting.CallOpts)
+}
+
+// POWERASSIGNERROLE is a free data retrieval call binding the contract method 0x05f48977.
+//
+// Solidity: function POWER_ASSIGNER_ROLE() view returns(bytes32)
+func (_Voting *VotingCallerSession) POWERASSIGNERROLE() ([32]byte, error) {
+	return _Voting.Contract.POWERASSIGNERROLE(&_Voting.CallOpts)
+}
+
+// VOTETYPEHASH is a free data retrieval call binding the contract method 0x86522973.
+//
+// Solidity: function VOTE_TYPEHASH() view returns(bytes32)
+func (_Voting *VotingCaller) VOTETYPEHASH(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "VOTE_TYPEHASH")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// VOTETYPEHASH is a free data retrieval call binding the contract method 0x86522973.
+//
+// Solidity: function VOTE_TYPEHASH() view returns(bytes32)
+func (_Voting *VotingSession) VOTETYPEHASH() ([32]byte, error) {
+	return _Voting.Contract.VOTETYPEHASH(&_Voting.CallOpts)
+}
+
+// VOTETYPEHASH is a free data retrieval call binding the contract method 0x86522973.
+//
+// Solidity: function VOTE_TYPEHASH() view returns(bytes32)
+func (_Voting *VotingCallerSession) VOTETYPEHASH() ([32]byte, error) {
+	return _Voting.Contract.VOTETYPEHASH(&_Voting.CallOpts)
+}
+
+// Admin is a free data retrieval call binding the contract method 0xf851a440.
+//
+// Solidity: function admin() view returns(address)
+func (_Voting *VotingCaller) Admin(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "admin")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Admin is a free data retrieval call binding the contract method 0xf851a440.
+//
+// Solidity: function admin() view returns(address)
+func (_Voting *VotingSession) Admin() (common.Address, error) {
+	return _Voting.Contract.Admin(&_Voting.CallOpts)
+}
+
+// Admin is a free data retrieval call binding the contract method 0xf851a440.
+//
+// Solidity: function admin() view returns(address)
+func (_Voting *VotingCallerSession) Admin() (common.Address, error) {
+	return _Voting.Contract.Admin(&_Voting.CallOpts)
+}
+
+// GetAllPollIds is a free data retrieval call binding the contract method 0xee0ca517.
+//
+// Solidity: function getAllPollIds() view returns(uint256[])
+func (_Voting *VotingCaller) GetAllPollIds(opts *bind.CallOpts) ([]*big.Int, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "getAllPollIds")
+
+	if err != nil {
+		return *new([]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+
+	return out0, err
+
+}
+
+// GetAllPollIds is a free data retrieval call binding the contract method 0xee0ca517.
+//
+// Solidity: function getAllPollIds() view returns(uint256[])
+func (_Voting *VotingSession) GetAllPollIds() ([]*big.Int, error) {
+	return _Voting.Contract.GetAllPollIds(&_Voting.CallOpts)
+}
+
+// GetAllPollIds is a free data retrieval call binding the contract method 0xee0ca517.
+//
+// Solidity: function getAllPollIds() view returns(uint256[])
+func (_Voting *VotingCallerSession) GetAllPollIds() ([]*big.Int, error) {
+	return _Voting.Contract.GetAllPollIds(&_Voting.CallOpts)
+}
+
+// GetPoll is a free data retrieval call binding the contract method 0x1a8cbcaa.
+//
+// Solidity: function getPoll(uint256 _pollId) view returns(string title, string description, string[] options, uint256 startTime, uint256 endTime, address creator, bool isActive, bool isCanceled, uint256 totalVotes)
+func (_Voting *VotingCaller) GetPoll(opts *bind.CallOpts, _pollId *big.Int) (struct {
+	Title       string
+	Description string
+	Options     []string
+	StartTime   *big.Int
+	EndTime     *big.Int
+	Creator     common.Address
+	IsActive    bool
+	IsCanceled  bool
+	TotalVotes  *big.Int
+}, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "getPoll", _pollId)
+
+	outstruct := new(struct {
+		Title       string
+		Description string
+		Options     []string
+		StartTime   *big.Int
+		EndTime     *big.Int
+		Creator     common.Address
+		IsActive    bool
+		IsCanceled  bool
+		TotalVotes  *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Title = *abi.ConvertType(out[0], new(string)).(*string)
+	outstruct.Description = *abi.ConvertType(out[1], new(string)).(*string)
+	outstruct.Options = *abi.ConvertType(out[2], new([]string)).(*[]string)
+	outstruct.StartTime = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.EndTime = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+	outstruct.Creator = *abi.ConvertType(out[5], new(common.Address)).(*common.Address)
+	outstruct.IsActive = *abi.ConvertType(out[6], new(bool)).(*bool)
+	outstruct.IsCanceled = *abi.ConvertType(out[7], new(bool)).(*bool)
+	outstruct.TotalVotes = *abi.ConvertType(out[8], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetPoll is a free data retrieval call binding the contract method 0x1a8cbcaa.
+//
+// Solidity: function getPoll(uint256 _pollId) view returns(string title, string description, string[] options, uint256 startTime, uint256 endTime, address creator, bool isActive, bool isCanceled, uint256 totalVotes)
+func (_Voting *VotingSession) GetPoll(_pollId *big.Int) (struct {
+	Title       string
+	Description string
+	Options     []string
+	StartTime   *big.Int
+	EndTime     *big.Int
+	Creator     common.Address
+	IsActive    bool
+	IsCanceled  bool
+	TotalVotes  *big.Int
+}, error) {
+	return _Voting.Contract.GetPoll(&_Voting.CallOpts, _pollId)
+}
+
+// GetPoll is a free data retrieval call binding the contract method 0x1a8cbcaa.
+//
+// Solidity: function getPoll(uint256 _pollId) view returns(string title, string description, string[] options, uint256 startTime, uint256 endTime, address creator, bool isActive, bool isCanceled, uint256 totalVotes)
+func (_Voting *VotingCallerSession) GetPoll(_pollId *big.Int) (struct {
+	Title       string
+	Description string
+	Options     []string
+	StartTime   *big.Int
+	EndTime     *big.Int
+	Creator     common.Address
+	IsActive    bool
+	IsCanceled  bool
+	TotalVotes  *big.Int
+}, error) {
+	return _Voting.Contract.GetPoll(&_Voting.CallOpts, _pollId)
+}
+
+// GetPollResults is a free data retrieval call binding the contract method 0x33b4097b.
+//
+// Solidity: function getPollResults(uint256 _pollId) view returns(string[] optionNames, uint256[] voteCountsArray, uint256 totalVotes)
+func (_Voting *VotingCaller) GetPollResults(opts *bind.CallOpts, _pollId *big.Int) (struct {
+	OptionNames     []string
+	VoteCountsArray []*big.Int
+	TotalVotes      *big.Int
+}, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "getPollResults", _pollId)
+
+	outstruct := new(struct {
+		OptionNames     []string
+		VoteCountsArray []*big.Int
+		TotalVotes      *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.OptionNames = *abi.ConvertType(out[0], new([]string)).(*[]string)
+	outstruct.VoteCountsArray = *abi.ConvertType(out[1], new([]*big.Int)).(*[]*big.Int)
+	outstruct.TotalVotes = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetPollResults is a free data retrieval call binding the contract method 0x33b4097b.
+//
+// Solidity: function getPollResults(uint256 _pollId) view returns(string[] optionNames, uint256[] voteCountsArray, uint256 totalVotes)
+func (_Voting *VotingSession) GetPollResults(_pollId *big.Int) (struct {
+	OptionNames     []string
+	VoteCountsArray []*big.Int
+	TotalVotes      *big.Int
+}, error) {
+	return _Voting.Contract.GetPollResults(&_Voting.CallOpts, _pollId)
+}
+
+// GetPollResults is a free data retrieval call binding the contract method 0x33b4097b.
+//
+// Solidity: function getPollResults(uint256 _pollId) view returns(string[] optionNames, uint256[] voteCountsArray, uint256 totalVotes)
+func (_Voting *VotingCallerSession) GetPollResults(_pollId *big.Int) (struct {
+	OptionNames     []string
+	VoteCountsArray []*big.Int
+	TotalVotes      *big.Int
+}, error) {
+	return _Voting.Contract.GetPollResults(&_Voting.CallOpts, _pollId)
+}
+
+// GetPollStatus is a free data retrieval call binding the contract method 0xb0c095e3.
+//
+// Solidity: function getPollStatus(uint256 _pollId) view returns(string status)
+func (_Voting *VotingCaller) GetPollStatus(opts *bind.CallOpts, _pollId *big.Int) (string, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "getPollStatus", _pollId)
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// GetPollStatus is a free data retrieval call binding the contract method 0xb0c095e3.
+//
+// Solidity: function getPollStatus(uint256 _pollId) view returns(string status)
+func (_Voting *VotingSession) GetPollStatus(_pollId *big.Int) (string, error) {
+	return _Voting.Contract.GetPollStatus(&_Voting.CallOpts, _pollId)
+}
+
+// GetPollStatus is a free data retrieval call binding the contract method 0xb0c095e3.
+//
+// Solidity: function getPollStatus(uint256 _pollId) view returns(string status)
+func (_Voting *VotingCallerSession) GetPollStatus(_pollId *big.Int) (string, error) {
+	return _Voting.Contract.GetPollStatus(&_Voting.CallOpts, _pollId)
+}
+
+// GetPollsByCreator is a free data retrieval call binding the contract method 0xfb790a32.
+//
+// Solidity: function getPollsByCreator(address _creator) view returns(uint256[])
+func (_Voting *VotingCaller) GetPollsByCreator(opts *bind.CallOpts, _creator common.Address) ([]*big.Int, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "getPollsByCreator", _creator)
+
+	if err != nil {
+		return *new([]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+
+	return out0, err
+
+}
+
+// GetPollsByCreator is a free data retrieval call binding the contract method 0xfb790a32.
+//
+// Solidity: function getPollsByCreator(address _creator) view returns(uint256[])
+func (_Voting *VotingSession) GetPollsByCreator(_creator common.Address) ([]*big.Int, error) {
+	return _Voting.Contract.GetPollsByCreator(&_Voting.CallOpts, _creator)
+}
+
+// GetPollsByCreator is a free data retrieval call binding the contract method 0xfb790a32.
+//
+// Solidity: function getPollsByCreator(address _creator) view returns(uint256[])
+func (_Voting *VotingCallerSession) GetPollsByCreator(_creator common.Address) ([]*big.Int, error) {
+	return _Voting.Contract.GetPollsByCreator(&_Voting.CallOpts, _creator)
+}
+
+// GetVote is a free data retrieval call binding the contract method 0xbc3f931f.
+//
+// Solidity: function getVote(uint256 _pollId, address _voter) view returns((uint256,uint256,address,uint256))
+func (_Voting *VotingCaller) GetVote(opts *bind.CallOpts, _pollId *big.Int, _voter common.Address) (VotingVote, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "getVote", _pollId, _voter)
+
+	if err != nil {
+		return *new(VotingVote), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(VotingVote)).(*VotingVote)
+
+	return out0, err
+
+}
+
+// GetVote is a free data retrieval call binding the contract method 0xbc3f931f.
+//
+// Solidity: function getVote(uint256 _pollId, address _voter) view returns((uint256,uint256,address,uint256))
+func (_Voting *VotingSession) GetVote(_pollId *big.Int, _voter common.Address) (VotingVote, error) {
+	return _Voting.Contract.GetVote(&_Voting.CallOpts, _pollId, _voter)
+}
+
+// GetVote is a free data retrieval call binding the contract method 0xbc3f931f.
+//
+// Solidity: function getVote(uint256 _pollId, address _voter) view returns((uint256,uint256,address,uint256))
+func (_Voting *VotingCallerSession) GetVote(_pollId *big.Int, _voter common.Address) (VotingVote, error) {
+	return _Voting.Contract.GetVote(&_Voting.CallOpts, _pollId, _voter)
+}
+
+// GetVoterStatus is a free data retrieval call binding the contract method 0x942f56bd.
+//
+// Solidity: function getVoterStatus(uint256 _pollId, address _voter) view returns(bool hasVotedStatus, uint256 optionIndex)
+func (_Voting *VotingCaller) GetVoterStatus(opts *bind.CallOpts, _pollId *big.Int, _voter common.Address) (struct {
+	HasVotedStatus bool
+	OptionIndex    *big.Int
+}, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "getVoterStatus", _pollId, _voter)
+
+	outstruct := new(struct {
+		HasVotedStatus bool
+		OptionIndex    *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.HasVotedStatus = *abi.ConvertType(out[0], new(bool)).(*bool)
+	outstruct.OptionIndex = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetVoterStatus is a free data retrieval call binding the contract method 0x942f56bd.
+//
+// Solidity: function getVoterStatus(uint256 _pollId, address _voter) view returns(bool hasVotedStatus, uint256 optionIndex)
+func (_Voting *VotingSession) GetVoterStatus(_pollId *big.Int, _voter common.Address) (struct {
+	HasVotedStatus bool
+	OptionIndex    *big.Int
+}, error) {
+	return _Voting.Contract.GetVoterStatus(&_Voting.CallOpts, _pollId, _voter)
+}
+
+// GetVoterStatus is a free data retrieval call binding the contract method 0x942f56bd.
+//
+// Solidity: function getVoterStatus(uint256 _pollId, address _voter) view returns(bool hasVotedStatus, uint256 optionIndex)
+func (_Voting *VotingCallerSession) GetVoterStatus(_pollId *big.Int, _voter common.Address) (struct {
+	HasVotedStatus bool
+	OptionIndex    *big.Int
+}, error) {
+	return _Voting.Contract.GetVoterStatus(&_Voting.CallOpts, _pollId, _voter)
+}
+
+// HasRole is a free data retrieval call binding the contract method 0x91d14854.
+//
+// Solidity: function hasRole(bytes32 _role, address _account) view returns(bool)
+func (_Voting *VotingCaller) HasRole(opts *bind.CallOpts, _role [32]byte, _account common.Address) (bool, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "hasRole", _role, _account)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// HasRole is a free data retrieval call binding the contract method 0x91d14854.
+//
+// Solidity: function hasRole(bytes32 _role, address _account) view returns(bool)
+func (_Voting *VotingSession) HasRole(_role [32]byte, _account common.Address) (bool, error) {
+	return _Voting.Contract.HasRole(&_Voting.CallOpts, _role, _account)
+}
+
+// HasRole is a free data retrieval call binding the contract method 0x91d14854.
+//
+// Solidity: function hasRole(bytes32 _role, address _account) view returns(bool)
+func (_Voting *VotingCallerSession) HasRole(_role [32]byte, _account common.Address) (bool, error) {
+	return _Voting.Contract.HasRole(&_Voting.CallOpts, _role, _account)
+}
+
+// HasVoted is a free data retrieval call binding the contract method 0x43859632.
+//
+// Solidity: function hasVoted(uint256 , address ) view returns(bool)
+func (_Voting *VotingCaller) HasVoted(opts *bind.CallOpts, arg0 *big.Int, arg1 common.Address) (bool, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "hasVoted", arg0, arg1)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// HasVoted is a free data retrieval call binding the contract method 0x43859632.
+//
+// Solidity: function hasVoted(uint256 , address ) view returns(bool)
+func (_Voting *VotingSession) HasVoted(arg0 *big.Int, arg1 common.Address) (bool, error) {
+	return _Voting.Contract.HasVoted(&_Voting.CallOpts, arg0, arg1)
+}
+
+// HasVoted is a free data retrieval call binding the contract method 0x43859632.
+//
+// Solidity: function hasVoted(uint256 , address ) view returns(bool)
+func (_Voting *VotingCallerSession) HasVoted(arg0 *big.Int, arg1 common.Address) (bool, error) {
+	return _Voting.Contract.HasVoted(&_Voting.CallOpts, arg0, arg1)
+}
+
+// Nonces is a free data retrieval call binding the contract method 0x7ecebe00.
+//
+// Solidity: function nonces(address ) view returns(uint256)
+func (_Voting *VotingCaller) Nonces(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "nonces", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Nonces is a free data retrieval call binding the contract method 0x7ecebe00.
+//
+// Solidity: function nonces(address ) view returns(uint256)
+func (_Voting *VotingSession) Nonces(arg0 common.Address) (*big.Int, error) {
+	return _Voting.Contract.Nonces(&_Voting.CallOpts, arg0)
+}
+
+// Nonces is a free data retrieval call binding the contract method 0x7ecebe00.
+//
+// Solidity: function nonces(address ) view returns(uint256)
+func (_Voting *VotingCallerSession) Nonces(arg0 common.Address) (*big.Int, error) {
+	return _Voting.Contract.Nonces(&_Voting.CallOpts, arg0)
+}
+
+// PollCount is a free data retrieval call binding the contract method 0x9207891d.
+//
+// Solidity: function pollCount() view returns(uint256)
+func (_Voting *VotingCaller) PollCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "pollCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// PollCount is a free data retrieval call binding the contract method 0x9207891d.
+//
+// Solidity: function pollCount() view returns(uint256)
+func (_Voting *VotingSession) PollCount() (*big.Int, error) {
+	return _Voting.Contract.PollCount(&_Voting.CallOpts)
+}
+
+// PollCount is a free data retrieval call binding the contract method 0x9207891d.
+//
+// Solidity: function pollCount() view returns(uint256)
+func (_Voting *VotingCallerSession) PollCount() (*big.Int, error) {
+	return _Voting.Contract.PollCount(&_Voting.CallOpts)
+}
+
+// Polls is a free data retrieval call binding the contract method 0xac2f0074.
+//
+// Solidity: function polls(uint256 ) view returns(uint256 id, string title, string description, uint256 startTime, uint256 endTime, address creator, bool isActive, bool isCanceled, uint256 totalVotes)
+func (_Voting *VotingCaller) Polls(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	Id          *big.Int
+	Title       string
+	Description string
+	StartTime   *big.Int
+	EndTime     *big.Int
+	Creator     common.Address
+	IsActive    bool
+	IsCanceled  bool
+	TotalVotes  *big.Int
+}, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "polls", arg0)
+
+	outstruct := new(struct {
+		Id          *big.Int
+		Title       string
+		Description string
+		StartTime   *big.Int
+		EndTime     *big.Int
+		Creator     common.Address
+		IsActive    bool
+		IsCanceled  bool
+		TotalVotes  *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Id = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Title = *abi.ConvertType(out[1], new(string)).(*string)
+	outstruct.Description = *abi.ConvertType(out[2], new(string)).(*string)
+	outstruct.StartTime = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.EndTime = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+	outstruct.Creator = *abi.ConvertType(out[5], new(common.Address)).(*common.Address)
+	outstruct.IsActive = *abi.ConvertType(out[6], new(bool)).(*bool)
+	outstruct.IsCanceled = *abi.ConvertType(out[7], new(bool)).(*bool)
+	outstruct.TotalVotes = *abi.ConvertType(out[8], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// Polls is a free data retrieval call binding the contract method 0xac2f0074.
+//
+// Solidity: function polls(uint256 ) view returns(uint256 id, string title, string description, uint256 startTime, uint256 endTime, address creator, bool isActive, bool isCanceled, uint256 totalVotes)
+func (_Voting *VotingSession) Polls(arg0 *big.Int) (struct {
+	Id          *big.Int
+	Title       string
+	Description string
+	StartTime   *big.Int
+	EndTime     *big.Int
+	Creator     common.Address
+	IsActive    bool
+	IsCanceled  bool
+	TotalVotes  *big.Int
+}, error) {
+	return _Voting.Contract.Polls(&_Voting.CallOpts, arg0)
+}
+
+// Polls is a free data retrieval call binding the contract method 0xac2f0074.
+//
+// Solidity: function polls(uint256 ) view returns(uint256 id, string title, string description, uint256 startTime, uint256 endTime, address creator, bool isActive, bool isCanceled, uint256 totalVotes)
+func (_Voting *VotingCallerSession) Polls(arg0 *big.Int) (struct {
+	Id          *big.Int
+	Title       string
+	Description string
+	StartTime   *big.Int
+	EndTime     *big.Int
+	Creator     common.Address
+	IsActive    bool
+	IsCanceled  bool
+	TotalVotes  *big.Int
+}, error) {
+	return _Voting.Contract.Polls(&_Voting.CallOpts, arg0)
+}
+
+// Roles is a free data retrieval call binding the contract method 0xf8fc08b9.
+//
+// Solidity: function roles(bytes32 , address ) view returns(bool)
+func (_Voting *VotingCaller) Roles(opts *bind.CallOpts, arg0 [32]byte, arg1 common.Address) (bool, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "roles", arg0, arg1)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Roles is a free data retrieval call binding the contract method 0xf8fc08b9.
+//
+// Solidity: function roles(bytes32 , address ) view returns(bool)
+func (_Voting *VotingSession) Roles(arg0 [32]byte, arg1 common.Address) (bool, error) {
+	return _Voting.Contract.Roles(&_Voting.CallOpts, arg0, arg1)
+}
+
+// Roles is a free data retrieval call binding the contract method 0xf8fc08b9.
+//
+// Solidity: function roles(bytes32 , address ) view returns(bool)
+func (_Voting *VotingCallerSession) Roles(arg0 [32]byte, arg1 common.Address) (bool, error) {
+	return _Voting.Contract.Roles(&_Voting.CallOpts, arg0, arg1)
+}
+
+// VoteCounts is a free data retrieval call binding the contract method 0xd2daa1c2.
+//
+// Solidity: function voteCounts(uint256 , uint256 ) view returns(uint256)
+func (_Voting *VotingCaller) VoteCounts(opts *bind.CallOpts, arg0 *big.Int, arg1 *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "voteCounts", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// VoteCounts is a free data retrieval call binding the contract method 0xd2daa1c2.
+//
+// Solidity: function voteCounts(uint256 , uint256 ) view returns(uint256)
+func (_Voting *VotingSession) VoteCounts(arg0 *big.Int, arg1 *big.Int) (*big.Int, error) {
+	return _Voting.Contract.VoteCounts(&_Voting.CallOpts, arg0, arg1)
+}
+
+// VoteCounts is a free data retrieval call binding the contract method 0xd2daa1c2.
+//
+// Solidity: function voteCounts(uint256 , uint256 ) view returns(uint256)
+func (_Voting *VotingCallerSession) VoteCounts(arg0 *big.Int, arg1 *big.Int) (*big.Int, error) {
+	return _Voting.Contract.VoteCounts(&_Voting.CallOpts, arg0, arg1)
+}
+
+// Votes is a free data retrieval call binding the contract method 0xd23254b4.
+//
+// Solidity: function votes(uint256 , address ) view returns(uint256 pollId, uint256 optionIndex, address voter, uint256 timestamp)
+func (_Voting *VotingCaller) Votes(opts *bind.CallOpts, arg0 *big.Int, arg1 common.Address) (struct {
+	PollId      *big.Int
+	OptionIndex *big.Int
+	Voter       common.Address
+	Timestamp   *big.Int
+}, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "votes", arg0, arg1)
+
+	outstruct := new(struct {
+		PollId      *big.Int
+		OptionIndex *big.Int
+		Voter       common.Address
+		Timestamp   *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.PollId = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.OptionIndex = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.Voter = *abi.ConvertType(out[2], new(common.Address)).(*common.Address)
+	outstruct.Timestamp = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// Votes is a free data retrieval call binding the contract method 0xd23254b4.
+//
+// Solidity: function votes(uint256 , address ) view returns(uint256 pollId, uint256 optionIndex, address voter, uint256 timestamp)
+func (_Voting *VotingSession) Votes(arg0 *big.Int, arg1 common.Address) (struct {
+	PollId      *big.Int
+	OptionIndex *big.Int
+	Voter       common.Address
+	Timestamp   *big.Int
+}, error) {
+	return _Voting.Contract.Votes(&_Voting.CallOpts, arg0, arg1)
+}
+
+// Votes is a free data retrieval call binding the contract method 0xd23254b4.
+//
+// Solidity: function votes(uint256 , address ) view returns(uint256 pollId, uint256 optionIndex, address voter, uint256 timestamp)
+func (_Voting *VotingCallerSession) Votes(arg0 *big.Int, arg1 common.Address) (struct {
+	PollId      *big.Int
+	OptionIndex *big.Int
+	Voter       common.Address
+	Timestamp   *big.Int
+}, error) {
+	return _Voting.Contract.Votes(&_Voting.CallOpts, arg0, arg1)
+}
+
+// VotingPower is a free data retrieval call binding the contract method 0xc07473f6.
+//
+// Solidity: function votingPower(address ) view returns(uint256)
+func (_Voting *VotingCaller) VotingPower(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "votingPower", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// VotingPower is a free data retrieval call binding the contract method 0xc07473f6.
+//
+// Solidity: function votingPower(address ) view returns(uint256)
+func (_Voting *VotingSession) VotingPower(arg0 common.Address) (*big.Int, error) {
+	return _Voting.Contract.VotingPower(&_Voting.CallOpts, arg0)
+}
+
+// VotingPower is a free data retrieval call binding the contract method 0xc07473f6.
+//
+// Solidity: function votingPower(address ) view returns(uint256)
+func (_Voting *VotingCallerSession) VotingPower(arg0 common.Address) (*big.Int, error) {
+	return _Voting.Contract.VotingPower(&_Voting.CallOpts, arg0)
+}
+
+// ActivatePoll is a paid mutator transaction binding the contract method 0xebb96896.
+//
+// Solidity: function activatePoll(uint256 _pollId) returns()
+func (_Voting *VotingTransactor) ActivatePoll(opts *bind.TransactOpts, _pollId *big.Int) (*types.Transaction, error) {
+	return _Voting.contract.Transact(opts, "activatePoll", _pollId)
+}
+
+// ActivatePoll is a paid mutator transaction binding the contract method 0xebb96896.
+//
+// Solidity: function activatePoll(uint256 _pollId) returns()
+func (_Voting *VotingSession) ActivatePoll(_pollId *big.Int) (*types.Transaction, error) {
+	return _Voting.Contract.ActivatePoll(&_Voting.TransactOpts, _pollId)
+}
+
+// ActivatePoll is a paid mutator transaction binding the contract method 0xebb96896.
+//
+// Solidity: function activatePoll(uint256 _pollId) returns()
+func (_Voting *VotingTransactorSession) ActivatePoll(_pollId *big.Int) (*types.Transaction, error) {
+	return _Voting.Contract.ActivatePoll(&_Voting.TransactOpts, _pollId)
+}
+
+// AssignVotingPower is a paid mutator transaction binding the contract method 0xc3a74f12.
+//
+// Solidity: function assignVotingPower(address _voter, uint256 _power) returns()
+func (_Voting *VotingTransactor) AssignVotingPower(opts *bind.TransactOpts, _voter common.Address, _power *big.Int) (*types.Transaction, error) {
+	return _Voting.contract.Transact(opts, "assignVotingPower", _voter, _power)
+}
+
+// AssignVotingPower is a paid mutator transaction binding the contract method 0xc3a74f12.
+//
+// Solidity: function assignVotingPower(address _voter, uint256 _power) returns()
+func (_Voting *VotingSession) AssignVotingPower(_voter common.Address, _power *big.Int) (*types.Transaction, error) {
+	return _Voting.Contract.AssignVotingPower(&_Voting.TransactOpts, _voter, _power)
+}
+
+// AssignVotingPower is a paid mutator transaction binding the contract method 0xc3a74f12.
+//
+// Solidity: function assignVotingPower(address _voter, uint256 _power) returns()
+func (_Voting *VotingTransactorSession) AssignVotingPower(_voter common.Address, _power *big.Int) (*types.Transaction, error) {
+	return _Voting.Contract.AssignVotingPower(&_Voting.TransactOpts, _voter, _power)
+}
+
+// BatchAssignVotingPower is a paid mutator transaction binding the contract method 0xcff9fced.
+//
+// Solidity: function batchAssignVotingPower(address[] _voters, uint256[] _powers) returns()
+func (_Voting *VotingTransactor) BatchAssignVotingPower(opts *bind.TransactOpts, _voters []common.Address, _powers []*big.Int) (*types.Transaction, error) {
+	return _Voting.contract.Transact(opts, "batchAssignVotingPower", _voters, _powers)
+}
+
+// BatchAssignVotingPower is a paid mutator transaction binding the contract method 0xcff9fced.
+//
+// Solidity: function batchAssignVotingPower(address[] _voters, uint256[] _powers) returns()
+func (_Voting *VotingSession) BatchAssignVotingPower(_voters []common.Address, _powers []*big.Int) (*types.Transaction, error) {
+	return _Voting.Contract.BatchAssignVotingPower(&_Voting.TransactOpts, _voters, _powers)
+}
+
+// BatchAssignVotingPower is a paid mutator transaction binding the contract method 0xcff9fced.
+//
+// Solidity: function batchAssignVotingPower(address[] _voters, uint256[] _powers) returns()
+func (_Voting *VotingTransactorSession) BatchAssignVotingPower(_voters []common.Address, _powers []*big.Int) (*types.Transaction, error) {
+	return _Voting.Contract.BatchAssignVotingPower(&_Voting.TransactOpts, _voters, _powers)
+}
+
+// CancelPoll is a paid mutator transaction binding the contract method 0xd0ec1607.
+//
+// Solidity: function cancelPoll(uint256 _pollId) returns()
+func (_Voting *VotingTransactor) CancelPoll(opts *bind.TransactOpts, _pollId *big.Int) (*types.Transaction, error) {
+	return _Voting.contract.Transact(opts, "cancelPoll", _pollId)
+}
+
+// CancelPoll is a paid mutator transaction binding the contract method 0xd0ec1607.
+//
+// Solidity: function cancelPoll(uint256 _pollId) returns()
+func (_Voting *VotingSession) CancelPoll(_pollId *big.Int) (*types.Transaction, error) {
+	return _Voting.Contract.CancelPoll(&_Voting.TransactOpts, _pollId)
+}
+
+// CancelPoll is a paid mutator transaction binding the contract method 0xd0ec1607.
+//
+// Solidity: function cancelPoll(uint256 _pollId) returns()
+func (_Voting *VotingTransactorSession) CancelPoll(_pollId *big.Int) (*types.Transaction, error) {
+	return _Voting.Contract.CancelPoll(&_Voting.TransactOpts, _pollId)
+}
+
+// CreatePoll is a paid mutator transaction binding the contract method 0x34b4bd60.
+//
+// Solidity: function createPoll(string _title, string _description, string[] _options, uint256 _startTime, uint256 _endTime) returns(uint256)
+func (_Voting *VotingTransactor) CreatePoll(opts *bind.TransactOpts, _title string, _description string, _options []string, _startTime *big.Int, _endTime *big.Int) (*types.Transaction, error) {
+	return _Voting.contract.Transact(opts, "createPoll", _title, _description, _options, _startTime, _endTime)
+}
+
+// CreatePoll is a paid mutator transaction binding the contract method 0x34b4bd60.
+//
+// Solidity: function createPoll(string _title, string _description, string[] _options, uint256 _startTime, uint256 _endTime) returns(uint256)
+func (_Voting *VotingSession) CreatePoll(_title string, _description string, _options []string, _startTime *big.Int, _endTime *big.Int) (*types.Transaction, error) {
+	return _Voting.Contract.CreatePoll(&_Voting.TransactOpts, _title, _description, _options, _startTime, _endTime)
+}
+
+// CreatePoll is a paid mutator transaction binding the contract method 0x34b4bd60.
+//
+// Solidity: function createPoll(string _title, string _description, string[] _options, uint256 _startTime, uint256 _endTime) returns(uint256)
+func (_Voting *VotingTransactorSession) CreatePoll(_title string, _description string, _options []string, _startTime *big.Int, _endTime *big.Int) (*types.Transaction, error) {
+	return _Voting.Contract.CreatePoll(&_Voting.TransactOpts, _title, _description, _options, _startTime, _endTime)
+}
+
+// DeactivatePoll is a paid mutator transaction binding the contract method 0x0732d075.
+//
+// Solidity: function deactivatePoll(uint256 _pollId) returns()
+func (_Voting *VotingTransactor) DeactivatePoll(opts *bind.TransactOpts, _pollId *big.Int) (*types.Transaction, error) {
+	return _Voting.contract.Transact(opts, "deactivatePoll", _pollId)
+}
+
+// DeactivatePoll is a paid mutator transaction binding the contract method 0x0732d075.
+//
+// Solidity: function deactivatePoll(uint256 _pollId) returns()
+func (_Voting *VotingSession) DeactivatePoll(_pollId *big.Int) (*types.Transaction, error) {
+	return _Voting.Contract.DeactivatePoll(&_Voting.TransactOpts, _pollId)
+}
+
+// DeactivatePoll is a paid mutator transaction binding the contract method 0x0732d075.
+//
+// Solidity: function deactivatePoll(uint256 _pollId) returns()
+func (_Voting *VotingTransactorSession) DeactivatePoll(_pollId *big.Int) (*types.Transaction, error) {
+	return _Voting.Contract.DeactivatePoll(&_Voting.TransactOpts, _pollId)
+}
+
+// GrantRole is a paid mutator transaction binding the contract method 0x2f2ff15d.
+//
+// Solidity: function grantRole(bytes32 _role, address _account) returns()
+func (_Voting *VotingTransactor) GrantRole(opts *bind.TransactOpts, _role [32]byte, _account common.Address) (*types.Transaction, error) {
+	return _Voting.contract.Transact(opts, "grantRole", _role, _account)
+}
+
+// GrantRole is a paid mutator transaction binding the contract method 0x2f2ff15d.
+//
+// Solidity: function grantRole(bytes32 _role, address _account) returns()
+func (_Voting *VotingSession) GrantRole(_role [32]byte, _account common.Address) (*types.Transaction, error) {
+	return _Voting.Contract.GrantRole(&_Voting.TransactOpts, _role, _account)
+}
+
+// GrantRole is a paid mutator transaction binding the contract method 0x2f2ff15d.
+//
+// Solidity: function grantRole(bytes32 _role, address _account) returns()
+func (_Voting *VotingTransactorSession) GrantRole(_role [32]byte, _account common.Address) (*types.Transaction, error) {
+	return _Voting.Contract.GrantRole(&_Voting.TransactOpts, _role, _account)
+}
+
+// RevokeRole is a paid mutator transaction binding the contract method 0xd547741f.
+//
+// Solidity: function revokeRole(bytes32 _role, address _account) returns()
+func (_Voting *VotingTransactor) RevokeRole(opts *bind.TransactOpts, _role [32]byte, _account common.Address) (*types.Transaction, error) {
+	return _Voting.contract.Transact(opts, "revokeRole", _role, _account)
+}
+
+// RevokeRole is a paid mutator transaction binding the contract method 0xd547741f.
+//
+// Solidity: function revokeRole(bytes32 _role, address _account) returns()
+func (_Voting *VotingSession) RevokeRole(_role [32]byte, _account common.Address) (*types.Transaction, error) {
+	return _Voting.Contract.RevokeRole(&_Voting.TransactOpts, _role, _account)
+}
+
+// RevokeRole is a paid mutator transaction binding the contract method 0xd547741f.
+//
+// Solidity: function revokeRole(bytes32 _role, address _account) returns()
+func (_Voting *VotingTransactorSession) RevokeRole(_role [32]byte, _account common.Address) (*types.Transaction, error) {
+	return _Voting.Contract.RevokeRole(&_Voting.TransactOpts, _role, _account)
+}
+
+// TransferAdmin is a paid mutator transaction binding the contract method 0x75829def.
+//
+// Solidity: function transferAdmin(address _newAdmin) returns()
+func (_Voting *VotingTransactor) TransferAdmin(opts *bind.TransactOpts, _newAdmin common.Address) (*types.Transaction, error) {
+	return _Voting.contract.Transact(opts, "transferAdmin", _newAdmin)
+}
+
+// TransferAdmin is a paid mutator transaction binding the contract method 0x75829def.
+//
+// Solidity: function transferAdmin(address _newAdmin) returns()
+func (_Voting *VotingSession) TransferAdmin(_newAdmin common.Address) (*types.Transaction, error) {
+	return _Voting.Contract.TransferAdmin(&_Voting.TransactOpts, _newAdmin)
+}
+
+// TransferAdmin is a paid mutator transaction binding the contract method 0x75829def.
+//
+// Solidity: function transferAdmin(address _newAdmin) returns()
+func (_Voting *VotingTransactorSession) TransferAdmin(_newAdmin common.Address) (*types.Transaction, error) {
+	return _Voting.Contract.TransferAdmin(&_Voting.TransactOpts, _newAdmin)
+}
+
+// Vote is a paid mutator transaction binding the contract method 0xb384abef.
+//
+// Solidity: function vote(uint256 _pollId, uint256 _optionIndex) returns()
+func (_Voting *VotingTransactor) Vote(opts *bind.TransactOpts, _pollId *big.Int, _optionIndex *big.Int) (*types.Transaction, error) {
+	return _Voting.contract.Transact(opts, "vote", _pollId, _optionIndex)
+}
+
+// Vote is a paid mutator transaction binding the contract method 0xb384abef.
+//
+// Solidity: function vote(uint256 _pollId, uint256 _optionIndex) returns()
+func (_Voting *VotingSession) Vote(_pollId *big.Int, _optionIndex *big.Int) (*types.Transaction, error) {
+	return _Voting.Contract.Vote(&_Voting.TransactOpts, _pollId, _optionIndex)
+}
+
+// Vote is a paid mutator transaction binding the contract method 0xb384abef.
+//
+// Solidity: function vote(uint256 _pollId, uint256 _optionIndex) returns()
+func (_Voting *VotingTransactorSession) Vote(_pollId *big.Int, _optionIndex *big.Int) (*types.Transaction, error) {
+	return _Voting.Contract.Vote(&_Voting.TransactOpts, _pollId, _optionIndex)
+}
+
+// VoteBySig is a paid mutator transaction binding the contract method 0x04f81b35.
+//
+// Solidity: function voteBySig(uint256 _pollId, uint256 _optionIndex, uint256 _nonce, uint256 _deadline, uint8 _v, bytes32 _r, bytes32 _s) returns()
+func (_Voting *VotingTransactor) VoteBySig(opts *bind.TransactOpts, _pollId *big.Int, _optionIndex *big.Int, _nonce *big.Int, _deadline *big.Int, _v uint8, _r [32]byte, _s [32]byte) (*types.Transaction, error) {
+	return _Voting.contract.Transact(opts, "voteBySig", _pollId, _optionIndex, _nonce, _deadline, _v, _r, _s)
+}
+
+// VoteBySig is a paid mutator transaction binding the contract method 0x04f81b35.
+//
+// Solidity: function voteBySig(uint256 _pollId, uint256 _optionIndex, uint256 _nonce, uint256 _deadline, uint8 _v, bytes32 _r, bytes32 _s) returns()
+func (_Voting *VotingSession) VoteBySig(_pollId *big.Int, _optionIndex *big.Int, _nonce *big.Int, _deadline *big.Int, _v uint8, _r [32]byte, _s [32]byte) (*types.Transaction, error) {
+	return _Voting.Contract.VoteBySig(&_Voting.TransactOpts, _pollId, _optionIndex, _nonce, _deadline, _v, _r, _s)
+}
+
+// VoteBySig is a paid mutator transaction binding the contract method 0x04f81b35.
+//
+// Solidity: function voteBySig(uint256 _pollId, uint256 _optionIndex, uint256 _nonce, uint256 _deadline, uint8 _v, bytes32 _r, bytes32 _s) returns()
+func (_Voting *VotingTransactorSession) VoteBySig(_pollId *big.Int, _optionIndex *big.Int, _nonce *big.Int, _deadline *big.Int, _v uint8, _r [32]byte, _s [32]byte) (*types.Transaction, error) {
+	return _Voting.Contract.VoteBySig(&_Voting.TransactOpts, _pollId, _optionIndex, _nonce, _deadline, _v, _r, _s)
+}
+
+// VotingAdminTransferredIterator is returned from FilterAdminTransferred and is used to iterate over the raw logs and unpacked data for AdminTransferred events raised by the Voting contract.
+type VotingAdminTransferredIterator struct {
+	Event *VotingAdminTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *VotingAdminTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(VotingAdminTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(VotingAdminTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *VotingAdminTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *VotingAdminTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// VotingAdminTransferred represents a AdminTransferred event raised by the Voting contract.
+type VotingAdminTransferred struct {
+	PreviousAdmin common.Address
+	NewAdmin      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterAdminTransferred is a free log retrieval operation binding the contract event 0xf8ccb027dfcd135e000e9d45e6cc2d662578a8825d4c45b5e32e0adf67e79ec6.
+//
+// Solidity: event AdminTransferred(address indexed previousAdmin, address indexed newAdmin)
+func (_Voting *VotingFilterer) FilterAdminTransferred(opts *bind.FilterOpts, previousAdmin []common.Address, newAdmin []common.Address) (*VotingAdminTransferredIterator, error) {
+
+	var previousAdminRule []interface{}
+	for _, previousAdminItem := range previousAdmin {
+		previousAdminRule = append(previousAdminRule, previousAdminItem)
+	}
+	var newAdminRule []interface{}
+	for _, newAdminItem := range newAdmin {
+		newAdminRule = append(newAdminRule, newAdminItem)
+	}
+
+	logs, sub, err := _Voting.contract.FilterLogs(opts, "AdminTransferred", previousAdminRule, newAdminRule)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingAdminTransferredIterator{contract: _Voting.contract, event: "AdminTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchAdminTransferred is a free log subscription operation binding the contract event 0xf8ccb027dfcd135e000e9d45e6cc2d662578a8825d4c45b5e32e0adf67e79ec6.
+//
+// Solidity: event AdminTransferred(address indexed previousAdmin, address indexed newAdmin)
+func (_Voting *VotingFilterer) WatchAdminTransferred(opts *bind.WatchOpts, sink chan<- *VotingAdminTransferred, previousAdmin []common.Address, newAdmin []common.Address) (event.Subscription, error) {
+
+	var previousAdminRule []interface{}
+	for _, previousAdminItem := range previousAdmin {
+		previousAdminRule = append(previousAdminRule, previousAdminItem)
+	}
+	var newAdminRule []interface{}
+	for _, newAdminItem := range newAdmin {
+		newAdminRule = append(newAdminRule, newAdminItem)
+	}
+
+	logs, sub, err := _Voting.contract.WatchLogs(opts, "AdminTransferred", previousAdminRule, newAdminRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(VotingAdminTransferred)
+				if err := _Voting.contract.UnpackLog(event, "AdminTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAdminTransferred is a log parse operation binding the contract event 0xf8ccb027dfcd135e000e9d45e6cc2d662578a8825d4c45b5e32e0adf67e79ec6.
+//
+// Solidity: event AdminTransferred(address indexed previousAdmin, address indexed newAdmin)
+func (_Voting *VotingFilterer) ParseAdminTransferred(log types.Log) (*VotingAdminTransferred, error) {
+	event := new(VotingAdminTransferred)
+	if err := _Voting.contract.UnpackLog(event, "AdminTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// VotingPollActivatedIterator is returned from FilterPollActivated and is used to iterate over the raw logs and unpacked data for PollActivated events raised by the Voting contract.
+type VotingPollActivatedIterator struct {
+	Event *VotingPollActivated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *VotingPollActivatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(VotingPollActivated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(VotingPollActivated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *VotingPollActivatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *VotingPollActivatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// VotingPollActivated represents a PollActivated event raised by the Voting contract.
+type VotingPollActivated struct {
+	PollId *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterPollActivated is a free log retrieval operation binding the contract event 0x4d863a7bb9fa1842bb9e4910f424611e539d2ba27a89dbd18d89ba8eb7a494c2.
+//
+// Solidity: event PollActivated(uint256 indexed pollId)
+func (_Voting *VotingFilterer) FilterPollActivated(opts *bind.FilterOpts, pollId []*big.Int) (*VotingPollActivatedIterator, error) {
+
+	var pollIdRule []interface{}
+	for _, pollIdItem := range pollId {
+		pollIdRule = append(pollIdRule, pollIdItem)
+	}
+
+	logs, sub, err := _Voting.contract.FilterLogs(opts, "PollActivated", pollIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingPollActivatedIterator{contract: _Voting.contract, event: "PollActivated", logs: logs, sub: sub}, nil
+}
+
+// WatchPollActivated is a free log subscription operation binding the contract event 0x4d863a7bb9fa1842bb9e4910f424611e539d2ba27a89dbd18d89ba8eb7a494c2.
+//
+// Solidity: event PollActivated(uint256 indexed pollId)
+func (_Voting *VotingFilterer) WatchPollActivated(opts *bind.WatchOpts, sink chan<- *VotingPollActivated, pollId []*big.Int) (event.Subscription, error) {
+
+	var pollIdRule []interface{}
+	for _, pollIdItem := range pollId {
+		pollIdRule = append(pollIdRule, pollIdItem)
+	}
+
+	logs, sub, err := _Voting.contract.WatchLogs(opts, "PollActivated", pollIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(VotingPollActivated)
+				if err := _Voting.contract.UnpackLog(event, "PollActivated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePollActivated is a log parse operation binding the contract event 0x4d863a7bb9fa1842bb9e4910f424611e539d2ba27a89dbd18d89ba8eb7a494c2.
+//
+// Solidity: event PollActivated(uint256 indexed pollId)
+func (_Voting *VotingFilterer) ParsePollActivated(log types.Log) (*VotingPollActivated, error) {
+	event := new(VotingPollActivated)
+	if err := _Voting.contract.UnpackLog(event, "PollActivated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// VotingPollCanceledIterator is returned from FilterPollCanceled and is used to iterate over the raw logs and unpacked data for PollCanceled events raised by the Voting contract.
+type VotingPollCanceledIterator struct {
+	Event *VotingPollCanceled // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *VotingPollCanceledIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(VotingPollCanceled)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(VotingPollCanceled)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *VotingPollCanceledIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *VotingPollCanceledIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// VotingPollCanceled represents a PollCanceled event raised by the Voting contract.
+type VotingPollCanceled struct {
+	PollId *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterPollCanceled is a free log retrieval operation binding the contract event 0xcbf6c6edb69cb9a305bdb44565eee6d45abd4788040a52a368e5d93dc6d6d35c.
+//
+// Solidity: event PollCanceled(uint256 indexed pollId)
+func (_Voting *VotingFilterer) FilterPollCanceled(opts *bind.FilterOpts, pollId []*big.Int) (*VotingPollCanceledIterator, error) {
+
+	var pollIdRule []interface{}
+	for _, pollIdItem := range pollId {
+		pollIdRule = append(pollIdRule, pollIdItem)
+	}
+
+	logs, sub, err := _Voting.contract.FilterLogs(opts, "PollCanceled", pollIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingPollCanceledIterator{contract: _Voting.contract, event: "PollCanceled", logs: logs, sub: sub}, nil
+}
+
+// WatchPollCanceled is a free log subscription operation binding the contract event 0xcbf6c6edb69cb9a305bdb44565eee6d45abd4788040a52a368e5d93dc6d6d35c.
+//
+// Solidity: event PollCanceled(uint256 indexed pollId)
+func (_Voting *VotingFilterer) WatchPollCanceled(opts *bind.WatchOpts, sink chan<- *VotingPollCanceled, pollId []*big.Int) (event.Subscription, error) {
+
+	var pollIdRule []interface{}
+	for _, pollIdItem := range pollId {
+		pollIdRule = append(pollIdRule, pollIdItem)
+	}
+
+	logs, sub, err := _Voting.contract.WatchLogs(opts, "PollCanceled", pollIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(VotingPollCanceled)
+				if err := _Voting.contract.UnpackLog(event, "PollCanceled", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePollCanceled is a log parse operation binding the contract event 0xcbf6c6edb69cb9a305bdb44565eee6d45abd4788040a52a368e5d93dc6d6d35c.
+//
+// Solidity: event PollCanceled(uint256 indexed pollId)
+func (_Voting *VotingFilterer) ParsePollCanceled(log types.Log) (*VotingPollCanceled, error) {
+	event := new(VotingPollCanceled)
+	if err := _Voting.contract.UnpackLog(event, "PollCanceled", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// VotingPollCreatedIterator is returned from FilterPollCreated and is used to iterate over the raw logs and unpacked data for PollCreated events raised by the Voting contract.
+type VotingPollCreatedIterator struct {
+	Event *VotingPollCreated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *VotingPollCreatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(VotingPollCreated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(VotingPollCreated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *VotingPollCreatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *VotingPollCreatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// VotingPollCreated represents a PollCreated event raised by the Voting contract.
+type VotingPollCreated struct {
+	PollId    *big.Int
+	Title     string
+	Creator   common.Address
+	StartTime *big.Int
+	EndTime   *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterPollCreated is a free log retrieval operation binding the contract event 0xca8cfbb6645913473bbcef27cf3f67a6711384974f848bc9208a5edc45b72f37.
+//
+// Solidity: event PollCreated(uint256 indexed pollId, string title, address indexed creator, uint256 startTime, uint256 endTime)
+func (_Voting *VotingFilterer) FilterPollCreated(opts *bind.FilterOpts, pollId []*big.Int, creator []common.Address) (*VotingPollCreatedIterator, error) {
+
+	var pollIdRule []interface{}
+	for _, pollIdItem := range pollId {
+		pollIdRule = append(pollIdRule, pollIdItem)
+	}
+
+	var creatorRule []interface{}
+	for _, creatorItem := range creator {
+		creatorRule = append(creatorRule, creatorItem)
+	}
+
+	logs, sub, err := _Voting.contract.FilterLogs(opts, "PollCreated", pollIdRule, creatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingPollCreatedIterator{contract: _Voting.contract, event: "PollCreated", logs: logs, sub: sub}, nil
+}
+
+// WatchPollCreated is a free log subscription operation binding the contract event 0xca8cfbb6645913473bbcef27cf3f67a6711384974f848bc9208a5edc45b72f37.
+//
+// Solidity: event PollCreated(uint256 indexed pollId, string title, address indexed creator, uint256 startTime, uint256 endTime)
+func (_Voting *VotingFilterer) WatchPollCreated(opts *bind.WatchOpts, sink chan<- *VotingPollCreated, pollId []*big.Int, creator []common.Address) (event.Subscription, error) {
+
+	var pollIdRule []interface{}
+	for _, pollIdItem := range pollId {
+		pollIdRule = append(pollIdRule, pollIdItem)
+	}
+
+	var creatorRule []interface{}
+	for _, creatorItem := range creator {
+		creatorRule = append(creatorRule, creatorItem)
+	}
+
+	logs, sub, err := _Voting.contract.WatchLogs(opts, "PollCreated", pollIdRule, creatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(VotingPollCreated)
+				if err := _Voting.contract.UnpackLog(event, "PollCreated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePollCreated is a log parse operation binding the contract event 0xca8cfbb6645913473bbcef27cf3f67a6711384974f848bc9208a5edc45b72f37.
+//
+// Solidity: event PollCreated(uint256 indexed pollId, string title, address indexed creator, uint256 startTime, uint256 endTime)
+func (_Voting *VotingFilterer) ParsePollCreated(log types.Log) (*VotingPollCreated, error) {
+	event := new(VotingPollCreated)
+	if err := _Voting.contract.UnpackLog(event, "PollCreated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// VotingPollDeactivatedIterator is returned from FilterPollDeactivated and is used to iterate over the raw logs and unpacked data for PollDeactivated events raised by the Voting contract.
+type VotingPollDeactivatedIterator struct {
+	Event *VotingPollDeactivated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *VotingPollDeactivatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(VotingPollDeactivated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(VotingPollDeactivated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *VotingPollDeactivatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *VotingPollDeactivatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// VotingPollDeactivated represents a PollDeactivated event raised by the Voting contract.
+type VotingPollDeactivated struct {
+	PollId *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterPollDeactivated is a free log retrieval operation binding the contract event 0x5ae0578d893eb1f19cc15b9b2c5b1d5d9ccdcee8ddf1017525850fba90cb8d3a.
+//
+// Solidity: event PollDeactivated(uint256 indexed pollId)
+func (_Voting *VotingFilterer) FilterPollDeactivated(opts *bind.FilterOpts, pollId []*big.Int) (*VotingPollDeactivatedIterator, error) {
+
+	var pollIdRule []interface{}
+	for _, pollIdItem := range pollId {
+		pollIdRule = append(pollIdRule, pollIdItem)
+	}
+
+	logs, sub, err := _Voting.contract.FilterLogs(opts, "PollDeactivated", pollIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingPollDeactivatedIterator{contract: _Voting.contract, event: "PollDeactivated", logs: logs, sub: sub}, nil
+}
+
+// WatchPollDeactivated is a free log subscription operation binding the contract event 0x5ae0578d893eb1f19cc15b9b2c5b1d5d9ccdcee8ddf1017525850fba90cb8d3a.
+//
+// Solidity: event PollDeactivated(uint256 indexed pollId)
+func (_Voting *VotingFilterer) WatchPollDeactivated(opts *bind.WatchOpts, sink chan<- *VotingPollDeactivated, pollId []*big.Int) (event.Subscription, error) {
+
+	var pollIdRule []interface{}
+	for _, pollIdItem := range pollId {
+		pollIdRule = append(pollIdRule, pollIdItem)
+	}
+
+	logs, sub, err := _Voting.contract.WatchLogs(opts, "PollDeactivated", pollIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(VotingPollDeactivated)
+				if err := _Voting.contract.UnpackLog(event, "PollDeactivated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePollDeactivated is a log parse operation binding the contract event 0x5ae0578d893eb1f19cc15b9b2c5b1d5d9ccdcee8ddf1017525850fba90cb8d3a.
+//
+// Solidity: event PollDeactivated(uint256 indexed pollId)
+func (_Voting *VotingFilterer) ParsePollDeactivated(log types.Log) (*VotingPollDeactivated, error) {
+	event := new(VotingPollDeactivated)
+	if err := _Voting.contract.UnpackLog(event, "PollDeactivated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// VotingRoleGrantedIterator is returned from FilterRoleGranted and is used to iterate over the raw logs and unpacked data for RoleGranted events raised by the Voting contract.
+type VotingRoleGrantedIterator struct {
+	Event *VotingRoleGranted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *VotingRoleGrantedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(VotingRoleGranted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(VotingRoleGranted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *VotingRoleGrantedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *VotingRoleGrantedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// VotingRoleGranted represents a RoleGranted event raised by the Voting contract.
+type VotingRoleGranted struct {
+	Role    [32]byte
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterRoleGranted is a free log retrieval operation binding the contract event 0x2ae6a113c0ed5b78a53413ffbb7679881f11145ccfba4fb92e863dfcd5a1d2f3.
+//
+// Solidity: event RoleGranted(bytes32 indexed role, address indexed account)
+func (_Voting *VotingFilterer) FilterRoleGranted(opts *bind.FilterOpts, role [][32]byte, account []common.Address) (*VotingRoleGrantedIterator, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _Voting.contract.FilterLogs(opts, "RoleGranted", roleRule, accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingRoleGrantedIterator{contract: _Voting.contract, event: "RoleGranted", logs: logs, sub: sub}, nil
+}
+
+// WatchRoleGranted is a free log subscription operation binding the contract event 0x2ae6a113c0ed5b78a53413ffbb7679881f11145ccfba4fb92e863dfcd5a1d2f3.
+//
+// Solidity: event RoleGranted(bytes32 indexed role, address indexed account)
+func (_Voting *VotingFilterer) WatchRoleGranted(opts *bind.WatchOpts, sink chan<- *VotingRoleGranted, role [][32]byte, account []common.Address) (event.Subscription, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _Voting.contract.WatchLogs(opts, "RoleGranted", roleRule, accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(VotingRoleGranted)
+				if err := _Voting.contract.UnpackLog(event, "RoleGranted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRoleGranted is a log parse operation binding the contract event 0x2ae6a113c0ed5b78a53413ffbb7679881f11145ccfba4fb92e863dfcd5a1d2f3.
+//
+// Solidity: event RoleGranted(bytes32 indexed role, address indexed account)
+func (_Voting *VotingFilterer) ParseRoleGranted(log types.Log) (*VotingRoleGranted, error) {
+	event := new(VotingRoleGranted)
+	if err := _Voting.contract.UnpackLog(event, "RoleGranted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// VotingRoleRevokedIterator is returned from FilterRoleRevoked and is used to iterate over the raw logs and unpacked data for RoleRevoked events raised by the Voting contract.
+type VotingRoleRevokedIterator struct {
+	Event *VotingRoleRevoked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *VotingRoleRevokedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(VotingRoleRevoked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(VotingRoleRevoked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *VotingRoleRevokedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *VotingRoleRevokedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// VotingRoleRevoked represents a RoleRevoked event raised by the Voting contract.
+type VotingRoleRevoked struct {
+	Role    [32]byte
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterRoleRevoked is a free log retrieval operation binding the contract event 0x155aaafb6329a2098580462df33ec4b7441b19729b9601c5fc17ae1cf99a8a52.
+//
+// Solidity: event RoleRevoked(bytes32 indexed role, address indexed account)
+func (_Voting *VotingFilterer) FilterRoleRevoked(opts *bind.FilterOpts, role [][32]byte, account []common.Address) (*VotingRoleRevokedIterator, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _Voting.contract.FilterLogs(opts, "RoleRevoked", roleRule, accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingRoleRevokedIterator{contract: _Voting.contract, event: "RoleRevoked", logs: logs, sub: sub}, nil
+}
+
+// WatchRoleRevoked is a free log subscription operation binding the contract event 0x155aaafb6329a2098580462df33ec4b7441b19729b9601c5fc17ae1cf99a8a52.
+//
+// Solidity: event RoleRevoked(bytes32 indexed role, address indexed account)
+func (_Voting *VotingFilterer) WatchRoleRevoked(opts *bind.WatchOpts, sink chan<- *VotingRoleRevoked, role [][32]byte, account []common.Address) (event.Subscription, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _Voting.contract.WatchLogs(opts, "RoleRevoked", roleRule, accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(VotingRoleRevoked)
+				if err := _Voting.contract.UnpackLog(event, "RoleRevoked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRoleRevoked is a log parse operation binding the contract event 0x155aaafb6329a2098580462df33ec4b7441b19729b9601c5fc17ae1cf99a8a52.
+//
+// Solidity: event RoleRevoked(bytes32 indexed role, address indexed account)
+func (_Voting *VotingFilterer) ParseRoleRevoked(log types.Log) (*VotingRoleRevoked, error) {
+	event := new(VotingRoleRevoked)
+	if err := _Voting.contract.UnpackLog(event, "RoleRevoked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// VotingVotedIterator is returned from FilterVoted and is used to iterate over the raw logs and unpacked data for Voted events raised by the Voting contract.
+type VotingVotedIterator struct {
+	Event *VotingVoted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *VotingVotedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(VotingVoted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(VotingVoted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *VotingVotedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *VotingVotedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// VotingVoted represents a Voted event raised by the Voting contract.
+type VotingVoted struct {
+	PollId      *big.Int
+	Voter       common.Address
+	OptionIndex *big.Int
+	Weight      *big.Int
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterVoted is a free log retrieval operation binding the contract event 0xb4c54afd73915f9f756c8dee39fe0b311937871a92261a47747f7a3d32f63a0c.
+//
+// Solidity: event Voted(uint256 indexed pollId, address indexed voter, uint256 optionIndex, uint256 weight)
+func (_Voting *VotingFilterer) FilterVoted(opts *bind.FilterOpts, pollId []*big.Int, voter []common.Address) (*VotingVotedIterator, error) {
+
+	var pollIdRule []interface{}
+	for _, pollIdItem := range pollId {
+		pollIdRule = append(pollIdRule, pollIdItem)
+	}
+	var voterRule []interface{}
+	for _, voterItem := range voter {
+		voterRule = append(voterRule, voterItem)
+	}
+
+	logs, sub, err := _Voting.contract.FilterLogs(opts, "Voted", pollIdRule, voterRule)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingVotedIterator{contract: _Voting.contract, event: "Voted", logs: logs, sub: sub}, nil
+}
+
+// WatchVoted is a free log subscription operation binding the contract event 0xb4c54afd73915f9f756c8dee39fe0b311937871a92261a47747f7a3d32f63a0c.
+//
+// Solidity: event Voted(uint256 indexed pollId, address indexed voter, uint256 optionIndex, uint256 weight)
+func (_Voting *VotingFilterer) WatchVoted(opts *bind.WatchOpts, sink chan<- *VotingVoted, pollId []*big.Int, voter []common.Address) (event.Subscription, error) {
+
+	var pollIdRule []interface{}
+	for _, pollIdItem := range pollId {
+		pollIdRule = append(pollIdRule, pollIdItem)
+	}
+	var voterRule []interface{}
+	for _, voterItem := range voter {
+		voterRule = append(voterRule, voterItem)
+	}
+
+	logs, sub, err := _Voting.contract.WatchLogs(opts, "Voted", pollIdRule, voterRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(VotingVoted)
+				if err := _Voting.contract.UnpackLog(event, "Voted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseVoted is a log parse operation binding the contract event 0xb4c54afd73915f9f756c8dee39fe0b311937871a92261a47747f7a3d32f63a0c.
+//
+// Solidity: event Voted(uint256 indexed pollId, address indexed voter, uint256 optionIndex, uint256 weight)
+func (_Voting *VotingFilterer) ParseVoted(log types.Log) (*VotingVoted, error) {
+	event := new(VotingVoted)
+	if err := _Voting.contract.UnpackLog(event, "Voted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// VotingVotingPowerAssignedIterator is returned from FilterVotingPowerAssigned and is used to iterate over the raw logs and unpacked data for VotingPowerAssigned events raised by the Voting contract.
+type VotingVotingPowerAssignedIterator struct {
+	Event *VotingVotingPowerAssigned // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *VotingVotingPowerAssignedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(VotingVotingPowerAssigned)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(VotingVotingPowerAssigned)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *VotingVotingPowerAssignedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *VotingVotingPowerAssignedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// VotingVotingPowerAssigned represents a VotingPowerAssigned event raised by the Voting contract.
+type VotingVotingPowerAssigned struct {
+	Voter common.Address
+	Power *big.Int
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterVotingPowerAssigned is a free log retrieval operation binding the contract event 0x9f8423a58aeaafe36a6e48f9d7da061cfb6597f98ea34a45c53818a0031e5bbb.
+//
+// Solidity: event VotingPowerAssigned(address indexed voter, uint256 power)
+func (_Voting *VotingFilterer) FilterVotingPowerAssigned(opts *bind.FilterOpts, voter []common.Address) (*VotingVotingPowerAssignedIterator, error) {
+
+	var voterRule []interface{}
+	for _, voterItem := range voter {
+		voterRule = append(voterRule, voterItem)
+	}
+
+	logs, sub, err := _Voting.contract.FilterLogs(opts, "VotingPowerAssigned", voterRule)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingVotingPowerAssignedIterator{contract: _Voting.contract, event: "VotingPowerAssigned", logs: logs, sub: sub}, nil
+}
+
+// WatchVotingPowerAssigned is a free log subscription operation binding the contract event 0x9f8423a58aeaafe36a6e48f9d7da061cfb6597f98ea34a45c53818a0031e5bbb.
+//
+// Solidity: event VotingPowerAssigned(address indexed voter, uint256 power)
+func (_Voting *VotingFilterer) WatchVotingPowerAssigned(opts *bind.WatchOpts, sink chan<- *VotingVotingPowerAssigned, voter []common.Address) (event.Subscription, error) {
+
+	var voterRule []interface{}
+	for _, voterItem := range voter {
+		voterRule = append(voterRule, voterItem)
+	}
+
+	logs, sub, err := _Voting.contract.WatchLogs(opts, "VotingPowerAssigned", voterRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(VotingVotingPowerAssigned)
+				if err := _Voting.contract.UnpackLog(event, "VotingPowerAssigned", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseVotingPowerAssigned is a log parse operation binding the contract event 0x9f8423a58aeaafe36a6e48f9d7da061cfb6597f98ea34a45c53818a0031e5bbb.
+//
+// Solidity: event VotingPowerAssigned(address indexed voter, uint256 power)
+func (_Voting *VotingFilterer) ParseVotingPowerAssigned(log types.Log) (*VotingVotingPowerAssigned, error) {
+	event := new(VotingVotingPowerAssigned)
+	if err := _Voting.contract.UnpackLog(event, "VotingPowerAssigned", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,128 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// maxPollerReorgDepth bounds how many block hashes a LogPoller keeps to find
+// the fork point of a reorganization
+const maxPollerReorgDepth = 128
+
+// ReorgError is returned by LogPoller.Poll when blocks it already returned
+// left the canonical chain. The next Poll returns the logs of the canonical
+// blocks after Block.
+type ReorgError struct {
+	Block uint64
+}
+
+func (e *ReorgError) Error() string {
+	return fmt.Sprintf("chain reorganization: blocks after %d were replaced", e.Block)
+}
+
+// LogPoller follows the contract's logs by polling the node, which also
+// works over HTTP where log subscriptions are unavailable
+type LogPoller struct {
+	client    *Client
+	start     uint64
+	next      uint64
+	batchSize uint64
+
+	// read holds the last block of each range read, oldest first
+	read []polledBlock
+}
+
+// polledBlock is the last block of a range a LogPoller read
+type polledBlock struct {
+	number uint64
+	hash   common.Hash
+}
+
+// NewLogPoller creates a poller whose first Poll returns logs from block
+// from onward, reading at most batchSize blocks per call
+func (c *Client) NewLogPoller(from, batchSize uint64) *LogPoller {
+	if batchSize == 0 {
+		batchSize = 2000
+	}
+	return &LogPoller{client: c, start: from, next: from, batchSize: batchSize}
+}
+
+// Poll returns the contract logs mined since the previous call, in chain
+// order, with the header of the last block read. The header is nil when no
+// new block has been mined. If the blocks read before were replaced by a
+// reorganization, Poll returns a *ReorgError instead and rewinds to the
+// fork point.
+func (p *LogPoller) Poll(ctx context.Context) (*types.Header, []types.Log, error) {
+	head, err := p.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if head < p.next {
+		return nil, nil, nil
+	}
+
+	// The first new block must build on the last one read
+	if len(p.read) > 0 {
+		first, err := p.client.HeaderByNumber(ctx, p.next)
+		if err != nil {
+			return nil, nil, err
+		}
+		if first.ParentHash != p.read[len(p.read)-1].hash {
+			return nil, nil, p.rewind(ctx)
+		}
+	}
+
+	to := p.next + p.batchSize - 1
+	if to > head {
+		to = head
+	}
+	header, err := p.client.HeaderByNumber(ctx, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	logs, err := p.client.FilterContractLogs(ctx, p.next, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p.next = to + 1
+	p.read = append(p.read, polledBlock{number: to, hash: header.Hash()})
+	if len(p.read) > maxPollerReorgDepth {
+		p.read = p.read[len(p.read)-maxPollerReorgDepth:]
+	}
+	return header, logs, nil
+}
+
+// rewind moves the poller back to the newest block read that is still
+// canonical, or to where it started if none is, and reports the fork point
+func (p *LogPoller) rewind(ctx context.Context) error {
+	for i := len(p.read) - 1; i >= 0; i-- {
+		b := p.read[i]
+		header, err := p.client.HeaderByNumber(ctx, b.number)
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if header.Hash() != b.hash {
+			continue
+		}
+		if i == len(p.read)-1 {
+			// The chain changed again while it was being compared
+			return nil
+		}
+		p.read = p.read[:i+1]
+		p.next = b.number + 1
+		return &ReorgError{Block: b.number}
+	}
+
+	p.read = nil
+	p.next = p.start
+	return &ReorgError{Block: p.start - 1}
+}
@@ -0,0 +1,52 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestLogPollerRewindsOnReorg(t *testing.T) {
+	client, sim, err := NewSimulatedClient(SimulatedOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	ctx := context.Background()
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	poller := client.NewLogPoller(head+1, 0)
+
+	createTestPoll(t, client, sim, "Yes", "No")
+	header, logs, err := poller.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header == nil || header.Number.Uint64() != head+1 || len(logs) != 1 {
+		t.Fatalf("poll = %v with %d logs, want block %d with the poll's log", header, len(logs), head+1)
+	}
+
+	// A longer chain without the poll replaces the block read
+	if err := sim.Backend.Fork(ctx, header.ParentHash); err != nil {
+		t.Fatal(err)
+	}
+	sim.Backend.Commit()
+	sim.Backend.Commit()
+
+	var reorg *ReorgError
+	if _, _, err := poller.Poll(ctx); !errors.As(err, &reorg) || reorg.Block != head {
+		t.Fatalf("poll after the reorg: %v, want blocks after %d replaced", err, head)
+	}
+	header, logs, err = poller.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header == nil || header.Number.Uint64() != head+2 || len(logs) != 0 {
+		t.Errorf("poll = %v with %d logs, want block %d without logs", header, len(logs), head+2)
+	}
+	if header, _, err := poller.Poll(ctx); err != nil || header != nil {
+		t.Errorf("poll without new blocks = %v, %v", header, err)
+	}
+}
@@ -0,0 +1,96 @@
+// Package cache stores encoded read results by key. An in-memory LRU is
+// provided; an external cache can be plugged in by implementing Cache.
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache stores values by key. Implementations must be safe for concurrent
+// use and may drop entries at any time.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte)
+	Delete(key string)
+}
+
+// LRU is an in-memory Cache holding up to a fixed number of entries, which
+// evicts the least recently used entry when full
+type LRU struct {
+	size int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// lruEntry is a cached value and its key, kept in the recency list
+type lruEntry struct {
+	key   string
+	value []byte
+}
+
+var _ Cache = (*LRU)(nil)
+
+// NewLRU creates a cache holding up to size entries
+func NewLRU(size int) *LRU {
+	if size < 1 {
+		size = 1
+	}
+	return &LRU{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the value stored under key and marks it recently used
+func (c *LRU) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*lruEntry).value, true
+}
+
+// Set stores value under key, evicting the least recently used entry if the
+// cache is full
+func (c *LRU) Set(key string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*lruEntry).value = value
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Delete removes the value stored under key
+func (c *LRU) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.order.Remove(el)
+		delete(c.entries, key)
+	}
+}
+
+// Len returns the number of entries held
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
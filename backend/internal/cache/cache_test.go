@@ -0,0 +1,32 @@
+package cache
+
+import "testing"
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU(2)
+	c.Set("a", []byte("1"))
+	c.Set("b", []byte("2"))
+	if v, ok := c.Get("a"); !ok || string(v) != "1" {
+		t.Fatalf("a = %q, %v", v, ok)
+	}
+
+	// b is now the least recently used entry
+	c.Set("c", []byte("3"))
+	if _, ok := c.Get("b"); ok {
+		t.Error("b was not evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("%s was evicted", key)
+		}
+	}
+
+	c.Set("a", []byte("4"))
+	if v, _ := c.Get("a"); string(v) != "4" || c.Len() != 2 {
+		t.Errorf("a = %q with %d entries after update", v, c.Len())
+	}
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok || c.Len() != 1 {
+		t.Errorf("a still cached after delete, %d entries", c.Len())
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -10,9 +11,68 @@ import (
 type Config struct {
 	ServerPort   string
 	EthRPCUrl    string
+	EthRPCUrls   []string
+	EthChainID   int
 	ContractAddr string
 	AdminPrivKey string
-	CORSOrigins  []string
+	SignerType   string
+
+	KeystorePath         string
+	KeystorePassword     string
+	KeystorePasswordFile string
+	RemoteSignerURL      string
+	RemoteSignerAddress  string
+	RemoteSignerMethod   string
+
+	OperatorPrivKeys  []string
+	OperatorKeystores []string
+	OperatorAddresses []string
+
+	MulticallAddr string
+
+	CacheSize int
+
+	CORSOrigins   []string
+	DatabasePath  string
+	TxTimeout     int
+	JobInterval   int
+	TxStuckAfter  int
+	TxMaxSpeedups int
+
+	RPCReconnectMin int
+	RPCReconnectMax int
+	RPCPollInterval int
+	RPCHealthCheck  int
+	RPCMaxLag       int
+	RPCQuorum       int
+
+	FeeHistoryBlocks    int
+	FeeRewardPercentile float64
+	FeeBaseMultiplier   float64
+	GasLimitMultiplier  float64
+	MaxFeeGwei          float64
+	MaxPriorityFeeGwei  float64
+
+	IndexerEnabled    bool
+	IndexerStartBlock int
+	IndexerBatchSize  int
+	IndexerInterval   int
+	Confirmations     int
+
+	AuthDomain   string
+	AuthChainID  int
+	AuthSecret   string
+	AuthTokenTTL int
+
+	Simulated        bool
+	SimBlockInterval int
+	SimVoters        int
+	SimVotingPower   int
+	SimOperators     int
+
+	StreamInterval   int
+	StreamHeartbeat  int
+	StreamBufferSize int
 }
 
 var AppConfig Config
@@ -24,11 +84,75 @@ func LoadConfig() error {
 	AppConfig = Config{
 		ServerPort:   getEnv("SERVER_PORT", "8080"),
 		EthRPCUrl:    getEnv("ETH_RPC_URL", "http://127.0.0.1:8545"),
+		EthChainID:   getEnvAsInt("ETH_CHAIN_ID", 0),
 		ContractAddr: getEnv("CONTRACT_ADDRESS", ""),
 		AdminPrivKey: getEnv("ADMIN_PRIVATE_KEY", ""),
-		CORSOrigins:  []string{getEnv("CORS_ORIGIN", "http://localhost:5173")},
+		SignerType:   getEnv("SIGNER_TYPE", "key"),
+
+		KeystorePath:         getEnv("KEYSTORE_PATH", ""),
+		KeystorePassword:     getEnv("KEYSTORE_PASSWORD", ""),
+		KeystorePasswordFile: getEnv("KEYSTORE_PASSWORD_FILE", ""),
+		RemoteSignerURL:      getEnv("REMOTE_SIGNER_URL", ""),
+		RemoteSignerAddress:  getEnv("REMOTE_SIGNER_ADDRESS", ""),
+		RemoteSignerMethod:   getEnv("REMOTE_SIGNER_METHOD", ""),
+
+		MulticallAddr: getEnv("MULTICALL_ADDRESS", ""),
+
+		CacheSize: getEnvAsInt("CACHE_SIZE", 1000),
+
+		CORSOrigins:   []string{getEnv("CORS_ORIGIN", "http://localhost:5173")},
+		DatabasePath:  getEnv("DATABASE_PATH", "voting.db"),
+		TxTimeout:     getEnvAsInt("TX_TIMEOUT_SECONDS", 120),
+		JobInterval:   getEnvAsInt("TX_JOB_INTERVAL_SECONDS", 2),
+		TxStuckAfter:  getEnvAsInt("TX_STUCK_AFTER_SECONDS", 60),
+		TxMaxSpeedups: getEnvAsInt("TX_MAX_SPEEDUPS", 3),
+
+		RPCReconnectMin: getEnvAsInt("RPC_RECONNECT_MIN_SECONDS", 1),
+		RPCReconnectMax: getEnvAsInt("RPC_RECONNECT_MAX_SECONDS", 30),
+		RPCPollInterval: getEnvAsInt("RPC_POLL_INTERVAL_SECONDS", 2),
+		RPCHealthCheck:  getEnvAsInt("RPC_HEALTH_INTERVAL_SECONDS", 10),
+		RPCMaxLag:       getEnvAsInt("RPC_MAX_LAG_BLOCKS", 5),
+		RPCQuorum:       getEnvAsInt("RPC_QUORUM", 0),
+
+		FeeHistoryBlocks:    getEnvAsInt("FEE_HISTORY_BLOCKS", 10),
+		FeeRewardPercentile: getEnvAsFloat("FEE_REWARD_PERCENTILE", 50),
+		FeeBaseMultiplier:   getEnvAsFloat("FEE_BASE_MULTIPLIER", 2),
+		GasLimitMultiplier:  getEnvAsFloat("GAS_LIMIT_MULTIPLIER", 1.2),
+		MaxFeeGwei:          getEnvAsFloat("MAX_FEE_GWEI", 0),
+		MaxPriorityFeeGwei:  getEnvAsFloat("MAX_PRIORITY_FEE_GWEI", 0),
+
+		IndexerEnabled:    getEnvAsBool("INDEXER_ENABLED", false),
+		IndexerStartBlock: getEnvAsInt("INDEXER_START_BLOCK", 0),
+		IndexerBatchSize:  getEnvAsInt("INDEXER_BATCH_SIZE", 2000),
+		IndexerInterval:   getEnvAsInt("INDEXER_INTERVAL_SECONDS", 5),
+		Confirmations:     getEnvAsInt("INDEXER_CONFIRMATIONS", 12),
+
+		AuthDomain:   getEnv("AUTH_DOMAIN", "localhost:5173"),
+		AuthChainID:  getEnvAsInt("AUTH_CHAIN_ID", 0),
+		AuthSecret:   getEnv("AUTH_JWT_SECRET", ""),
+		AuthTokenTTL: getEnvAsInt("AUTH_TOKEN_TTL_MINUTES", 60),
+
+		Simulated:        getEnvAsBool("SIMULATED", false),
+		SimBlockInterval: getEnvAsInt("SIM_BLOCK_INTERVAL_SECONDS", 1),
+		SimVoters:        getEnvAsInt("SIM_VOTERS", 5),
+		SimVotingPower:   getEnvAsInt("SIM_VOTING_POWER", 10),
+		SimOperators:     getEnvAsInt("SIM_OPERATORS", 0),
+
+		StreamInterval:   getEnvAsInt("STREAM_INTERVAL_SECONDS", 2),
+		StreamHeartbeat:  getEnvAsInt("STREAM_HEARTBEAT_SECONDS", 15),
+		StreamBufferSize: getEnvAsInt("STREAM_BUFFER_SIZE", 1000),
 	}
 
+	// ETH_RPC_URLS lists several endpoints to fail over between
+	AppConfig.EthRPCUrls = getEnvAsList("ETH_RPC_URLS", []string{AppConfig.EthRPCUrl})
+
+	// Operator accounts to dispatch write transactions across, given the
+	// same way as the admin signer: keys, keystore files sharing the
+	// keystore password, or addresses held by the remote signer
+	AppConfig.OperatorPrivKeys = getEnvAsList("OPERATOR_PRIVATE_KEYS", nil)
+	AppConfig.OperatorKeystores = getEnvAsList("OPERATOR_KEYSTORE_PATHS", nil)
+	AppConfig.OperatorAddresses = getEnvAsList("OPERATOR_ADDRESSES", nil)
+
 	return nil
 }
 
@@ -39,6 +163,19 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvAsList(key string, defaultValue []string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return defaultValue
+	}
+	return values
+}
+
 func getEnvAsInt(key string, defaultValue int) int {
 	valueStr := os.Getenv(key)
 	if value, err := strconv.Atoi(valueStr); err == nil {
@@ -46,3 +183,19 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	valueStr := os.Getenv(key)
+	if value, err := strconv.ParseFloat(valueStr, 64); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := os.Getenv(key)
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
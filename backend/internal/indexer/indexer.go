@@ -0,0 +1,308 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"voting-dapp/backend/internal/blockchain"
+	"voting-dapp/backend/internal/store"
+)
+
+// Config controls where the indexer starts and how it follows the chain
+type Config struct {
+	// StartBlock is the first block scanned when no checkpoint exists,
+	// usually the contract's deployment block
+	StartBlock uint64
+	// BatchSize is the maximum number of blocks fetched per log query
+	BatchSize uint64
+	// Interval is how often the chain head is polled once caught up
+	Interval time.Duration
+	// Confirmations is the number of blocks, counting the one a record was
+	// mined in, after which the record is reported as final
+	Confirmations uint64
+}
+
+// maxReorgDepth bounds how many recorded block hashes are compared with the
+// canonical chain when looking for a fork point
+const maxReorgDepth = 1024
+
+// Commit describes one write of the indexer to the store, reported once
+// it is stored
+type Commit struct {
+	// Batch holds the records stored, if any
+	Batch *store.IndexBatch
+	// RolledBack is set when the records of blocks that left the canonical
+	// chain were removed
+	RolledBack bool
+	// Finalized is set when the newest final block advanced, so records
+	// may have become final
+	Finalized bool
+}
+
+// Indexer follows the Voting contract's events and materializes polls,
+// votes and voting power into the store
+type Indexer struct {
+	client *blockchain.Client
+	store  *store.Store
+	cfg    Config
+
+	onCommit func(Commit)
+	final    uint64
+	hasFinal bool
+}
+
+// New creates an indexer
+func New(client *blockchain.Client, st *store.Store, cfg Config) *Indexer {
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = 2000
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = 5 * time.Second
+	}
+	return &Indexer{client: client, store: st, cfg: cfg}
+}
+
+// OnCommit sets a function called after every write to the store, such as
+// one dropping cached reads. It must be set before Backfill or Run.
+func (ix *Indexer) OnCommit(fn func(Commit)) {
+	ix.onCommit = fn
+}
+
+func (ix *Indexer) commit(c Commit) {
+	if ix.onCommit != nil {
+		ix.onCommit(c)
+	}
+}
+
+// Backfill rolls back any blocks that left the canonical chain and indexes
+// every block from the checkpoint up to the current head
+func (ix *Indexer) Backfill(ctx context.Context) error {
+	if err := ix.handleReorg(ctx); err != nil {
+		return fmt.Errorf("failed to check for reorgs: %v", err)
+	}
+
+	head, err := ix.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain head: %v", err)
+	}
+
+	if head+1 >= ix.cfg.Confirmations {
+		final := head + 1 - ix.cfg.Confirmations
+		if err := ix.store.SetFinalBlock(final); err != nil {
+			return err
+		}
+		if !ix.hasFinal || final != ix.final {
+			ix.final, ix.hasFinal = final, true
+			ix.commit(Commit{Finalized: true})
+		}
+	}
+
+	from, err := ix.nextBlock()
+	if err != nil {
+		return err
+	}
+
+	for from <= head {
+		to := from + ix.cfg.BatchSize - 1
+		if to > head {
+			to = head
+		}
+		if err := ix.indexRange(ctx, from, to); err != nil {
+			return fmt.Errorf("failed to index blocks %d-%d: %v", from, to, err)
+		}
+		from = to + 1
+	}
+	return nil
+}
+
+// Run keeps the store in sync with the chain until ctx is canceled,
+// catching up when the client reports a new head and every interval
+func (ix *Indexer) Run(ctx context.Context) {
+	ticker := time.NewTicker(ix.cfg.Interval)
+	defer ticker.Stop()
+	heads, stop := ix.client.NotifyHeads()
+	defer stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-heads:
+		}
+		if err := ix.Backfill(ctx); err != nil {
+			log.Printf("Indexer: %v", err)
+		}
+	}
+}
+
+// handleReorg compares recorded block hashes with the canonical chain,
+// newest first, and rolls the store back to the newest block that still
+// matches
+func (ix *Indexer) handleReorg(ctx context.Context) error {
+	blocks, err := ix.store.RecentBlocks(maxReorgDepth)
+	if err != nil {
+		return err
+	}
+
+	for i, b := range blocks {
+		header, err := ix.client.HeaderByNumber(ctx, b.Number)
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if header.Hash().Hex() != b.Hash {
+			continue
+		}
+
+		if i > 0 {
+			log.Printf("Indexer: chain reorganization detected, rolling back to block %d", b.Number)
+			if err := ix.store.RollbackTo(b.Number); err != nil {
+				return err
+			}
+			ix.commit(Commit{RolledBack: true})
+		}
+		return nil
+	}
+
+	if len(blocks) > 0 {
+		log.Printf("Indexer: no recorded block is canonical, reindexing from block %d", ix.cfg.StartBlock)
+		if err := ix.store.ResetIndex(); err != nil {
+			return err
+		}
+		ix.commit(Commit{RolledBack: true})
+	}
+	return nil
+}
+
+// nextBlock returns the first block that has not been indexed yet
+func (ix *Indexer) nextBlock() (uint64, error) {
+	last, ok, err := ix.store.LastIndexedBlock()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read checkpoint: %v", err)
+	}
+	if !ok || last+1 < ix.cfg.StartBlock {
+		return ix.cfg.StartBlock, nil
+	}
+	return last + 1, nil
+}
+
+// indexRange decodes the contract's logs in [from, to] and stores them
+// together with the new checkpoint
+func (ix *Indexer) indexRange(ctx context.Context, from, to uint64) error {
+	// Record the range's last block before reading logs: if a reorg lands
+	// in between, the stale hash is caught by the next reorg check
+	last, err := ix.client.HeaderByNumber(ctx, to)
+	if err != nil {
+		return err
+	}
+
+	logs, err := ix.client.FilterContractLogs(ctx, from, to)
+	if err != nil {
+		return err
+	}
+
+	batch := &store.IndexBatch{
+		Blocks: []store.BlockRecord{{Number: to, Hash: last.Hash().Hex()}},
+	}
+	timestamps := make(map[uint64]int64)
+	seenBlocks := map[uint64]bool{to: true}
+
+	for _, l := range logs {
+		if l.Removed {
+			continue
+		}
+		if !seenBlocks[l.BlockNumber] {
+			seenBlocks[l.BlockNumber] = true
+			batch.Blocks = append(batch.Blocks, store.BlockRecord{Number: l.BlockNumber, Hash: l.BlockHash.Hex()})
+		}
+
+		event, err := ix.client.ParseEvent(l)
+		if err != nil {
+			log.Printf("Indexer: skipping log %s/%d: %v", l.TxHash.Hex(), l.Index, err)
+			continue
+		}
+
+		pos := position(l)
+		switch e := event.(type) {
+		case *blockchain.VotingPollCreated:
+			poll, err := ix.client.GetPoll(e.PollId.Uint64())
+			if err != nil {
+				return fmt.Errorf("failed to load poll %d: %v", e.PollId.Uint64(), err)
+			}
+			batch.Polls = append(batch.Polls, store.PollRecord{
+				Position:    pos,
+				ID:          e.PollId.Uint64(),
+				Title:       e.Title,
+				Description: poll.Description,
+				Options:     poll.Options,
+				StartTime:   e.StartTime.Int64(),
+				EndTime:     e.EndTime.Int64(),
+				Creator:     e.Creator.Hex(),
+			})
+
+		case *blockchain.VotingVoted:
+			ts, ok := timestamps[l.BlockNumber]
+			if !ok {
+				header, err := ix.client.HeaderByNumber(ctx, l.BlockNumber)
+				if err != nil {
+					return fmt.Errorf("failed to load block %d: %v", l.BlockNumber, err)
+				}
+				ts = int64(header.Time)
+				timestamps[l.BlockNumber] = ts
+			}
+			batch.Votes = append(batch.Votes, store.VoteRecord{
+				Position:    pos,
+				PollID:      e.PollId.Uint64(),
+				Voter:       e.Voter.Hex(),
+				OptionIndex: e.OptionIndex.Uint64(),
+				Weight:      e.Weight.Uint64(),
+				Timestamp:   ts,
+			})
+
+		case *blockchain.VotingVotingPowerAssigned:
+			batch.Powers = append(batch.Powers, store.PowerRecord{
+				Position: pos,
+				Voter:    e.Voter.Hex(),
+				Power:    e.Power.Uint64(),
+			})
+
+		case *blockchain.VotingPollCanceled:
+			batch.PollEvents = append(batch.PollEvents, store.PollEventRecord{
+				Position: pos, PollID: e.PollId.Uint64(), Kind: store.PollEventCanceled,
+			})
+
+		case *blockchain.VotingPollActivated:
+			batch.PollEvents = append(batch.PollEvents, store.PollEventRecord{
+				Position: pos, PollID: e.PollId.Uint64(), Kind: store.PollEventActivated,
+			})
+
+		case *blockchain.VotingPollDeactivated:
+			batch.PollEvents = append(batch.PollEvents, store.PollEventRecord{
+				Position: pos, PollID: e.PollId.Uint64(), Kind: store.PollEventDeactivated,
+			})
+		}
+	}
+
+	if err := ix.store.ApplyIndexBatch(batch, to); err != nil {
+		return err
+	}
+	ix.commit(Commit{Batch: batch})
+	return nil
+}
+
+func position(l types.Log) store.Position {
+	return store.Position{
+		BlockNumber: l.BlockNumber,
+		LogIndex:    l.Index,
+		TxHash:      l.TxHash.Hex(),
+	}
+}
@@ -0,0 +1,468 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"voting-dapp/backend/internal/blockchain"
+	"voting-dapp/backend/internal/models"
+	"voting-dapp/backend/internal/store"
+)
+
+const testVotingPower = 5
+
+// indexerTest indexes a simulated chain into a store in a temporary
+// directory and records the commits of its indexer
+type indexerTest struct {
+	t      *testing.T
+	client *blockchain.Client
+	sim    *blockchain.Simulation
+	voting *blockchain.Voting
+	path   string
+	cfg    Config
+
+	store   *store.Store
+	ix      *Indexer
+	commits []Commit
+}
+
+func newIndexerTest(t *testing.T, cfg Config) *indexerTest {
+	t.Helper()
+
+	// Start a day back so blocks can be mined and time advanced freely
+	client, sim, err := blockchain.NewSimulatedClient(blockchain.SimulatedOptions{
+		Voters:      2,
+		VotingPower: testVotingPower,
+		StartTime:   time.Now().Add(-24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+
+	voting, err := blockchain.NewVoting(sim.Contract, sim.Backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it := &indexerTest{
+		t:      t,
+		client: client,
+		sim:    sim,
+		voting: voting,
+		path:   filepath.Join(t.TempDir(), "index.db"),
+		cfg:    cfg,
+	}
+	it.open()
+	return it
+}
+
+// open opens the store and starts a new indexer on it, as on a restart
+func (it *indexerTest) open() {
+	it.t.Helper()
+	st, err := store.Open(it.path)
+	if err != nil {
+		it.t.Fatal(err)
+	}
+	it.t.Cleanup(func() { st.Close() })
+
+	it.store = st
+	it.ix = New(it.client, st, it.cfg)
+	it.ix.OnCommit(func(c Commit) { it.commits = append(it.commits, c) })
+}
+
+func (it *indexerTest) backfill() {
+	it.t.Helper()
+	if err := it.ix.Backfill(context.Background()); err != nil {
+		it.t.Fatal(err)
+	}
+}
+
+func (it *indexerTest) head() *types.Header {
+	it.t.Helper()
+	header, err := it.sim.Backend.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		it.t.Fatal(err)
+	}
+	return header
+}
+
+// createPoll mines a poll with two options that starts a minute after
+// the head and returns its ID
+func (it *indexerTest) createPoll() uint64 {
+	it.t.Helper()
+	start := int64(it.head().Time) + 60
+	if _, err := it.client.CreatePollTx("Poll", "", []string{"A", "B"}, start, start+86400); err != nil {
+		it.t.Fatal(err)
+	}
+	it.sim.Backend.Commit()
+
+	ids, err := it.client.GetAllPollIds()
+	if err != nil || len(ids) == 0 {
+		it.t.Fatalf("poll IDs = %v, %v", ids, err)
+	}
+	return ids[len(ids)-1]
+}
+
+// advance mines an empty block d after the next block would be mined
+func (it *indexerTest) advance(d time.Duration) {
+	it.t.Helper()
+	if err := it.sim.Backend.AdjustTime(d); err != nil {
+		it.t.Fatal(err)
+	}
+	it.sim.Backend.Commit()
+}
+
+// vote mines a vote sent by voter itself, so that it can be replayed on a
+// fork without the client's nonces
+func (it *indexerTest) vote(voter blockchain.SimulatedAccount, pollID, optionIndex uint64) {
+	it.t.Helper()
+	auth, err := bind.NewKeyedTransactorWithChainID(voter.Key, big.NewInt(1337))
+	if err != nil {
+		it.t.Fatal(err)
+	}
+	if _, err := it.voting.Vote(auth, new(big.Int).SetUint64(pollID), new(big.Int).SetUint64(optionIndex)); err != nil {
+		it.t.Fatal(err)
+	}
+	it.sim.Backend.Commit()
+}
+
+func (it *indexerTest) expectCounts(pollID uint64, want ...uint64) {
+	it.t.Helper()
+	results, err := it.store.GetPollResults(pollID)
+	if err != nil {
+		it.t.Fatal(err)
+	}
+	if !reflect.DeepEqual(results.VoteCounts, want) {
+		it.t.Errorf("indexed vote counts = %v, want %v", results.VoteCounts, want)
+	}
+}
+
+func (it *indexerTest) rolledBack() bool {
+	for _, c := range it.commits {
+		if c.RolledBack {
+			return true
+		}
+	}
+	return false
+}
+
+func TestReorgReplacesOrphanedVotes(t *testing.T) {
+	it := newIndexerTest(t, Config{Confirmations: 10})
+	id := it.createPoll()
+	it.advance(2 * time.Minute)
+	fork := it.head().Hash()
+
+	voter := it.sim.Voters[0]
+	it.vote(voter, id, 0)
+	it.backfill()
+	it.expectCounts(id, testVotingPower, 0)
+
+	// Replace the vote's block with a longer branch voting the other way
+	if err := it.sim.Backend.Fork(context.Background(), fork); err != nil {
+		t.Fatal(err)
+	}
+	it.vote(voter, id, 1)
+	it.sim.Backend.Commit()
+	it.backfill()
+
+	if !it.rolledBack() {
+		t.Error("no rollback committed after the fork")
+	}
+	it.expectCounts(id, 0, testVotingPower)
+	status, err := it.store.GetVoterStatus(id, voter.Address.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.HasVoted || status.OptionIndex != 1 {
+		t.Errorf("voter status after reorg = %+v, want a vote for option 1", status)
+	}
+
+	blocks, err := it.store.RecentBlocks(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if head := it.head(); len(blocks) != 1 || blocks[0].Hash != head.Hash().Hex() {
+		t.Errorf("recorded blocks = %+v, want the new head %d", blocks, head.Number)
+	}
+}
+
+func TestReorgPastRecordedBlocksResetsIndex(t *testing.T) {
+	// A single confirmation prunes every recorded hash but the head's
+	it := newIndexerTest(t, Config{Confirmations: 1})
+	fork := it.head().Hash()
+	id := it.createPoll()
+	it.advance(2 * time.Minute)
+	it.vote(it.sim.Voters[0], id, 0)
+	// The second pass prunes what the first recorded
+	it.backfill()
+	it.backfill()
+
+	blocks, err := it.store.RecentBlocks(maxReorgDepth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 1 {
+		t.Fatalf("recorded blocks = %+v, want only the head", blocks)
+	}
+
+	// Fork below every recorded block and outgrow the old branch
+	if err := it.sim.Backend.Fork(context.Background(), fork); err != nil {
+		t.Fatal(err)
+	}
+	for it.head().Number.Uint64() <= blocks[0].Number {
+		it.sim.Backend.Commit()
+	}
+	it.backfill()
+
+	if !it.rolledBack() {
+		t.Error("no rollback committed after the fork")
+	}
+	polls, err := it.store.ListPolls()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(polls) != 0 {
+		t.Errorf("indexed polls after reset = %d, want none", len(polls))
+	}
+	last, ok, err := it.store.LastIndexedBlock()
+	if err != nil || !ok || last != it.head().Number.Uint64() {
+		t.Errorf("checkpoint after reindexing = %d, %v, %v, want the head %d", last, ok, err, it.head().Number)
+	}
+	power, err := it.store.GetVotingPower(it.sim.Voters[0].Address.Hex())
+	if err != nil || power != testVotingPower {
+		t.Errorf("reindexed voting power = %d, %v, want %d", power, err, testVotingPower)
+	}
+}
+
+func TestFinalityFollowsConfirmations(t *testing.T) {
+	it := newIndexerTest(t, Config{Confirmations: 3})
+	id := it.createPoll()
+
+	// The poll's block is final once it and two more blocks are mined
+	for i, want := range []string{models.FinalityUnconfirmed, models.FinalityUnconfirmed, models.FinalityFinal} {
+		if i > 0 {
+			it.sim.Backend.Commit()
+		}
+		it.backfill()
+		poll, err := it.store.GetPoll(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if poll.Finality != want {
+			t.Errorf("finality with %d confirmations = %q, want %q", i+1, poll.Finality, want)
+		}
+	}
+
+	finalized := 0
+	for _, c := range it.commits {
+		if c.Finalized {
+			finalized++
+		}
+	}
+	if finalized != 3 {
+		t.Errorf("final block advanced %d times, want 3", finalized)
+	}
+}
+
+func TestRestartResumesFromCheckpoint(t *testing.T) {
+	it := newIndexerTest(t, Config{Confirmations: 1})
+	first := it.createPoll()
+	it.backfill()
+	checkpoint, ok, err := it.store.LastIndexedBlock()
+	if err != nil || !ok {
+		t.Fatalf("checkpoint = %d, %v, %v", checkpoint, ok, err)
+	}
+
+	if err := it.store.Close(); err != nil {
+		t.Fatal(err)
+	}
+	second := it.createPoll()
+	it.open()
+	it.commits = nil
+	it.backfill()
+
+	var indexed []uint64
+	for _, c := range it.commits {
+		if c.Batch == nil {
+			continue
+		}
+		for _, b := range c.Batch.Blocks {
+			if b.Number <= checkpoint {
+				t.Errorf("block %d indexed again after a restart at checkpoint %d", b.Number, checkpoint)
+			}
+		}
+		for _, p := range c.Batch.Polls {
+			indexed = append(indexed, p.ID)
+		}
+	}
+	if !reflect.DeepEqual(indexed, []uint64{second}) {
+		t.Errorf("polls indexed after the restart = %v, want only %d", indexed, second)
+	}
+
+	polls, err := it.store.ListPolls()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(polls) != 2 || polls[0].ID != first || polls[1].ID != second {
+		t.Errorf("indexed polls = %+v, want %d and %d", polls, first, second)
+	}
+	if creator := common.HexToAddress(polls[1].Creator); creator != it.sim.Admin.Address {
+		t.Errorf("creator = %s, want the admin %s", creator.Hex(), it.sim.Admin.Address.Hex())
+	}
+}
+
+func TestBackfillChunksRanges(t *testing.T) {
+	it := newIndexerTest(t, Config{BatchSize: 3})
+
+	// Create polls in the last block of one batch and the first of the next
+	var created []uint64
+	for len(created) < 2 {
+		if next := it.head().Number.Uint64() + 1; next%3 == 2 || (len(created) == 1 && next%3 == 0) {
+			created = append(created, it.createPoll())
+			continue
+		}
+		it.sim.Backend.Commit()
+	}
+	it.sim.Backend.Commit()
+	it.backfill()
+
+	head := it.head().Number.Uint64()
+	var from uint64
+	var polls []uint64
+	for _, c := range it.commits {
+		if c.Batch == nil {
+			continue
+		}
+		to := from + 2
+		if to > head {
+			to = head
+		}
+		if c.Batch.Blocks[0].Number != to {
+			t.Errorf("batch from %d ends at %d, want %d", from, c.Batch.Blocks[0].Number, to)
+		}
+		for _, p := range c.Batch.Polls {
+			if p.BlockNumber < from || p.BlockNumber > to {
+				t.Errorf("poll %d from block %d stored in batch %d-%d", p.ID, p.BlockNumber, from, to)
+			}
+			polls = append(polls, p.ID)
+		}
+		from = to + 1
+	}
+	if from != head+1 {
+		t.Errorf("batches end at %d, want the head %d", from-1, head)
+	}
+	if !reflect.DeepEqual(polls, created) {
+		t.Errorf("polls indexed = %v, want each of %v once", polls, created)
+	}
+}
+
+func TestReindexingRangeIsIdempotent(t *testing.T) {
+	it := newIndexerTest(t, Config{})
+	id := it.createPoll()
+	it.advance(2 * time.Minute)
+	it.vote(it.sim.Voters[0], id, 0)
+	it.vote(it.sim.Voters[1], id, 1)
+	it.backfill()
+	before, err := it.store.GetPoll(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	head := it.head().Number.Uint64()
+	for i := 0; i < 2; i++ {
+		if err := it.ix.indexRange(context.Background(), 0, head); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	after, err := it.store.GetPoll(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(after, before) {
+		t.Errorf("poll after reindexing = %+v, want %+v", after, before)
+	}
+	it.expectCounts(id, testVotingPower, testVotingPower)
+}
+
+func TestIndexedReadsMatchChain(t *testing.T) {
+	it := newIndexerTest(t, Config{BatchSize: 4})
+	voters := it.sim.Voters
+
+	open := it.createPoll()
+	canceled := it.createPoll()
+	inactive := it.createPoll()
+	it.advance(2 * time.Minute)
+	it.vote(voters[0], open, 1)
+	it.vote(voters[1], open, 0)
+	it.vote(voters[0], inactive, 0)
+	for _, send := range []func() (*types.Transaction, error){
+		func() (*types.Transaction, error) { return it.client.CancelPollTx(canceled) },
+		func() (*types.Transaction, error) { return it.client.DeactivatePollTx(inactive) },
+		func() (*types.Transaction, error) { return it.client.AssignVotingPowerTx(voters[1].Address.Hex(), 12) },
+	} {
+		if _, err := send(); err != nil {
+			t.Fatal(err)
+		}
+		it.sim.Backend.Commit()
+	}
+	it.backfill()
+
+	ids, err := it.client.GetAllPollIds()
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainPolls, err := it.client.GetPolls(ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexedPolls, err := it.store.ListPolls()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the index knows how final its records are
+	for _, p := range indexedPolls {
+		p.Finality = ""
+	}
+	if !reflect.DeepEqual(indexedPolls, chainPolls) {
+		t.Errorf("indexed polls = %+v\nchain polls = %+v", indexedPolls, chainPolls)
+	}
+
+	for _, id := range []uint64{open, canceled, inactive} {
+		chainResults, err := it.client.GetPollResults(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		indexedResults, err := it.store.GetPollResults(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		indexedResults.Finality = ""
+		if !reflect.DeepEqual(indexedResults, chainResults) {
+			t.Errorf("poll %d: indexed results = %+v, chain results = %+v", id, indexedResults, chainResults)
+		}
+
+		for _, v := range voters {
+			chainStatus, err := it.client.GetVoterStatus(id, v.Address.Hex())
+			if err != nil {
+				t.Fatal(err)
+			}
+			indexedStatus, err := it.store.GetVoterStatus(id, v.Address.Hex())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(indexedStatus, chainStatus) {
+				t.Errorf("poll %d voter %s: indexed status = %+v, chain status = %+v", id, v.Address.Hex(), indexedStatus, chainStatus)
+			}
+		}
+	}
+}
@@ -0,0 +1,450 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"voting-dapp/backend/internal/blockchain"
+	"voting-dapp/backend/internal/models"
+	"voting-dapp/backend/internal/store"
+)
+
+// Actions recorded on transaction jobs
+const (
+	ActionCreatePoll             = "createPoll"
+	ActionVote                   = "vote"
+	ActionVoteBySig              = "voteBySig"
+	ActionAssignVotingPower      = "assignVotingPower"
+	ActionBatchAssignVotingPower = "batchAssignVotingPower"
+	ActionCancelPoll             = "cancelPoll"
+	ActionActivatePoll           = "activatePoll"
+	ActionDeactivatePoll         = "deactivatePoll"
+	ActionTransferAdmin          = "transferAdmin"
+	ActionGrantRole              = "grantRole"
+	ActionRevokeRole             = "revokeRole"
+)
+
+// ErrNotPending is returned when speeding up or canceling a job whose
+// transaction is no longer pending
+var ErrNotPending = errors.New("transaction is no longer pending")
+
+// StuckPolicy configures how transactions that stay pending are sped up
+type StuckPolicy struct {
+	// After is how long a transaction may stay pending before it is re-sent
+	// with higher fees. Zero disables automatic speed-ups.
+	After time.Duration
+	// MaxSpeedups bounds how often a job's transaction is replaced
+	// automatically. Zero means no bound.
+	MaxSpeedups int
+}
+
+// Manager tracks transactions submitted on behalf of API requests until
+// they are mined, fail or are replaced, persisting every change
+type Manager struct {
+	client   *blockchain.Client
+	store    *store.Store
+	interval time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*models.TxJob
+	locks   map[string]*jobLock
+	stuck   StuckPolicy
+}
+
+// jobLock keeps a job from being checked and replaced at once, counting
+// the callers holding or waiting for it so it can be dropped after them
+type jobLock struct {
+	sync.Mutex
+	refs int
+}
+
+// NewManager creates a job manager that checks pending jobs every interval
+func NewManager(client *blockchain.Client, st *store.Store, interval time.Duration) *Manager {
+	return &Manager{
+		client:   client,
+		store:    st,
+		interval: interval,
+		pending:  make(map[string]*models.TxJob),
+		locks:    make(map[string]*jobLock),
+	}
+}
+
+// SetStuckPolicy sets when pending transactions are sped up automatically
+func (m *Manager) SetStuckPolicy(policy StuckPolicy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stuck = policy
+}
+
+// Run resumes tracking of jobs left pending by a previous process and
+// polls for their outcome until ctx is canceled
+func (m *Manager) Run(ctx context.Context) error {
+	jobs, err := m.store.PendingTxJobs()
+	if err != nil {
+		return fmt.Errorf("failed to load pending jobs: %v", err)
+	}
+
+	m.mu.Lock()
+	for _, job := range jobs {
+		m.pending[job.ID] = job
+	}
+	m.mu.Unlock()
+
+	if len(jobs) > 0 {
+		log.Printf("Resuming %d pending transaction jobs", len(jobs))
+	}
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			m.checkPending(ctx)
+		}
+	}
+}
+
+// Track registers a submitted transaction and returns its job
+func (m *Manager) Track(action string, tx *types.Transaction) (*models.TxJob, error) {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	job := &models.TxJob{
+		ID:          id,
+		Action:      action,
+		Status:      models.TxStatusPending,
+		TxHash:      tx.Hash().Hex(),
+		From:        from.Hex(),
+		Nonce:       tx.Nonce(),
+		SubmittedAt: now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		RawTx:       raw,
+	}
+	if err := m.store.SaveTxJob(job); err != nil {
+		return nil, fmt.Errorf("failed to save job: %v", err)
+	}
+
+	m.mu.Lock()
+	m.pending[job.ID] = job
+	m.mu.Unlock()
+
+	return job, nil
+}
+
+// Get returns the job with the given ID
+func (m *Manager) Get(id string) (*models.TxJob, error) {
+	return m.store.GetTxJob(id)
+}
+
+// SpeedUp re-sends a pending job's transaction with higher fees
+func (m *Manager) SpeedUp(ctx context.Context, id string) (*models.TxJob, error) {
+	return m.replacePending(ctx, id, m.client.SpeedUpTx)
+}
+
+// Cancel replaces a pending job's transaction with a zero-value transfer to
+// the sender. The job ends canceled if the replacement is mined first.
+func (m *Manager) Cancel(ctx context.Context, id string) (*models.TxJob, error) {
+	return m.replacePending(ctx, id, m.client.CancelTx)
+}
+
+// replacePending replaces the transaction of a pending job
+func (m *Manager) replacePending(ctx context.Context, id string, replace func(context.Context, *types.Transaction) (*types.Transaction, error)) (*models.TxJob, error) {
+	defer m.lockJob(id)()
+
+	m.mu.Lock()
+	job, ok := m.pending[id]
+	m.mu.Unlock()
+	if !ok {
+		// Either unknown or already final
+		stored, err := m.store.GetTxJob(id)
+		if err != nil {
+			return nil, err
+		}
+		if stored.Status != models.TxStatusPending {
+			return nil, ErrNotPending
+		}
+		job = stored
+	}
+
+	if err := m.replace(ctx, job, replace, false); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.pending[job.ID] = job
+	m.mu.Unlock()
+
+	// The tracked job keeps changing once its lock is released
+	replaced := *job
+	return &replaced, nil
+}
+
+// replace sends a replacement for a job's transaction and records it,
+// counting it as automatic if it is; callers hold the job's lock
+func (m *Manager) replace(ctx context.Context, job *models.TxJob, replace func(context.Context, *types.Transaction) (*types.Transaction, error), automatic bool) error {
+	tx, err := m.transaction(ctx, job)
+	if err != nil {
+		return err
+	}
+	replacement, err := replace(ctx, tx)
+	if err != nil {
+		return err
+	}
+	raw, err := replacement.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	job.PreviousTxHashes = append(job.PreviousTxHashes, job.TxHash)
+	if automatic {
+		job.AutoSpeedups++
+	}
+	job.TxHash = replacement.Hash().Hex()
+	job.RawTx = raw
+	job.SubmittedAt = now
+	job.UpdatedAt = now
+	return m.store.SaveTxJob(job)
+}
+
+// transaction returns a job's current transaction, from the job itself or,
+// for jobs recorded before transactions were kept, from the node
+func (m *Manager) transaction(ctx context.Context, job *models.TxJob) (*types.Transaction, error) {
+	if len(job.RawTx) == 0 {
+		return m.client.TransactionByHash(ctx, common.HexToHash(job.TxHash))
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(job.RawTx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// lockJob locks the job with the given ID and returns its unlock function
+func (m *Manager) lockJob(id string) func() {
+	m.mu.Lock()
+	l, ok := m.locks[id]
+	if !ok {
+		l = &jobLock{}
+		m.locks[id] = l
+	}
+	l.refs++
+	m.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		m.mu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(m.locks, id)
+		}
+		m.mu.Unlock()
+	}
+}
+
+// checkPending checks every pending job, locking one job at a time so that
+// speeding up or canceling a job does not wait for the whole sweep
+func (m *Manager) checkPending(ctx context.Context) {
+	m.mu.Lock()
+	jobs := make([]*models.TxJob, 0, len(m.pending))
+	for _, job := range m.pending {
+		jobs = append(jobs, job)
+	}
+	m.mu.Unlock()
+
+	for _, job := range jobs {
+		if err := m.checkJob(ctx, job); err != nil {
+			log.Printf("Failed to check transaction job %s: %v", job.ID, err)
+		}
+	}
+}
+
+// checkJob checks a job under its lock and stops tracking it once final
+func (m *Manager) checkJob(ctx context.Context, job *models.TxJob) error {
+	defer m.lockJob(job.ID)()
+
+	done, err := m.check(ctx, job)
+	if err != nil || !done {
+		return err
+	}
+	m.mu.Lock()
+	delete(m.pending, job.ID)
+	m.mu.Unlock()
+	return nil
+}
+
+// check refreshes a single job and reports whether it reached a final
+// status, speeding up its transaction if it has been pending too long
+func (m *Manager) check(ctx context.Context, job *models.TxJob) (bool, error) {
+	// Read the account nonce before the receipts: if the nonce has moved past
+	// the job and there is still no receipt, another transaction took its place.
+	nonce, err := m.client.NonceAt(ctx, common.HexToAddress(job.From))
+	if err != nil {
+		return false, err
+	}
+
+	// Any of the transactions sent for the job may be the one mined
+	var receipt *types.Receipt
+	for _, hash := range append([]string{job.TxHash}, job.PreviousTxHashes...) {
+		if receipt, err = m.client.TransactionReceipt(ctx, common.HexToHash(hash)); err != nil {
+			return false, err
+		}
+		if receipt != nil {
+			break
+		}
+	}
+
+	switch {
+	case receipt != nil:
+		if err := m.applyReceipt(ctx, job, receipt); err != nil {
+			return false, err
+		}
+	case nonce > job.Nonce:
+		job.Status = models.TxStatusReplaced
+	default:
+		// A transaction pending past the timeout may have been dropped or be
+		// queued behind a nonce the node lost
+		if time.Since(job.SubmittedAt) >= m.client.TxTimeout() {
+			if err := m.repair(ctx, job); err != nil {
+				log.Printf("Failed to repair transaction job %s: %v", job.ID, err)
+			}
+		}
+		if m.isStuck(job) {
+			previous := job.TxHash
+			if err := m.replace(ctx, job, m.client.SpeedUpTx, true); err != nil {
+				return false, err
+			}
+			log.Printf("Sped up transaction job %s: %s replaced by %s", job.ID, previous, job.TxHash)
+		}
+		return false, nil
+	}
+
+	job.UpdatedAt = time.Now().UTC()
+	if err := m.store.SaveTxJob(job); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// repair re-sends a job's transaction if the node no longer has it and
+// fills any nonce of the sender the node is missing
+func (m *Manager) repair(ctx context.Context, job *models.TxJob) error {
+	if len(job.RawTx) > 0 {
+		tx, err := m.transaction(ctx, job)
+		if err != nil {
+			return err
+		}
+		_, err = m.client.TransactionByHash(ctx, tx.Hash())
+		if errors.Is(err, ethereum.NotFound) {
+			if err := m.client.SendTransaction(ctx, tx); err != nil && !blockchain.IsNonceError(err) {
+				return fmt.Errorf("failed to re-send dropped transaction: %v", err)
+			}
+			log.Printf("Re-sent dropped transaction %s of job %s", job.TxHash, job.ID)
+		} else if err != nil {
+			return err
+		}
+	}
+
+	filled, err := m.client.RepairNonces(ctx, common.HexToAddress(job.From))
+	for _, tx := range filled {
+		log.Printf("Filled nonce %d of %s with %s", tx.Nonce(), job.From, tx.Hash().Hex())
+	}
+	return err
+}
+
+// isStuck reports whether a job's transaction is due to be sped up
+func (m *Manager) isStuck(job *models.TxJob) bool {
+	m.mu.Lock()
+	policy := m.stuck
+	m.mu.Unlock()
+
+	if policy.After <= 0 || time.Since(job.SubmittedAt) < policy.After {
+		return false
+	}
+	return policy.MaxSpeedups <= 0 || job.AutoSpeedups < policy.MaxSpeedups
+}
+
+func (m *Manager) applyReceipt(ctx context.Context, job *models.TxJob, receipt *types.Receipt) error {
+	raw, err := json.Marshal(receipt)
+	if err != nil {
+		return err
+	}
+	job.Receipt = raw
+	job.BlockNumber = receipt.BlockNumber.Uint64()
+	job.GasUsed = receipt.GasUsed
+
+	// Record the transaction that was mined, which may be one it replaced
+	if mined := receipt.TxHash.Hex(); mined != job.TxHash {
+		hashes := []string{job.TxHash}
+		for _, hash := range job.PreviousTxHashes {
+			if hash != mined {
+				hashes = append(hashes, hash)
+			}
+		}
+		job.TxHash, job.PreviousTxHashes = mined, hashes
+	}
+
+	var tx *types.Transaction
+	if receipt.Status == types.ReceiptStatusFailed || len(job.PreviousTxHashes) > 0 {
+		if tx, err = m.client.TransactionByHash(ctx, receipt.TxHash); err != nil {
+			return err
+		}
+	}
+
+	if receipt.Status == types.ReceiptStatusFailed {
+		job.Status = models.TxStatusFailed
+		job.RevertReason, err = m.client.RevertReason(ctx, tx, receipt)
+		return err
+	}
+
+	if len(job.PreviousTxHashes) > 0 && blockchain.IsCancelTx(tx) {
+		job.Status = models.TxStatusCanceled
+		return nil
+	}
+
+	job.Status = models.TxStatusMined
+	if job.Action == ActionCreatePoll {
+		pollID, err := m.client.PollIDFromReceipt(receipt)
+		if err != nil {
+			return err
+		}
+		job.PollID = pollID
+	}
+	return nil
+}
+
+func newJobID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
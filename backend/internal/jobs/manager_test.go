@@ -0,0 +1,464 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	"voting-dapp/backend/internal/blockchain"
+	"voting-dapp/backend/internal/models"
+	"voting-dapp/backend/internal/store"
+)
+
+// jobsTest is a job manager over a simulated chain and a store
+type jobsTest struct {
+	t       *testing.T
+	client  *blockchain.Client
+	sim     *blockchain.Simulation
+	store   *store.Store
+	manager *Manager
+}
+
+func newJobsTest(t *testing.T) *jobsTest {
+	t.Helper()
+	client, sim, err := blockchain.NewSimulatedClient(blockchain.SimulatedOptions{Voters: 1, VotingPower: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	st, err := store.Open(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { st.Close() })
+	return &jobsTest{t: t, client: client, sim: sim, store: st, manager: NewManager(client, st, time.Hour)}
+}
+
+// createPoll submits a poll starting in an hour without mining it
+func (jt *jobsTest) createPoll(title string) *types.Transaction {
+	jt.t.Helper()
+	head, err := jt.sim.Backend.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		jt.t.Fatal(err)
+	}
+	start := int64(head.Time) + 3600
+	tx, err := jt.client.CreatePollTx(title, "", []string{"Yes", "No"}, start, start+3600)
+	if err != nil {
+		jt.t.Fatal(err)
+	}
+	return tx
+}
+
+// stall drops the pending block, the way a congested node leaves an
+// underpriced transaction unmined
+func (jt *jobsTest) stall() {
+	jt.sim.Backend.Rollback()
+}
+
+func (jt *jobsTest) track(action string, tx *types.Transaction) *models.TxJob {
+	jt.t.Helper()
+	job, err := jt.manager.Track(action, tx)
+	if err != nil {
+		jt.t.Fatal(err)
+	}
+	return job
+}
+
+func (jt *jobsTest) check() {
+	jt.manager.checkPending(context.Background())
+}
+
+// stored returns a job as persisted, failing unless it has status
+func (jt *jobsTest) stored(id, status string) *models.TxJob {
+	jt.t.Helper()
+	job, err := jt.store.GetTxJob(id)
+	if err != nil {
+		jt.t.Fatal(err)
+	}
+	if job.Status != status {
+		jt.t.Fatalf("job %s is %s, want %s", id, job.Status, status)
+	}
+	return job
+}
+
+func (jt *jobsTest) tracking(id string) bool {
+	jt.manager.mu.Lock()
+	defer jt.manager.mu.Unlock()
+	_, ok := jt.manager.pending[id]
+	return ok
+}
+
+func TestJobMined(t *testing.T) {
+	jt := newJobsTest(t)
+	tx := jt.createPoll("Mined")
+	job := jt.track(ActionCreatePoll, tx)
+	if job.Status != models.TxStatusPending || job.Nonce != tx.Nonce() || job.From != jt.sim.Admin.Address.Hex() {
+		t.Fatalf("tracked job = %+v", job)
+	}
+
+	jt.check()
+	jt.stored(job.ID, models.TxStatusPending)
+
+	jt.sim.Backend.Commit()
+	jt.check()
+	mined := jt.stored(job.ID, models.TxStatusMined)
+	if mined.TxHash != tx.Hash().Hex() || mined.PollID != 1 || mined.BlockNumber == 0 || mined.GasUsed == 0 || len(mined.Receipt) == 0 {
+		t.Errorf("mined job = %+v", mined)
+	}
+	if jt.tracking(job.ID) {
+		t.Error("mined job still tracked")
+	}
+}
+
+func TestJobFailed(t *testing.T) {
+	jt := newJobsTest(t)
+	jt.createPoll("Poll")
+	jt.sim.Backend.Commit()
+
+	// A voter without the poll-manager role cancels the poll, with a fixed
+	// gas limit so the revert happens on-chain
+	voting, err := blockchain.NewVoting(jt.sim.Contract, jt.sim.Backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth, err := bind.NewKeyedTransactorWithChainID(jt.sim.Voters[0].Key, jt.client.ChainID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth.GasLimit = 200000
+	tx, err := voting.CancelPoll(auth, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := jt.track(ActionCancelPoll, tx)
+
+	jt.sim.Backend.Commit()
+	jt.check()
+	failed := jt.stored(job.ID, models.TxStatusFailed)
+	if !strings.Contains(failed.RevertReason, "missing the required role") || failed.BlockNumber == 0 {
+		t.Errorf("failed job = %+v", failed)
+	}
+	if jt.tracking(job.ID) {
+		t.Error("failed job still tracked")
+	}
+}
+
+func TestJobReplaced(t *testing.T) {
+	jt := newJobsTest(t)
+	ctx := context.Background()
+	voter := jt.sim.Voters[0]
+	nonce, err := jt.sim.Backend.PendingNonceAt(ctx, voter.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := types.LatestSignerForChainID(jt.client.ChainID())
+	transfer := func(value int64) *types.Transaction {
+		tx, err := types.SignNewTx(voter.Key, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			To:       &jt.sim.Admin.Address,
+			Value:    big.NewInt(value),
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(10 * params.GWei),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+
+	// The tracked transaction never lands; another one takes its nonce
+	job := jt.track(ActionVote, transfer(1))
+	if err := jt.sim.Backend.SendTransaction(ctx, transfer(2)); err != nil {
+		t.Fatal(err)
+	}
+	jt.sim.Backend.Commit()
+
+	jt.check()
+	jt.stored(job.ID, models.TxStatusReplaced)
+	if jt.tracking(job.ID) {
+		t.Error("replaced job still tracked")
+	}
+}
+
+func TestStuckJobIsSpedUp(t *testing.T) {
+	jt := newJobsTest(t)
+	jt.manager.SetStuckPolicy(StuckPolicy{After: time.Nanosecond})
+
+	tx := jt.createPoll("Stuck")
+	job := jt.track(ActionCreatePoll, tx)
+	submitted := job.SubmittedAt
+	jt.stall()
+
+	jt.check()
+	sped := jt.stored(job.ID, models.TxStatusPending)
+	if sped.TxHash == tx.Hash().Hex() || len(sped.PreviousTxHashes) != 1 || sped.PreviousTxHashes[0] != tx.Hash().Hex() {
+		t.Fatalf("job after the stuck timeout = %+v", sped)
+	}
+	if !sped.SubmittedAt.After(submitted) {
+		t.Errorf("submitted at %s, want after %s", sped.SubmittedAt, submitted)
+	}
+
+	jt.sim.Backend.Commit()
+	jt.check()
+	mined := jt.stored(job.ID, models.TxStatusMined)
+	if mined.TxHash != sped.TxHash || mined.PollID != 1 {
+		t.Errorf("mined job = %+v, want the replacement %s mined", mined, sped.TxHash)
+	}
+}
+
+func TestDroppedJobIsResent(t *testing.T) {
+	jt := newJobsTest(t)
+	jt.client.SetTxTimeout(time.Nanosecond)
+
+	tx := jt.createPoll("Dropped")
+	job := jt.track(ActionCreatePoll, tx)
+	jt.stall()
+
+	// Past the timeout the node no longer has the transaction, so the job
+	// sends it again as it was
+	jt.check()
+	if _, err := jt.client.TransactionByHash(context.Background(), tx.Hash()); err != nil {
+		t.Fatalf("transaction not re-sent: %v", err)
+	}
+	jt.sim.Backend.Commit()
+	jt.check()
+	mined := jt.stored(job.ID, models.TxStatusMined)
+	if mined.TxHash != tx.Hash().Hex() || len(mined.PreviousTxHashes) != 0 {
+		t.Errorf("mined job = %+v, want the original transaction mined", mined)
+	}
+}
+
+func TestRunResumesPendingJobs(t *testing.T) {
+	jt := newJobsTest(t)
+	first := jt.track(ActionCreatePoll, jt.createPoll("First"))
+	second := jt.track(ActionCreatePoll, jt.createPoll("Second"))
+
+	// A new process picks the jobs up from the store
+	restarted := NewManager(jt.client, jt.store, 10*time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- restarted.Run(ctx) }()
+	defer func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Error(err)
+		}
+	}()
+
+	jt.sim.Backend.Commit()
+	deadline := time.Now().Add(5 * time.Second)
+	for _, id := range []string{first.ID, second.ID} {
+		for {
+			job, err := jt.store.GetTxJob(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if job.Status == models.TxStatusMined {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("job %s still %s after restart", id, job.Status)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	restarted.mu.Lock()
+	left := len(restarted.pending)
+	restarted.mu.Unlock()
+	if left != 0 {
+		t.Errorf("%d jobs still tracked after they were mined", left)
+	}
+}
+
+// rawTx decodes the transaction a job last sent
+func rawTx(t *testing.T, job *models.TxJob) *types.Transaction {
+	t.Helper()
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(job.RawTx); err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+// checkBump fails unless replacement reuses old's nonce and raises both of
+// its fees by at least the 10% nodes require to accept a replacement
+func checkBump(t *testing.T, old, replacement *types.Transaction) {
+	t.Helper()
+	if replacement.Nonce() != old.Nonce() {
+		t.Errorf("replacement nonce %d, want %d", replacement.Nonce(), old.Nonce())
+	}
+	for _, fee := range []struct {
+		name     string
+		old, new *big.Int
+	}{
+		{"tip", old.GasTipCap(), replacement.GasTipCap()},
+		{"fee cap", old.GasFeeCap(), replacement.GasFeeCap()},
+	} {
+		min := new(big.Int).Div(new(big.Int).Mul(fee.old, big.NewInt(110)), big.NewInt(100))
+		if fee.new.Cmp(min) < 0 {
+			t.Errorf("replacement %s %s, want at least %s", fee.name, fee.new, min)
+		}
+	}
+}
+
+func TestSpeedUpJob(t *testing.T) {
+	jt := newJobsTest(t)
+	ctx := context.Background()
+	tx := jt.createPoll("Slow")
+	job := jt.track(ActionCreatePoll, tx)
+	jt.stall()
+
+	sped, err := jt.manager.SpeedUp(ctx, job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored := jt.stored(job.ID, models.TxStatusPending)
+	replacement := rawTx(t, stored)
+	checkBump(t, tx, replacement)
+	if stored.TxHash != replacement.Hash().Hex() || sped.TxHash != stored.TxHash ||
+		!reflect.DeepEqual(stored.PreviousTxHashes, []string{tx.Hash().Hex()}) {
+		t.Errorf("sped up job = %+v, want %s replacing %s", stored, replacement.Hash().Hex(), tx.Hash().Hex())
+	}
+
+	jt.sim.Backend.Commit()
+	jt.check()
+	if mined := jt.stored(job.ID, models.TxStatusMined); mined.TxHash != replacement.Hash().Hex() || mined.PollID != 1 {
+		t.Errorf("mined job = %+v", mined)
+	}
+	if _, err := jt.manager.SpeedUp(ctx, job.ID); !errors.Is(err, ErrNotPending) {
+		t.Errorf("speeding up a mined job: got %v, want ErrNotPending", err)
+	}
+}
+
+func TestStuckJobSpeedUpsAreCapped(t *testing.T) {
+	jt := newJobsTest(t)
+	ctx := context.Background()
+	jt.manager.SetStuckPolicy(StuckPolicy{After: time.Nanosecond, MaxSpeedups: 2})
+
+	tx := jt.createPoll("Stuck")
+	job := jt.track(ActionCreatePoll, tx)
+	sent := []*types.Transaction{tx}
+
+	// A speed-up the admin asks for does not use up the automatic ones
+	jt.stall()
+	if _, err := jt.manager.SpeedUp(ctx, job.ID); err != nil {
+		t.Fatal(err)
+	}
+	sent = append(sent, rawTx(t, jt.stored(job.ID, models.TxStatusPending)))
+	for i := 0; i < 4; i++ {
+		jt.stall()
+		jt.check()
+		if latest := rawTx(t, jt.stored(job.ID, models.TxStatusPending)); latest.Hash() != sent[len(sent)-1].Hash() {
+			sent = append(sent, latest)
+		}
+	}
+
+	if len(sent) != 4 {
+		t.Fatalf("sent %d transactions, want the original, 1 requested and 2 automatic speed-ups", len(sent))
+	}
+	for i := 1; i < len(sent); i++ {
+		checkBump(t, sent[i-1], sent[i])
+	}
+	stored := jt.stored(job.ID, models.TxStatusPending)
+	want := []string{sent[0].Hash().Hex(), sent[1].Hash().Hex(), sent[2].Hash().Hex()}
+	if stored.TxHash != sent[3].Hash().Hex() || !reflect.DeepEqual(stored.PreviousTxHashes, want) || stored.AutoSpeedups != 2 {
+		t.Errorf("job = %+v, want %s replacing %v after 2 automatic speed-ups", stored, sent[3].Hash().Hex(), want)
+	}
+
+	// Speed-ups asked for explicitly are not bounded
+	jt.stall()
+	if _, err := jt.manager.SpeedUp(ctx, job.ID); err != nil {
+		t.Errorf("speeding up past the automatic limit: %v", err)
+	}
+}
+
+func TestCancelJob(t *testing.T) {
+	jt := newJobsTest(t)
+	tx := jt.createPoll("Unwanted")
+	job := jt.track(ActionCreatePoll, tx)
+	jt.stall()
+
+	if _, err := jt.manager.Cancel(context.Background(), job.ID); err != nil {
+		t.Fatal(err)
+	}
+	stored := jt.stored(job.ID, models.TxStatusPending)
+	cancel := rawTx(t, stored)
+	checkBump(t, tx, cancel)
+	if cancel.To() == nil || *cancel.To() != jt.sim.Admin.Address || cancel.Value().Sign() != 0 || len(cancel.Data()) != 0 {
+		t.Errorf("cancellation sends %s to %v with %d bytes of data, want a 0-value transfer to %s",
+			cancel.Value(), cancel.To(), len(cancel.Data()), jt.sim.Admin.Address.Hex())
+	}
+	if stored.TxHash != cancel.Hash().Hex() || !reflect.DeepEqual(stored.PreviousTxHashes, []string{tx.Hash().Hex()}) {
+		t.Errorf("canceled job = %+v, want %s replacing %s", stored, cancel.Hash().Hex(), tx.Hash().Hex())
+	}
+
+	jt.sim.Backend.Commit()
+	jt.check()
+	if canceled := jt.stored(job.ID, models.TxStatusCanceled); canceled.TxHash != cancel.Hash().Hex() || canceled.PollID != 0 {
+		t.Errorf("canceled job = %+v", canceled)
+	}
+	ids, err := jt.client.GetAllPollIds()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("polls = %v, want none", ids)
+	}
+}
+
+func TestReplaceDoesNotWaitForSweep(t *testing.T) {
+	jt := newJobsTest(t)
+	ctx := context.Background()
+	voter := jt.sim.Voters[0]
+	transfer, err := types.SignNewTx(voter.Key, types.LatestSignerForChainID(jt.client.ChainID()), &types.LegacyTx{
+		To:       &jt.sim.Admin.Address,
+		Gas:      params.TxGas,
+		GasPrice: big.NewInt(10 * params.GWei),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slow := jt.track(ActionVote, transfer)
+	job := jt.track(ActionCreatePoll, jt.createPoll("Urgent"))
+	jt.stall()
+
+	// The sweep blocks on one job, as on a node slow to answer for it
+	unlock := jt.manager.lockJob(slow.ID)
+	swept := make(chan struct{})
+	go func() {
+		jt.check()
+		close(swept)
+	}()
+	for waiting := false; !waiting; {
+		jt.manager.mu.Lock()
+		waiting = jt.manager.locks[slow.ID].refs == 2
+		jt.manager.mu.Unlock()
+		time.Sleep(time.Millisecond)
+	}
+
+	sped := make(chan error, 1)
+	go func() {
+		_, err := jt.manager.SpeedUp(ctx, job.ID)
+		sped <- err
+	}()
+	select {
+	case err := <-sped:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("speed-up waited for the sweep")
+	}
+	unlock()
+	<-swept
+}
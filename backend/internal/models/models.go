@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Poll represents a voting poll
 type Poll struct {
@@ -14,6 +17,64 @@ type Poll struct {
 	IsActive    bool     `json:"isActive"`
 	IsCanceled  bool     `json:"isCanceled"`
 	TotalVotes  uint64   `json:"totalVotes"`
+	Finality    string   `json:"finality,omitempty"`
+}
+
+// Poll statuses as the contract reports them
+const (
+	PollStatusPending  = "Pending"
+	PollStatusActive   = "Active"
+	PollStatusEnded    = "Ended"
+	PollStatusCanceled = "Canceled"
+	PollStatusInactive = "Inactive"
+)
+
+// StatusAt returns the status the contract reports for the poll at block
+// time ts
+func (p *Poll) StatusAt(ts int64) string {
+	switch {
+	case p.IsCanceled:
+		return PollStatusCanceled
+	case ts < p.StartTime:
+		return PollStatusPending
+	case ts > p.EndTime:
+		return PollStatusEnded
+	case p.IsActive:
+		return PollStatusActive
+	default:
+		return PollStatusInactive
+	}
+}
+
+// PollPage is one page of a filtered poll listing. NextCursor is set when
+// more polls follow; passing it back as cursor returns the next page.
+type PollPage struct {
+	Polls      []*Poll `json:"polls"`
+	Total      int     `json:"total"`
+	Limit      int     `json:"limit"`
+	NextCursor string  `json:"nextCursor,omitempty"`
+	// Failed lists polls that could not be read and are missing from the page
+	Failed []ReadFailure `json:"failed,omitempty"`
+}
+
+// ReadFailure is a poll that could not be read as part of a batch
+type ReadFailure struct {
+	PollID uint64 `json:"pollId"`
+	Error  string `json:"error"`
+}
+
+// BatchResults are the results of several polls read together
+type BatchResults struct {
+	Results []*PollResults `json:"results"`
+	Failed  []ReadFailure  `json:"failed,omitempty"`
+}
+
+// BatchVoterStatus is a voter's status in several polls read together,
+// keyed by poll ID
+type BatchVoterStatus struct {
+	Voter    string                  `json:"voter"`
+	Statuses map[uint64]*VoterStatus `json:"statuses"`
+	Failed   []ReadFailure           `json:"failed,omitempty"`
 }
 
 // PollResults represents the results of a poll
@@ -22,8 +83,31 @@ type PollResults struct {
 	Options    []string `json:"options"`
 	VoteCounts []uint64 `json:"voteCounts"`
 	TotalVotes uint64   `json:"totalVotes"`
+	Finality   string   `json:"finality,omitempty"`
 }
 
+// ResultsSeries is a poll's cumulative vote counts at the end of each of a
+// run of equal time buckets
+type ResultsSeries struct {
+	PollID  uint64          `json:"pollId"`
+	Options []string        `json:"options"`
+	Bucket  int64           `json:"bucket"`
+	Points  []*ResultsPoint `json:"points"`
+}
+
+// ResultsPoint is a poll's tally as of a time
+type ResultsPoint struct {
+	Timestamp  int64    `json:"timestamp"`
+	VoteCounts []uint64 `json:"voteCounts"`
+	TotalVotes uint64   `json:"totalVotes"`
+}
+
+// Finality of indexed records
+const (
+	FinalityUnconfirmed = "unconfirmed"
+	FinalityFinal       = "final"
+)
+
 // Vote represents a single vote
 type Vote struct {
 	PollID      uint64 `json:"pollId"`
@@ -31,6 +115,45 @@ type Vote struct {
 	Voter       string `json:"voter"`
 	Timestamp   int64  `json:"timestamp"`
 	Weight      uint64 `json:"weight"`
+	TxHash      string `json:"txHash"`
+	BlockNumber uint64 `json:"blockNumber"`
+}
+
+// VotePage is one page of a poll's ballots
+type VotePage struct {
+	Votes  []*Vote `json:"votes"`
+	Total  int     `json:"total"`
+	Offset int     `json:"offset"`
+	Limit  int     `json:"limit"`
+}
+
+// Stream event types
+const (
+	EventPollCreated     = "pollCreated"
+	EventVoted           = "voted"
+	EventPollCanceled    = "pollCanceled"
+	EventPollActivated   = "pollActivated"
+	EventPollDeactivated = "pollDeactivated"
+	EventStatus          = "status"
+)
+
+// Event is a change pushed to stream subscribers. ID orders events by
+// chain position as "<block>-<index>" and is the Last-Event-ID to resume from.
+type Event struct {
+	ID          string      `json:"id"`
+	Type        string      `json:"type"`
+	PollID      uint64      `json:"pollId"`
+	BlockNumber uint64      `json:"blockNumber"`
+	Index       uint        `json:"-"`
+	TxHash      string      `json:"txHash,omitempty"`
+	Data        interface{} `json:"data,omitempty"`
+}
+
+// AuthVerifyRequest is the request body for completing a Sign-In with
+// Ethereum login
+type AuthVerifyRequest struct {
+	Message   string `json:"message" binding:"required"`
+	Signature string `json:"signature" binding:"required"`
 }
 
 // CreatePollRequest is the request body for creating a poll
@@ -42,10 +165,16 @@ type CreatePollRequest struct {
 	EndTime     int64    `json:"endTime" binding:"required"`
 }
 
-// VoteRequest is the request body for casting a vote
+// VoteRequest is the request body for casting a vote. The voter signs
+// (pollId, optionIndex, nonce, deadline) as EIP-712 typed data and the
+// server relays it, so the vote is attributed to the voter.
 type VoteRequest struct {
 	PollID      uint64 `json:"pollId" binding:"required"`
-	OptionIndex uint64 `json:"optionIndex" binding:"required"`
+	OptionIndex uint64 `json:"optionIndex"`
+	Voter       string `json:"voter" binding:"required"`
+	Nonce       uint64 `json:"nonce"`
+	Deadline    int64  `json:"deadline" binding:"required"`
+	Signature   string `json:"signature" binding:"required"`
 }
 
 // AssignVotingPowerRequest is the request body for assigning voting power
@@ -78,6 +207,14 @@ type APIResponse struct {
 	Success bool        `json:"success"`
 	Data    interface{} `json:"data,omitempty"`
 	Error   string      `json:"error,omitempty"`
+	// Meta is the block contract reads in Data were made at
+	Meta *BlockRef `json:"meta,omitempty"`
+}
+
+// BlockRef identifies the block a read was made at
+type BlockRef struct {
+	BlockNumber uint64 `json:"blockNumber"`
+	BlockHash   string `json:"blockHash"`
 }
 
 // ErrorResponse is an error response
@@ -90,6 +227,122 @@ type ErrorResponse struct {
 type ContractInfo struct {
 	Address    string    `json:"address"`
 	Admin      string    `json:"admin"`
+	ChainID    uint64    `json:"chainId"`
 	Network    string    `json:"network"`
 	DeployedAt time.Time `json:"deployedAt"`
 }
+
+// AccountRoles lists the contract roles an account holds. Operator is set
+// for accounts granted every role, which the backend may send any
+// transaction from.
+type AccountRoles struct {
+	Address  string   `json:"address"`
+	Roles    []string `json:"roles"`
+	Operator bool     `json:"operator"`
+}
+
+// RoleRequest is the request body for granting or revoking a role
+type RoleRequest struct {
+	Address string `json:"address" binding:"required"`
+	Role    string `json:"role" binding:"required"`
+}
+
+// TransferAdminRequest is the request body for transferring admin rights
+type TransferAdminRequest struct {
+	NewAdmin string `json:"newAdmin" binding:"required"`
+}
+
+// OperatorStatus describes an account the backend sends transactions from.
+// Balance is in wei; Pending counts transactions sent but not yet mined.
+type OperatorStatus struct {
+	Address      string `json:"address"`
+	Admin        bool   `json:"admin"`
+	Authorized   bool   `json:"authorized"`
+	Balance      string `json:"balance"`
+	Nonce        uint64 `json:"nonce"`
+	PendingNonce uint64 `json:"pendingNonce"`
+	Pending      uint64 `json:"pending"`
+}
+
+// Node transports
+const (
+	TransportWebSocket = "websocket"
+	TransportHTTP      = "http"
+	TransportIPC       = "ipc"
+	TransportSimulated = "simulated"
+	TransportMulti     = "multi"
+)
+
+// CacheStats counts how read cache lookups were answered and how many
+// events dropped entries
+type CacheStats struct {
+	Hits          uint64 `json:"hits"`
+	Misses        uint64 `json:"misses"`
+	Invalidations uint64 `json:"invalidations"`
+}
+
+// NodeHealth describes the connection to the Ethereum node. Subscribed is
+// set while new heads are pushed over a subscription rather than polled.
+type NodeHealth struct {
+	Transport  string     `json:"transport"`
+	Connected  bool       `json:"connected"`
+	Subscribed bool       `json:"subscribed"`
+	HeadBlock  uint64     `json:"headBlock"`
+	HeadSeenAt *time.Time `json:"headSeenAt,omitempty"`
+	Reconnects int        `json:"reconnects"`
+	LastError  string     `json:"lastError,omitempty"`
+
+	// Endpoints and Quorum are set when calls are routed across several
+	// RPC endpoints
+	Endpoints []EndpointHealth `json:"endpoints,omitempty"`
+	Quorum    int              `json:"quorum,omitempty"`
+}
+
+// EndpointHealth describes one of several RPC endpoints. URL omits the path
+// and credentials, which often hold API keys.
+type EndpointHealth struct {
+	URL       string     `json:"url"`
+	Transport string     `json:"transport"`
+	Healthy   bool       `json:"healthy"`
+	HeadBlock uint64     `json:"headBlock"`
+	Failures  int        `json:"failures"`
+	LastError string     `json:"lastError,omitempty"`
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+}
+
+// Transaction job statuses
+const (
+	TxStatusPending  = "pending"
+	TxStatusMined    = "mined"
+	TxStatusFailed   = "failed"
+	TxStatusReplaced = "replaced"
+	TxStatusCanceled = "canceled"
+)
+
+// TxJob tracks a transaction submitted on behalf of an API request. When
+// the transaction is sped up or canceled, TxHash is the latest replacement
+// and PreviousTxHashes the transactions it replaced, any of which may still
+// be mined in its place. AutoSpeedups counts the replacements sent because
+// the transaction was stuck, as opposed to those requested.
+type TxJob struct {
+	ID               string          `json:"id"`
+	Action           string          `json:"action"`
+	Status           string          `json:"status"`
+	TxHash           string          `json:"txHash"`
+	PreviousTxHashes []string        `json:"previousTxHashes,omitempty"`
+	AutoSpeedups     int             `json:"autoSpeedups,omitempty"`
+	From             string          `json:"from"`
+	Nonce            uint64          `json:"nonce"`
+	BlockNumber      uint64          `json:"blockNumber,omitempty"`
+	GasUsed          uint64          `json:"gasUsed,omitempty"`
+	Receipt          json.RawMessage `json:"receipt,omitempty"`
+	RevertReason     string          `json:"revertReason,omitempty"`
+	PollID           uint64          `json:"pollId,omitempty"`
+	SubmittedAt      time.Time       `json:"submittedAt"`
+	CreatedAt        time.Time       `json:"createdAt"`
+	UpdatedAt        time.Time       `json:"updatedAt"`
+
+	// RawTx is the signed transaction TxHash refers to, kept so it can be
+	// replaced after the node forgets it
+	RawTx []byte `json:"-"`
+}
@@ -0,0 +1,491 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"voting-dapp/backend/internal/blockchain"
+	"voting-dapp/backend/internal/cache"
+	"voting-dapp/backend/internal/indexer"
+	"voting-dapp/backend/internal/models"
+)
+
+// pollIDsKey is the cache key of the list of poll IDs
+const pollIDsKey = "polls"
+
+func pollKey(pollID uint64) string {
+	return fmt.Sprintf("poll:%d", pollID)
+}
+
+func resultsKey(pollID uint64) string {
+	return fmt.Sprintf("results:%d", pollID)
+}
+
+// Cached serves poll and result reads of the latest state from a cache in
+// front of the wrapped service. Entries are dropped as the events that
+// change them are stored, either by Apply as events arrive from the chain or
+// by Commit as the indexer stores them: a poll on a status change or a vote,
+// which changes its total; its results on a vote; and the poll list when a
+// poll is created. Commit also drops every entry when the index is rolled
+// back and the unconfirmed ones when the final block advances. Blocking
+// writes drop the entries they change as soon as they are mined. Reads at a
+// given block, statuses and voting power are not cached.
+type Cached struct {
+	VotingService
+	cache cache.Cache
+
+	// mu orders invalidations with storing values read before them; gen
+	// counts invalidations so that a read which raced one is not stored.
+	// stored holds the keys set, and whether their value is unconfirmed.
+	mu     sync.Mutex
+	gen    atomic.Uint64
+	stored map[string]bool
+
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+// cacheEntry is a cached value and the block it was read at
+type cacheEntry struct {
+	Block models.BlockRef `json:"block"`
+	Value json.RawMessage `json:"value"`
+}
+
+// NewCached creates a service that caches the reads of base in c
+func NewCached(base VotingService, c cache.Cache) *Cached {
+	return &Cached{VotingService: base, cache: c, stored: make(map[string]bool)}
+}
+
+// Stats reports the cache hits, misses and invalidations so far
+func (s *Cached) Stats() models.CacheStats {
+	return models.CacheStats{
+		Hits:          s.hits.Load(),
+		Misses:        s.misses.Load(),
+		Invalidations: s.invalidations.Load(),
+	}
+}
+
+// Apply drops the entries a contract event changes
+func (s *Cached) Apply(e models.Event) {
+	switch e.Type {
+	case models.EventPollCreated:
+		s.invalidate(pollIDsKey)
+	case models.EventVoted:
+		s.invalidate(pollKey(e.PollID), resultsKey(e.PollID))
+	case models.EventPollCanceled, models.EventPollActivated, models.EventPollDeactivated:
+		s.invalidate(pollKey(e.PollID))
+	}
+}
+
+// Commit drops the entries a write of the indexer changes
+func (s *Cached) Commit(c indexer.Commit) {
+	if c.RolledBack {
+		s.invalidateWhere(func(bool) bool { return true })
+	}
+	if c.Finalized {
+		s.invalidateWhere(func(unconfirmed bool) bool { return unconfirmed })
+	}
+	if c.Batch == nil {
+		return
+	}
+
+	var keys []string
+	if len(c.Batch.Polls) > 0 {
+		keys = append(keys, pollIDsKey)
+	}
+	for _, p := range c.Batch.Polls {
+		keys = append(keys, pollKey(p.ID))
+	}
+	for _, e := range c.Batch.PollEvents {
+		keys = append(keys, pollKey(e.PollID))
+	}
+	for _, v := range c.Batch.Votes {
+		keys = append(keys, pollKey(v.PollID), resultsKey(v.PollID))
+	}
+	if len(keys) > 0 {
+		s.invalidate(keys...)
+	}
+}
+
+// invalidate drops the entries under keys
+func (s *Cached) invalidate(keys ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gen.Add(1)
+	for _, key := range keys {
+		s.cache.Delete(key)
+		delete(s.stored, key)
+	}
+	s.invalidations.Add(1)
+}
+
+// invalidateWhere drops the entries for which drop, given whether the
+// entry is unconfirmed, returns true
+func (s *Cached) invalidateWhere(drop func(unconfirmed bool) bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gen.Add(1)
+	for key, unconfirmed := range s.stored {
+		if drop(unconfirmed) {
+			s.cache.Delete(key)
+			delete(s.stored, key)
+		}
+	}
+	s.invalidations.Add(1)
+}
+
+// load decodes the entry under key into v, counting a hit or a miss, and
+// returns the block the entry was read at
+func (s *Cached) load(key string, v interface{}) (models.BlockRef, bool) {
+	var entry cacheEntry
+	if data, ok := s.cache.Get(key); ok && json.Unmarshal(data, &entry) == nil && json.Unmarshal(entry.Value, v) == nil {
+		s.hits.Add(1)
+		return entry.Block, true
+	}
+	s.misses.Add(1)
+	return models.BlockRef{}, false
+}
+
+// save stores v, read at block, under key unless an entry was invalidated
+// since gen was read, in which case v may already be stale
+func (s *Cached) save(gen uint64, key string, block models.BlockRef, v interface{}) {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(cacheEntry{Block: block, Value: value})
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.gen.Load() == gen {
+		s.cache.Set(key, data)
+		s.stored[key] = isUnconfirmed(v)
+	}
+}
+
+// isUnconfirmed reports whether v is an indexed poll or result that may
+// still change when its block becomes final
+func isUnconfirmed(v interface{}) bool {
+	switch v := v.(type) {
+	case *models.Poll:
+		return v.Finality == models.FinalityUnconfirmed
+	case *models.PollResults:
+		return v.Finality == models.FinalityUnconfirmed
+	}
+	return false
+}
+
+// latest returns a reader of the latest block that answers from the cache
+// and reads misses through the wrapped service. The generation is read
+// before the block is pinned, so that nothing read at a block older than an
+// invalidation is stored.
+func (s *Cached) latest() (*cachedReader, models.BlockRef, error) {
+	gen := s.gen.Load()
+	r, block, err := s.VotingService.At(nil)
+	if err != nil {
+		return nil, models.BlockRef{}, err
+	}
+	return &cachedReader{Reader: r, cache: s, gen: gen, block: block, oldest: block}, block, nil
+}
+
+// At returns a reader of the latest block that answers from the cache.
+// Values it answers with may have been read at an earlier block; ReadBlock
+// reports the oldest. Reads at a given block pass through to the wrapped
+// service.
+func (s *Cached) At(block *big.Int) (Reader, models.BlockRef, error) {
+	if block != nil {
+		return s.VotingService.At(block)
+	}
+	r, ref, err := s.latest()
+	if err != nil {
+		return nil, models.BlockRef{}, err
+	}
+	return r, ref, nil
+}
+
+// ListPolls returns every poll, from the cache where possible
+func (s *Cached) ListPolls() ([]*models.Poll, error) {
+	r, _, err := s.latest()
+	if err != nil {
+		return nil, err
+	}
+	return r.ListPolls()
+}
+
+// ListPollsByCreator returns the polls an address created, from the cache
+// where possible
+func (s *Cached) ListPollsByCreator(creator string) ([]*models.Poll, error) {
+	r, _, err := s.latest()
+	if err != nil {
+		return nil, err
+	}
+	return r.ListPollsByCreator(creator)
+}
+
+// GetAllPollIds returns every poll ID, from the cache where possible
+func (s *Cached) GetAllPollIds() ([]uint64, error) {
+	r, _, err := s.latest()
+	if err != nil {
+		return nil, err
+	}
+	return r.GetAllPollIds()
+}
+
+// GetPoll returns a poll, from the cache where possible
+func (s *Cached) GetPoll(pollID uint64) (*models.Poll, error) {
+	r, _, err := s.latest()
+	if err != nil {
+		return nil, err
+	}
+	return r.GetPoll(pollID)
+}
+
+// GetPollResults returns a poll's results, from the cache where possible
+func (s *Cached) GetPollResults(pollID uint64) (*models.PollResults, error) {
+	r, _, err := s.latest()
+	if err != nil {
+		return nil, err
+	}
+	return r.GetPollResults(pollID)
+}
+
+// GetPollsResults returns the results of several polls, from the cache
+// where possible
+func (s *Cached) GetPollsResults(pollIDs []uint64) ([]*models.PollResults, error) {
+	r, _, err := s.latest()
+	if err != nil {
+		return nil, err
+	}
+	return r.GetPollsResults(pollIDs)
+}
+
+// CreatePoll creates a poll and drops the cached poll list
+func (s *Cached) CreatePoll(title, description string, options []string, startTime, endTime int64) (uint64, error) {
+	id, err := s.VotingService.CreatePoll(title, description, options, startTime, endTime)
+	if err == nil {
+		s.invalidate(pollIDsKey)
+	}
+	return id, err
+}
+
+// CancelPoll cancels a poll and drops it from the cache
+func (s *Cached) CancelPoll(pollID uint64) error {
+	err := s.VotingService.CancelPoll(pollID)
+	if err == nil {
+		s.invalidate(pollKey(pollID))
+	}
+	return err
+}
+
+// ActivatePoll activates a poll and drops it from the cache
+func (s *Cached) ActivatePoll(pollID uint64) error {
+	err := s.VotingService.ActivatePoll(pollID)
+	if err == nil {
+		s.invalidate(pollKey(pollID))
+	}
+	return err
+}
+
+// DeactivatePoll deactivates a poll and drops it from the cache
+func (s *Cached) DeactivatePoll(pollID uint64) error {
+	err := s.VotingService.DeactivatePoll(pollID)
+	if err == nil {
+		s.invalidate(pollKey(pollID))
+	}
+	return err
+}
+
+// VoteBySig relays a signed vote and drops the poll and its results from
+// the cache
+func (s *Cached) VoteBySig(vote *blockchain.SignedVote) error {
+	err := s.VotingService.VoteBySig(vote)
+	if err == nil {
+		s.invalidate(pollKey(vote.PollID), resultsKey(vote.PollID))
+	}
+	return err
+}
+
+// cachedReader answers poll and result reads from the cache and passes
+// misses and every other read through to the embedded reader, which reads
+// block. Misses are stored only while the cache generation is still gen,
+// the one before block was pinned. It tracks the oldest block a value it
+// answered with was read at.
+type cachedReader struct {
+	Reader
+	cache *Cached
+	gen   uint64
+	block models.BlockRef
+
+	mu     sync.Mutex
+	oldest models.BlockRef
+}
+
+// BlockTime returns the timestamp of the block the reader reads
+func (r *cachedReader) BlockTime() (int64, error) {
+	return BlockTime(r.Reader)
+}
+
+// ReadBlock returns the oldest block the values answered so far were read at
+func (r *cachedReader) ReadBlock() models.BlockRef {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.oldest
+}
+
+// served records that a value read at block was answered with
+func (r *cachedReader) served(block models.BlockRef) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if block.BlockNumber < r.oldest.BlockNumber {
+		r.oldest = block
+	}
+}
+
+// load answers from the entry under key into v, if it is cached
+func (r *cachedReader) load(key string, v interface{}) bool {
+	block, ok := r.cache.load(key, v)
+	if ok {
+		r.served(block)
+	}
+	return ok
+}
+
+// GetAllPollIds returns every poll ID
+func (r *cachedReader) GetAllPollIds() ([]uint64, error) {
+	var ids []uint64
+	if r.load(pollIDsKey, &ids) {
+		return ids, nil
+	}
+	ids, err := r.Reader.GetAllPollIds()
+	if err != nil {
+		return nil, err
+	}
+	r.cache.save(r.gen, pollIDsKey, r.block, ids)
+	return ids, nil
+}
+
+// GetPoll returns a poll
+func (r *cachedReader) GetPoll(pollID uint64) (*models.Poll, error) {
+	var poll models.Poll
+	if r.load(pollKey(pollID), &poll) {
+		return &poll, nil
+	}
+	p, err := r.Reader.GetPoll(pollID)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.save(r.gen, pollKey(pollID), r.block, p)
+	return p, nil
+}
+
+// GetPollResults returns a poll's results
+func (r *cachedReader) GetPollResults(pollID uint64) (*models.PollResults, error) {
+	var results models.PollResults
+	if r.load(resultsKey(pollID), &results) {
+		return &results, nil
+	}
+	res, err := r.Reader.GetPollResults(pollID)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.save(r.gen, resultsKey(pollID), r.block, res)
+	return res, nil
+}
+
+// GetPollsResults returns the results of several polls, reading those not
+// cached together. Results that cannot be read are reported in a
+// *blockchain.PartialReadError alongside the rest.
+func (r *cachedReader) GetPollsResults(pollIDs []uint64) ([]*models.PollResults, error) {
+	found := make(map[uint64]*models.PollResults, len(pollIDs))
+	var missing []uint64
+	for _, id := range pollIDs {
+		var results models.PollResults
+		if r.load(resultsKey(id), &results) {
+			found[id] = &results
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	var failed []models.ReadFailure
+	if len(missing) > 0 {
+		read, err := r.Reader.GetPollsResults(missing)
+		failures, partial := blockchain.AsPartialRead(err)
+		if err != nil && !partial {
+			return nil, err
+		}
+		for _, res := range read {
+			r.cache.save(r.gen, resultsKey(res.PollID), r.block, res)
+			found[res.PollID] = res
+		}
+		failed = failures
+	}
+
+	results := make([]*models.PollResults, 0, len(found))
+	for _, id := range pollIDs {
+		if res, ok := found[id]; ok {
+			results = append(results, res)
+		}
+	}
+	return results, blockchain.PartialRead(failed)
+}
+
+// ListPolls returns every poll. If any poll is not cached, the whole list
+// is read together and cached.
+func (r *cachedReader) ListPolls() ([]*models.Poll, error) {
+	ids, err := r.GetAllPollIds()
+	if err != nil {
+		return nil, err
+	}
+	polls := make([]*models.Poll, 0, len(ids))
+	blocks := make([]models.BlockRef, 0, len(ids))
+	for _, id := range ids {
+		var poll models.Poll
+		block, ok := r.cache.load(pollKey(id), &poll)
+		if !ok {
+			break
+		}
+		polls = append(polls, &poll)
+		blocks = append(blocks, block)
+	}
+	if len(polls) == len(ids) {
+		for _, block := range blocks {
+			r.served(block)
+		}
+		return polls, nil
+	}
+
+	polls, err = r.Reader.ListPolls()
+	if _, partial := blockchain.AsPartialRead(err); err != nil && !partial {
+		return nil, err
+	}
+	for _, p := range polls {
+		r.cache.save(r.gen, pollKey(p.ID), r.block, p)
+	}
+	return polls, err
+}
+
+// ListPollsByCreator returns the polls an address created, picked from
+// the list of every poll
+func (r *cachedReader) ListPollsByCreator(creator string) ([]*models.Poll, error) {
+	polls, err := r.ListPolls()
+	if _, partial := blockchain.AsPartialRead(err); err != nil && !partial {
+		return nil, err
+	}
+	address := common.HexToAddress(creator)
+	created := make([]*models.Poll, 0)
+	for _, p := range polls {
+		if common.HexToAddress(p.Creator) == address {
+			created = append(created, p)
+		}
+	}
+	return created, err
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"math/big"
+
+	"voting-dapp/backend/internal/blockchain"
+	"voting-dapp/backend/internal/models"
+)
+
+// Chain serves every operation from the contract through a blockchain client
+type Chain struct {
+	*blockchain.Client
+}
+
+// NewChain creates a service backed by client
+func NewChain(client *blockchain.Client) *Chain {
+	return &Chain{Client: client}
+}
+
+// At returns a reader of the contract as of block, or as of the current
+// head if block is nil
+func (c *Chain) At(block *big.Int) (Reader, models.BlockRef, error) {
+	r, err := c.Client.At(block)
+	if err != nil {
+		return nil, models.BlockRef{}, err
+	}
+	return chainReader{r}, r.Block(), nil
+}
+
+// GetPollResultsAt reads a poll's results as of block, which the node must
+// still have the state of
+func (c *Chain) GetPollResultsAt(pollID uint64, block *big.Int) (*models.PollResults, models.BlockRef, error) {
+	r, err := c.Client.At(block)
+	if err != nil {
+		return nil, models.BlockRef{}, err
+	}
+	results, err := r.GetPollResults(pollID)
+	if err != nil {
+		return nil, models.BlockRef{}, err
+	}
+	return results, r.Block(), nil
+}
+
+// ListPolls returns every poll, read together at one block. Polls that
+// cannot be read are reported in a *blockchain.PartialReadError alongside
+// the rest.
+func (c *Chain) ListPolls() ([]*models.Poll, error) {
+	r, err := c.Client.At(nil)
+	if err != nil {
+		return nil, err
+	}
+	return chainReader{r}.ListPolls()
+}
+
+// ListPollsByCreator returns the polls an address created, read together at
+// one block. Polls that cannot be read are reported in a
+// *blockchain.PartialReadError alongside the rest.
+func (c *Chain) ListPollsByCreator(creator string) ([]*models.Poll, error) {
+	r, err := c.Client.At(nil)
+	if err != nil {
+		return nil, err
+	}
+	return chainReader{r}.ListPollsByCreator(creator)
+}
+
+// chainReader reads the contract at the block of its blockchain reader
+type chainReader struct {
+	*blockchain.Reader
+}
+
+// ListPolls returns every poll that existed at the reader's block
+func (r chainReader) ListPolls() ([]*models.Poll, error) {
+	ids, err := r.GetAllPollIds()
+	if err != nil {
+		return nil, err
+	}
+	return r.GetPolls(ids)
+}
+
+// ListPollsByCreator returns the polls an address had created by the
+// reader's block
+func (r chainReader) ListPollsByCreator(creator string) ([]*models.Poll, error) {
+	ids, err := r.GetPollsByCreator(creator)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetPolls(ids)
+}
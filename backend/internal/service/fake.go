@@ -0,0 +1,733 @@
+package service
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"voting-dapp/backend/internal/blockchain"
+	"voting-dapp/backend/internal/models"
+)
+
+// fakeChainID is the chain ID the fake signs transactions and votes for
+const fakeChainID = 1337
+
+// Fake is an in-memory VotingService that applies the contract's rules
+// without a chain, for unit tests. Writes take effect immediately and are
+// reported as transactions signed by the admin key. Those transactions are
+// never mined, so jobs tracking them stay pending.
+type Fake struct {
+	// Now returns the time polls are checked against; it defaults to time.Now
+	Now func() time.Time
+
+	mu       sync.Mutex
+	admin    *ecdsa.PrivateKey
+	owner    common.Address
+	roles    map[string]map[common.Address]bool
+	contract common.Address
+	signer   types.Signer
+	block    uint64
+	times    []int64 // times[i] is when block i+1 was mined
+	polls    []*fakePoll
+	power    map[common.Address]uint64
+	nonces   map[common.Address]uint64
+	votes    []*models.Vote
+}
+
+// fakePoll is a poll with its tallies
+type fakePoll struct {
+	poll    models.Poll
+	created uint64
+	counts  []uint64
+	// choices maps each voter to the option they picked
+	choices map[common.Address]uint64
+}
+
+// NewFake creates an empty fake administered by admin
+func NewFake(admin *ecdsa.PrivateKey) *Fake {
+	return &Fake{
+		Now:      time.Now,
+		admin:    admin,
+		owner:    crypto.PubkeyToAddress(admin.PublicKey),
+		roles:    make(map[string]map[common.Address]bool),
+		contract: crypto.CreateAddress(crypto.PubkeyToAddress(admin.PublicKey), 0),
+		signer:   types.LatestSignerForChainID(big.NewInt(fakeChainID)),
+		power:    make(map[common.Address]uint64),
+		nonces:   make(map[common.Address]uint64),
+	}
+}
+
+// revert returns the error a contract call reverting with reason produces
+func revert(reason string) error {
+	return fmt.Errorf("execution reverted: %s", reason)
+}
+
+// GetContractAddress returns the address the fake contract would be deployed at
+func (f *Fake) GetContractAddress() string {
+	return f.contract.Hex()
+}
+
+// ChainID returns the chain ID the fake signs for
+func (f *Fake) ChainID() *big.Int {
+	return big.NewInt(fakeChainID)
+}
+
+// GetAdmin returns the admin address, which starts as the admin key's
+// account and changes with TransferAdmin
+func (f *Fake) GetAdmin() (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.owner.Hex(), nil
+}
+
+// Health reports the fake as a connected node
+func (f *Fake) Health() models.NodeHealth {
+	return models.NodeHealth{Transport: "fake", Connected: true}
+}
+
+// Operators reports the admin as the only account the fake sends from.
+// None of its transactions are ever mined, so all of them are pending.
+func (f *Fake) Operators(ctx context.Context) ([]models.OperatorStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sender := crypto.PubkeyToAddress(f.admin.PublicKey)
+	return []models.OperatorStatus{{
+		Address:      sender.Hex(),
+		Admin:        sender == f.owner,
+		Authorized:   sender == f.owner,
+		Balance:      "0",
+		PendingNonce: f.block,
+		Pending:      f.block,
+	}}, nil
+}
+
+// VoteDigest returns the EIP-712 digest a voter signs to authorize a vote
+func (f *Fake) VoteDigest(pollID, optionIndex, nonce uint64, deadline int64) (common.Hash, error) {
+	domain := blockchain.DomainSeparator(big.NewInt(fakeChainID), f.contract)
+	return blockchain.HashVote(domain, pollID, optionIndex, nonce, deadline), nil
+}
+
+// At returns the fake itself for the current block, which is the only one
+// it keeps the state of. Its block hash is made up from the number.
+func (f *Fake) At(block *big.Int) (Reader, models.BlockRef, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if block != nil && (!block.IsUint64() || block.Uint64() != f.block) {
+		return nil, models.BlockRef{}, fmt.Errorf("%w %v", blockchain.ErrUnknownBlock, block)
+	}
+	return f, fakeBlockRef(f.block), nil
+}
+
+// BlockAtTime returns the last block mined at or before timestamp
+func (f *Fake) BlockAtTime(timestamp int64) (*big.Int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := sort.Search(len(f.times), func(i int) bool { return f.times[i] > timestamp })
+	return big.NewInt(int64(n)), nil
+}
+
+// GetPollResultsAt replays the votes of a poll cast up to and including
+// block
+func (f *Fake) GetPollResultsAt(pollID uint64, block *big.Int) (*models.PollResults, models.BlockRef, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !block.IsUint64() || block.Uint64() > f.block {
+		return nil, models.BlockRef{}, fmt.Errorf("%w %v", blockchain.ErrUnknownBlock, block)
+	}
+	p, err := f.lookup(pollID)
+	if err == nil && p.created > block.Uint64() {
+		err = revert("Poll does not exist")
+	}
+	if err != nil {
+		return nil, models.BlockRef{}, err
+	}
+
+	results := &models.PollResults{
+		PollID:     pollID,
+		Options:    append([]string(nil), p.poll.Options...),
+		VoteCounts: make([]uint64, len(p.counts)),
+	}
+	for _, v := range f.votes {
+		if v.PollID == pollID && v.BlockNumber <= block.Uint64() {
+			results.VoteCounts[v.OptionIndex] += v.Weight
+			results.TotalVotes += v.Weight
+		}
+	}
+	return results, fakeBlockRef(block.Uint64()), nil
+}
+
+// fakeBlockRef identifies a fake block by its number and a hash made up
+// from it
+func fakeBlockRef(number uint64) models.BlockRef {
+	hash := crypto.Keccak256Hash(new(big.Int).SetUint64(number).Bytes())
+	return models.BlockRef{BlockNumber: number, BlockHash: hash.Hex()}
+}
+
+// ListPolls returns every poll
+func (f *Fake) ListPolls() ([]*models.Poll, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	polls := make([]*models.Poll, len(f.polls))
+	for i, p := range f.polls {
+		polls[i] = p.copyPoll()
+	}
+	return polls, nil
+}
+
+// ListPollsByCreator returns the polls an address created
+func (f *Fake) ListPollsByCreator(creator string) ([]*models.Poll, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	address := common.HexToAddress(creator).Hex()
+	polls := make([]*models.Poll, 0)
+	for _, p := range f.polls {
+		if p.poll.Creator == address {
+			polls = append(polls, p.copyPoll())
+		}
+	}
+	return polls, nil
+}
+
+// GetAllPollIds returns every poll ID
+func (f *Fake) GetAllPollIds() ([]uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := make([]uint64, len(f.polls))
+	for i, p := range f.polls {
+		ids[i] = p.poll.ID
+	}
+	return ids, nil
+}
+
+// GetPoll returns a poll
+func (f *Fake) GetPoll(pollID uint64) (*models.Poll, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, err := f.lookup(pollID)
+	if err != nil {
+		return nil, err
+	}
+	return p.copyPoll(), nil
+}
+
+// GetPollResults returns a poll's vote counts
+func (f *Fake) GetPollResults(pollID uint64) (*models.PollResults, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, err := f.lookup(pollID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.PollResults{
+		PollID:     pollID,
+		Options:    append([]string(nil), p.poll.Options...),
+		VoteCounts: append([]uint64(nil), p.counts...),
+		TotalVotes: p.poll.TotalVotes,
+	}, nil
+}
+
+// GetPollsResults returns the vote counts of several polls
+func (f *Fake) GetPollsResults(pollIDs []uint64) ([]*models.PollResults, error) {
+	results := make([]*models.PollResults, 0, len(pollIDs))
+	var failures []models.ReadFailure
+	for _, id := range pollIDs {
+		r, err := f.GetPollResults(id)
+		if err != nil {
+			failures = append(failures, models.ReadFailure{PollID: id, Error: err.Error()})
+			continue
+		}
+		results = append(results, r)
+	}
+	return results, blockchain.PartialRead(failures)
+}
+
+// GetPollStatus returns a poll's status as the contract reports it
+func (f *Fake) GetPollStatus(pollID uint64) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, err := f.lookup(pollID)
+	if err != nil {
+		return "", err
+	}
+
+	return p.poll.StatusAt(f.Now().Unix()), nil
+}
+
+// BlockTime returns the fake's clock, which its statuses follow
+func (f *Fake) BlockTime() (int64, error) {
+	return f.Now().Unix(), nil
+}
+
+// GetPollVotes returns every vote cast in a poll in the order they were cast
+func (f *Fake) GetPollVotes(pollID uint64) ([]*models.Vote, error) {
+	return f.filterVotes(func(v *models.Vote) bool { return v.PollID == pollID }), nil
+}
+
+// GetVotesByVoter returns every vote an address has cast
+func (f *Fake) GetVotesByVoter(voter string) ([]*models.Vote, error) {
+	addr := common.HexToAddress(voter).Hex()
+	return f.filterVotes(func(v *models.Vote) bool { return v.Voter == addr }), nil
+}
+
+// GetVoterStatus returns whether a voter has voted in a poll
+func (f *Fake) GetVoterStatus(pollID uint64, voter string) (*models.VoterStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, err := f.lookup(pollID)
+	if err != nil {
+		return nil, err
+	}
+	addr := common.HexToAddress(voter)
+	option, voted := p.choices[addr]
+	return &models.VoterStatus{
+		HasVoted:    voted,
+		OptionIndex: option,
+		VotingPower: f.power[addr],
+	}, nil
+}
+
+// GetVoterStatuses returns a voter's status in several polls
+func (f *Fake) GetVoterStatuses(pollIDs []uint64, voter string) (map[uint64]*models.VoterStatus, error) {
+	statuses := make(map[uint64]*models.VoterStatus, len(pollIDs))
+	var failures []models.ReadFailure
+	for _, id := range pollIDs {
+		status, err := f.GetVoterStatus(id, voter)
+		if err != nil {
+			failures = append(failures, models.ReadFailure{PollID: id, Error: err.Error()})
+			continue
+		}
+		statuses[id] = status
+	}
+	return statuses, blockchain.PartialRead(failures)
+}
+
+// GetVotingPower returns a voter's voting power
+func (f *Fake) GetVotingPower(voter string) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.power[common.HexToAddress(voter)], nil
+}
+
+// VoteNonce returns the nonce a voter must sign into their next vote
+func (f *Fake) VoteNonce(voter string) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.nonces[common.HexToAddress(voter)], nil
+}
+
+// CreatePollTx creates a poll
+func (f *Fake) CreatePollTx(title, description string, options []string, startTime, endTime int64) (*types.Transaction, error) {
+	_, tx, err := f.createPoll(title, description, options, startTime, endTime)
+	return tx, err
+}
+
+// CreatePoll creates a poll and returns its ID
+func (f *Fake) CreatePoll(title, description string, options []string, startTime, endTime int64) (uint64, error) {
+	id, _, err := f.createPoll(title, description, options, startTime, endTime)
+	return id, err
+}
+
+func (f *Fake) createPoll(title, description string, options []string, startTime, endTime int64) (uint64, *types.Transaction, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var err error
+	switch {
+	case title == "":
+		err = revert("Title cannot be empty")
+	case len(options) < 2:
+		err = revert("At least 2 options required")
+	case startTime >= endTime:
+		err = revert("Invalid time range")
+	case startTime < f.Now().Unix():
+		err = revert("Start time must be in the future")
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create poll: %v", err)
+	}
+
+	tx, err := f.newTx()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	id := uint64(len(f.polls)) + 1
+	f.polls = append(f.polls, &fakePoll{
+		poll: models.Poll{
+			ID:          id,
+			Title:       title,
+			Description: description,
+			Options:     append([]string(nil), options...),
+			StartTime:   startTime,
+			EndTime:     endTime,
+			Creator:     crypto.PubkeyToAddress(f.admin.PublicKey).Hex(),
+			IsActive:    true,
+		},
+		created: f.block,
+		counts:  make([]uint64, len(options)),
+		choices: make(map[common.Address]uint64),
+	})
+	return id, tx, nil
+}
+
+// CancelPollTx cancels a poll
+func (f *Fake) CancelPollTx(pollID uint64) (*types.Transaction, error) {
+	return f.updatePoll(pollID, "cancel", func(p *models.Poll) { p.IsCanceled = true })
+}
+
+// CancelPoll cancels a poll
+func (f *Fake) CancelPoll(pollID uint64) error {
+	_, err := f.CancelPollTx(pollID)
+	return err
+}
+
+// ActivatePollTx activates a poll
+func (f *Fake) ActivatePollTx(pollID uint64) (*types.Transaction, error) {
+	return f.updatePoll(pollID, "activate", func(p *models.Poll) { p.IsActive = true })
+}
+
+// ActivatePoll activates a poll
+func (f *Fake) ActivatePoll(pollID uint64) error {
+	_, err := f.ActivatePollTx(pollID)
+	return err
+}
+
+// DeactivatePollTx deactivates a poll
+func (f *Fake) DeactivatePollTx(pollID uint64) (*types.Transaction, error) {
+	return f.updatePoll(pollID, "deactivate", func(p *models.Poll) { p.IsActive = false })
+}
+
+// DeactivatePoll deactivates a poll
+func (f *Fake) DeactivatePoll(pollID uint64) error {
+	_, err := f.DeactivatePollTx(pollID)
+	return err
+}
+
+func (f *Fake) updatePoll(pollID uint64, action string, update func(*models.Poll)) (*types.Transaction, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, err := f.lookup(pollID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s poll: %v", action, err)
+	}
+	tx, err := f.newTx()
+	if err != nil {
+		return nil, err
+	}
+	update(&p.poll)
+	return tx, nil
+}
+
+// VoteBySigTx verifies a signed vote and records it
+func (f *Fake) VoteBySigTx(vote *blockchain.SignedVote) (*types.Transaction, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.Now()
+	if vote.Deadline < 0 || now.Unix() > vote.Deadline {
+		return nil, fmt.Errorf("%w: signature expired", blockchain.ErrVoteRejected)
+	}
+	digest, _ := f.VoteDigest(vote.PollID, vote.OptionIndex, vote.Nonce, vote.Deadline)
+	if err := blockchain.CheckVoteSigner(digest, vote); err != nil {
+		return nil, err
+	}
+	if nonce := f.nonces[vote.Voter]; vote.Nonce != nonce {
+		return nil, fmt.Errorf("%w: invalid nonce %d, expected %d", blockchain.ErrVoteRejected, vote.Nonce, nonce)
+	}
+
+	p, err := f.lookup(vote.PollID)
+	if err == nil {
+		err = f.checkVote(p, vote, now.Unix())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", blockchain.ErrVoteRejected, err)
+	}
+
+	tx, err := f.newTx()
+	if err != nil {
+		return nil, err
+	}
+
+	weight := f.power[vote.Voter]
+	f.nonces[vote.Voter]++
+	p.choices[vote.Voter] = vote.OptionIndex
+	p.counts[vote.OptionIndex] += weight
+	p.poll.TotalVotes += weight
+	f.votes = append(f.votes, &models.Vote{
+		PollID:      vote.PollID,
+		OptionIndex: vote.OptionIndex,
+		Voter:       vote.Voter.Hex(),
+		Timestamp:   now.Unix(),
+		Weight:      weight,
+		TxHash:      tx.Hash().Hex(),
+		BlockNumber: f.block,
+	})
+	return tx, nil
+}
+
+// checkVote applies the contract's checks on a vote in poll p at time now
+func (f *Fake) checkVote(p *fakePoll, vote *blockchain.SignedVote, now int64) error {
+	switch {
+	case !p.poll.IsActive:
+		return revert("Poll is not active")
+	case p.poll.IsCanceled:
+		return revert("Poll has been canceled")
+	case now < p.poll.StartTime:
+		return revert("Poll has not started yet")
+	case now > p.poll.EndTime:
+		return revert("Poll has ended")
+	}
+	if _, voted := p.choices[vote.Voter]; voted {
+		return revert("Already voted")
+	}
+	if vote.OptionIndex >= uint64(len(p.poll.Options)) {
+		return revert("Invalid option index")
+	}
+	if f.power[vote.Voter] == 0 {
+		return revert("No voting power")
+	}
+	return nil
+}
+
+// VoteBySig verifies a signed vote and records it
+func (f *Fake) VoteBySig(vote *blockchain.SignedVote) error {
+	_, err := f.VoteBySigTx(vote)
+	return err
+}
+
+// AssignVotingPowerTx sets a voter's voting power
+func (f *Fake) AssignVotingPowerTx(voter string, power uint64) (*types.Transaction, error) {
+	tx, err := f.assign([]string{voter}, []uint64{power})
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign voting power: %v", err)
+	}
+	return tx, nil
+}
+
+// AssignVotingPower sets a voter's voting power
+func (f *Fake) AssignVotingPower(voter string, power uint64) error {
+	_, err := f.AssignVotingPowerTx(voter, power)
+	return err
+}
+
+// BatchAssignVotingPowerTx sets the voting power of several voters
+func (f *Fake) BatchAssignVotingPowerTx(voters []string, powers []uint64) (*types.Transaction, error) {
+	tx, err := f.assign(voters, powers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch assign voting power: %v", err)
+	}
+	return tx, nil
+}
+
+// BatchAssignVotingPower sets the voting power of several voters
+func (f *Fake) BatchAssignVotingPower(voters []string, powers []uint64) error {
+	_, err := f.BatchAssignVotingPowerTx(voters, powers)
+	return err
+}
+
+func (f *Fake) assign(voters []string, powers []uint64) (*types.Transaction, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(voters) != len(powers) {
+		return nil, revert("Arrays length mismatch")
+	}
+	for _, voter := range voters {
+		if common.HexToAddress(voter) == (common.Address{}) {
+			return nil, revert("Invalid voter address")
+		}
+	}
+
+	tx, err := f.newTx()
+	if err != nil {
+		return nil, err
+	}
+	for i, voter := range voters {
+		f.power[common.HexToAddress(voter)] = powers[i]
+	}
+	return tx, nil
+}
+
+// TransferAdminTx makes another account the admin
+func (f *Fake) TransferAdminTx(newAdmin string) (*types.Transaction, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	address := common.HexToAddress(newAdmin)
+	err := f.checkAdmin()
+	if err == nil && address == (common.Address{}) {
+		err = revert("Invalid admin address")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to transfer admin: %v", err)
+	}
+
+	tx, err := f.newTx()
+	if err != nil {
+		return nil, err
+	}
+	f.owner = address
+	return tx, nil
+}
+
+// TransferAdmin makes another account the admin
+func (f *Fake) TransferAdmin(newAdmin string) error {
+	_, err := f.TransferAdminTx(newAdmin)
+	return err
+}
+
+// GrantRoleTx grants a role to an account
+func (f *Fake) GrantRoleTx(role, account string) (*types.Transaction, error) {
+	tx, err := f.setRole(role, account, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to grant role: %v", err)
+	}
+	return tx, nil
+}
+
+// GrantRole grants a role to an account
+func (f *Fake) GrantRole(role, account string) error {
+	_, err := f.GrantRoleTx(role, account)
+	return err
+}
+
+// RevokeRoleTx revokes a role from an account
+func (f *Fake) RevokeRoleTx(role, account string) (*types.Transaction, error) {
+	tx, err := f.setRole(role, account, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke role: %v", err)
+	}
+	return tx, nil
+}
+
+// RevokeRole revokes a role from an account
+func (f *Fake) RevokeRole(role, account string) error {
+	_, err := f.RevokeRoleTx(role, account)
+	return err
+}
+
+func (f *Fake) setRole(role, account string, held bool) (*types.Transaction, error) {
+	if !blockchain.IsGrantableRole(role) {
+		return nil, fmt.Errorf("%w %q", blockchain.ErrUnknownRole, role)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.checkAdmin(); err != nil {
+		return nil, err
+	}
+	tx, err := f.newTx()
+	if err != nil {
+		return nil, err
+	}
+	if f.roles[role] == nil {
+		f.roles[role] = make(map[common.Address]bool)
+	}
+	f.roles[role][common.HexToAddress(account)] = held
+	return tx, nil
+}
+
+// HasRole reports whether an account holds a role, counting the admin as
+// holding every role
+func (f *Fake) HasRole(role, account string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	address := common.HexToAddress(account)
+	switch {
+	case address == f.owner:
+		return true, nil
+	case role == blockchain.RoleAdmin:
+		return false, nil
+	case !blockchain.IsGrantableRole(role):
+		return false, fmt.Errorf("%w %q", blockchain.ErrUnknownRole, role)
+	}
+	return f.roles[role][address], nil
+}
+
+// GetRoles returns the roles an account holds
+func (f *Fake) GetRoles(account string) (*models.AccountRoles, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	address := common.HexToAddress(account)
+	roles := &models.AccountRoles{Address: address.Hex(), Roles: []string{}}
+	if address == f.owner {
+		roles.Roles = append(roles.Roles, blockchain.RoleAdmin)
+	}
+	for _, role := range []string{blockchain.RolePollManager, blockchain.RolePowerAssigner} {
+		if f.roles[role][address] {
+			roles.Roles = append(roles.Roles, role)
+		}
+	}
+	return roles, nil
+}
+
+// checkAdmin fails unless the fake's key is still the admin; f.mu must be held
+func (f *Fake) checkAdmin() error {
+	if crypto.PubkeyToAddress(f.admin.PublicKey) != f.owner {
+		return revert("Only admin can call this function")
+	}
+	return nil
+}
+
+// lookup returns a poll; f.mu must be held
+func (f *Fake) lookup(pollID uint64) (*fakePoll, error) {
+	if pollID == 0 || pollID > uint64(len(f.polls)) {
+		return nil, revert("Poll does not exist")
+	}
+	return f.polls[pollID-1], nil
+}
+
+// newTx returns a transaction from the admin to the contract standing in
+// for a write, and advances the block number; f.mu must be held
+func (f *Fake) newTx() (*types.Transaction, error) {
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{
+		Nonce:    f.block,
+		To:       &f.contract,
+		GasPrice: new(big.Int),
+	}), f.signer, f.admin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %v", err)
+	}
+	f.block++
+	f.times = append(f.times, f.Now().Unix())
+	return tx, nil
+}
+
+func (f *Fake) filterVotes(match func(*models.Vote) bool) []*models.Vote {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	votes := make([]*models.Vote, 0)
+	for _, v := range f.votes {
+		if match(v) {
+			vote := *v
+			votes = append(votes, &vote)
+		}
+	}
+	return votes
+}
+
+func (p *fakePoll) copyPoll() *models.Poll {
+	poll := p.poll
+	poll.Options = append([]string(nil), p.poll.Options...)
+	return &poll
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"fmt"
+	"math/big"
+
+	"voting-dapp/backend/internal/blockchain"
+	"voting-dapp/backend/internal/models"
+	"voting-dapp/backend/internal/store"
+)
+
+// Indexed serves poll, status and voting power reads from the event index
+// and passes everything else through to the wrapped service
+type Indexed struct {
+	VotingService
+	store *store.Store
+}
+
+// NewIndexed creates a service that reads from st in front of base
+func NewIndexed(base VotingService, st *store.Store) *Indexed {
+	return &Indexed{VotingService: base, store: st}
+}
+
+// At returns the index itself as the reader of its last indexed block, and
+// reads other blocks from the wrapped service. Until something has been
+// indexed, every block is read from the wrapped service.
+func (s *Indexed) At(block *big.Int) (Reader, models.BlockRef, error) {
+	if block != nil {
+		return s.VotingService.At(block)
+	}
+	blocks, err := s.store.RecentBlocks(1)
+	if err != nil {
+		return nil, models.BlockRef{}, fmt.Errorf("failed to get indexed block: %v", err)
+	}
+	if len(blocks) == 0 {
+		return s.VotingService.At(nil)
+	}
+	return s, models.BlockRef{BlockNumber: blocks[0].Number, BlockHash: blocks[0].Hash}, nil
+}
+
+// GetPollResultsAt replays a poll's indexed votes up to block, so that no
+// archive node is needed. Blocks past the index are read from the wrapped
+// service.
+func (s *Indexed) GetPollResultsAt(pollID uint64, block *big.Int) (*models.PollResults, models.BlockRef, error) {
+	last, ok, err := s.store.LastIndexedBlock()
+	if err != nil {
+		return nil, models.BlockRef{}, fmt.Errorf("failed to get indexed block: %v", err)
+	}
+	if !ok || !block.IsUint64() || block.Uint64() > last {
+		return s.VotingService.GetPollResultsAt(pollID, block)
+	}
+
+	// Only the block's header is read from the wrapped service
+	_, ref, err := s.VotingService.At(block)
+	if err != nil {
+		return nil, models.BlockRef{}, err
+	}
+	results, err := s.store.GetPollResultsAt(pollID, block.Uint64())
+	if err != nil {
+		return nil, models.BlockRef{}, err
+	}
+	return results, ref, nil
+}
+
+// ListPolls returns every indexed poll
+func (s *Indexed) ListPolls() ([]*models.Poll, error) {
+	return s.store.ListPolls()
+}
+
+// ListPollsByCreator returns the indexed polls an address created
+func (s *Indexed) ListPollsByCreator(creator string) ([]*models.Poll, error) {
+	return s.store.ListPollsByCreator(creator)
+}
+
+// GetPoll returns an indexed poll
+func (s *Indexed) GetPoll(pollID uint64) (*models.Poll, error) {
+	return s.store.GetPoll(pollID)
+}
+
+// GetPollResults returns a poll's indexed vote counts
+func (s *Indexed) GetPollResults(pollID uint64) (*models.PollResults, error) {
+	return s.store.GetPollResults(pollID)
+}
+
+// GetPollsResults returns the indexed vote counts of several polls
+func (s *Indexed) GetPollsResults(pollIDs []uint64) ([]*models.PollResults, error) {
+	results := make([]*models.PollResults, 0, len(pollIDs))
+	var failures []models.ReadFailure
+	for _, id := range pollIDs {
+		r, err := s.store.GetPollResults(id)
+		if err != nil {
+			failures = append(failures, models.ReadFailure{PollID: id, Error: err.Error()})
+			continue
+		}
+		results = append(results, r)
+	}
+	return results, blockchain.PartialRead(failures)
+}
+
+// GetPollStatus returns a poll's status from the index
+func (s *Indexed) GetPollStatus(pollID uint64) (string, error) {
+	return s.store.GetPollStatus(pollID)
+}
+
+// GetVoterStatus returns a voter's indexed status in a poll
+func (s *Indexed) GetVoterStatus(pollID uint64, voter string) (*models.VoterStatus, error) {
+	return s.store.GetVoterStatus(pollID, voter)
+}
+
+// GetVoterStatuses returns a voter's indexed status in several polls
+func (s *Indexed) GetVoterStatuses(pollIDs []uint64, voter string) (map[uint64]*models.VoterStatus, error) {
+	statuses := make(map[uint64]*models.VoterStatus, len(pollIDs))
+	var failures []models.ReadFailure
+	for _, id := range pollIDs {
+		status, err := s.store.GetVoterStatus(id, voter)
+		if err != nil {
+			failures = append(failures, models.ReadFailure{PollID: id, Error: err.Error()})
+			continue
+		}
+		statuses[id] = status
+	}
+	return statuses, blockchain.PartialRead(failures)
+}
+
+// GetVotingPower returns a voter's indexed voting power
+func (s *Indexed) GetVotingPower(voter string) (uint64, error) {
+	return s.store.GetVotingPower(voter)
+}
@@ -0,0 +1,103 @@
+// Package service defines the voting operations the API is built on and
+// their implementations: the contract on a live or simulated chain, the
+// event index and an in-memory fake for tests.
+package service
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"voting-dapp/backend/internal/blockchain"
+	"voting-dapp/backend/internal/models"
+)
+
+// Reader reads the contract's state. Reads of several polls return what
+// they could read along with a *blockchain.PartialReadError naming the
+// polls they could not.
+type Reader interface {
+	ListPolls() ([]*models.Poll, error)
+	ListPollsByCreator(creator string) ([]*models.Poll, error)
+	GetAllPollIds() ([]uint64, error)
+	GetPoll(pollID uint64) (*models.Poll, error)
+	GetPollResults(pollID uint64) (*models.PollResults, error)
+	GetPollsResults(pollIDs []uint64) ([]*models.PollResults, error)
+	GetPollStatus(pollID uint64) (string, error)
+	GetVoterStatus(pollID uint64, voter string) (*models.VoterStatus, error)
+	GetVoterStatuses(pollIDs []uint64, voter string) (map[uint64]*models.VoterStatus, error)
+	GetVotingPower(voter string) (uint64, error)
+}
+
+// VotingService reads and changes voting state. Each write has a blocking
+// form that waits for the change to be mined and a Tx form that returns as
+// soon as the transaction is submitted. Its own reads see the latest state;
+// At returns a reader whose reads all see the state of one block.
+type VotingService interface {
+	Reader
+
+	GetContractAddress() string
+	GetAdmin() (string, error)
+	ChainID() *big.Int
+	Health() models.NodeHealth
+	Operators(ctx context.Context) ([]models.OperatorStatus, error)
+
+	At(block *big.Int) (Reader, models.BlockRef, error)
+	BlockAtTime(timestamp int64) (*big.Int, error)
+	GetPollResultsAt(pollID uint64, block *big.Int) (*models.PollResults, models.BlockRef, error)
+	GetPollVotes(pollID uint64) ([]*models.Vote, error)
+	GetVotesByVoter(voter string) ([]*models.Vote, error)
+	VoteNonce(voter string) (uint64, error)
+	GetRoles(account string) (*models.AccountRoles, error)
+	HasRole(role, account string) (bool, error)
+
+	CreatePoll(title, description string, options []string, startTime, endTime int64) (uint64, error)
+	CreatePollTx(title, description string, options []string, startTime, endTime int64) (*types.Transaction, error)
+	CancelPoll(pollID uint64) error
+	CancelPollTx(pollID uint64) (*types.Transaction, error)
+	ActivatePoll(pollID uint64) error
+	ActivatePollTx(pollID uint64) (*types.Transaction, error)
+	DeactivatePoll(pollID uint64) error
+	DeactivatePollTx(pollID uint64) (*types.Transaction, error)
+	VoteBySig(vote *blockchain.SignedVote) error
+	VoteBySigTx(vote *blockchain.SignedVote) (*types.Transaction, error)
+	AssignVotingPower(voter string, power uint64) error
+	AssignVotingPowerTx(voter string, power uint64) (*types.Transaction, error)
+	BatchAssignVotingPower(voters []string, powers []uint64) error
+	BatchAssignVotingPowerTx(voters []string, powers []uint64) (*types.Transaction, error)
+	TransferAdmin(newAdmin string) error
+	TransferAdminTx(newAdmin string) (*types.Transaction, error)
+	GrantRole(role, account string) error
+	GrantRoleTx(role, account string) (*types.Transaction, error)
+	RevokeRole(role, account string) error
+	RevokeRoleTx(role, account string) (*types.Transaction, error)
+}
+
+var (
+	_ VotingService = (*Chain)(nil)
+	_ VotingService = (*Simulated)(nil)
+	_ VotingService = (*Indexed)(nil)
+	_ VotingService = (*Cached)(nil)
+	_ VotingService = (*Fake)(nil)
+)
+
+// ReadBlock returns the block the reads of r so far saw, given the block r
+// was opened at. A reader that answers from values read at earlier blocks
+// reports the oldest of them.
+func ReadBlock(r Reader, block models.BlockRef) models.BlockRef {
+	if br, ok := r.(interface{ ReadBlock() models.BlockRef }); ok {
+		return br.ReadBlock()
+	}
+	return block
+}
+
+// BlockTime returns the timestamp poll statuses read through r are derived
+// at: that of the block r reads, or the current time for readers such as
+// the event index that follow the wall clock
+func BlockTime(r Reader) (int64, error) {
+	if bt, ok := r.(interface{ BlockTime() (int64, error) }); ok {
+		return bt.BlockTime()
+	}
+	return time.Now().Unix(), nil
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"voting-dapp/backend/internal/blockchain"
+	"voting-dapp/backend/internal/models"
+)
+
+// Simulated serves the contract on an in-process simulated chain. The
+// embedded Simulation exposes the backend and funded accounts, and its Run
+// method mines blocks.
+type Simulated struct {
+	*Chain
+	*blockchain.Simulation
+}
+
+// NewSimulated starts a simulated chain with the contract deployed
+func NewSimulated(opts blockchain.SimulatedOptions) (*Simulated, error) {
+	client, sim, err := blockchain.NewSimulatedClient(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Simulated{Chain: NewChain(client), Simulation: sim}, nil
+}
+
+// Operators reports the accounts the client sends transactions from. It
+// resolves the clash with the Simulation field listing operator accounts.
+func (s *Simulated) Operators(ctx context.Context) ([]models.OperatorStatus, error) {
+	return s.Chain.Operators(ctx)
+}
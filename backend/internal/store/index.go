@@ -0,0 +1,483 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"voting-dapp/backend/internal/models"
+)
+
+// Poll status change kinds recorded in poll_events
+const (
+	PollEventCanceled    = "canceled"
+	PollEventActivated   = "activated"
+	PollEventDeactivated = "deactivated"
+)
+
+// indexer_state keys
+const (
+	// lastBlockKey holds the last fully indexed block
+	lastBlockKey = "last_block"
+	// finalBlockKey holds the newest block with enough confirmations to be final
+	finalBlockKey = "final_block"
+)
+
+// Position locates an event log on chain
+type Position struct {
+	BlockNumber uint64
+	LogIndex    uint
+	TxHash      string
+}
+
+// PollRecord is an indexed PollCreated event with the poll's metadata
+type PollRecord struct {
+	Position
+	ID          uint64
+	Title       string
+	Description string
+	Options     []string
+	StartTime   int64
+	EndTime     int64
+	Creator     string
+}
+
+// PollEventRecord is an indexed PollCanceled, PollActivated or PollDeactivated event
+type PollEventRecord struct {
+	Position
+	PollID uint64
+	Kind   string
+}
+
+// VoteRecord is an indexed Voted event
+type VoteRecord struct {
+	Position
+	PollID      uint64
+	Voter       string
+	OptionIndex uint64
+	Weight      uint64
+	Timestamp   int64
+}
+
+// PowerRecord is an indexed VotingPowerAssigned event
+type PowerRecord struct {
+	Position
+	Voter string
+	Power uint64
+}
+
+// BlockRecord is the hash of an indexed block, kept to detect reorgs
+type BlockRecord struct {
+	Number uint64
+	Hash   string
+}
+
+// IndexBatch holds the records decoded from a range of blocks
+type IndexBatch struct {
+	Polls      []PollRecord
+	PollEvents []PollEventRecord
+	Votes      []VoteRecord
+	Powers     []PowerRecord
+	Blocks     []BlockRecord
+}
+
+// LastIndexedBlock returns the checkpoint of the indexer; ok is false if
+// nothing has been indexed yet
+func (s *Store) LastIndexedBlock() (block uint64, ok bool, err error) {
+	err = s.db.QueryRow(`SELECT value FROM indexer_state WHERE key = ?`, lastBlockKey).Scan(&block)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	return block, err == nil, err
+}
+
+// ApplyIndexBatch stores a batch of records and advances the checkpoint to
+// lastBlock atomically. Records already present are left untouched, so a
+// range can safely be indexed twice.
+func (s *Store) ApplyIndexBatch(batch *IndexBatch, lastBlock uint64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, p := range batch.Polls {
+		options, err := json.Marshal(p.Options)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO polls
+			(id, title, description, options, start_time, end_time, creator, block_number, log_index, tx_hash)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			p.ID, p.Title, p.Description, string(options), p.StartTime, p.EndTime,
+			normalizeAddress(p.Creator), p.BlockNumber, p.LogIndex, p.TxHash,
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, e := range batch.PollEvents {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO poll_events
+			(block_number, log_index, tx_hash, poll_id, kind) VALUES (?, ?, ?, ?, ?)`,
+			e.BlockNumber, e.LogIndex, e.TxHash, e.PollID, e.Kind,
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range batch.Votes {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO votes
+			(block_number, log_index, tx_hash, poll_id, voter, option_index, weight, timestamp)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			v.BlockNumber, v.LogIndex, v.TxHash, v.PollID, normalizeAddress(v.Voter),
+			v.OptionIndex, v.Weight, v.Timestamp,
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, p := range batch.Powers {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO voting_power
+			(block_number, log_index, tx_hash, voter, power) VALUES (?, ?, ?, ?, ?)`,
+			p.BlockNumber, p.LogIndex, p.TxHash, normalizeAddress(p.Voter), p.Power,
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, b := range batch.Blocks {
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO blocks (number, hash) VALUES (?, ?)`,
+			b.Number, b.Hash); err != nil {
+			return err
+		}
+	}
+
+	if err := setState(tx, lastBlockKey, lastBlock); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RecentBlocks returns up to limit recorded block hashes, newest first
+func (s *Store) RecentBlocks(limit int) ([]BlockRecord, error) {
+	rows, err := s.db.Query(`SELECT number, hash FROM blocks ORDER BY number DESC LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blocks []BlockRecord
+	for rows.Next() {
+		var b BlockRecord
+		if err := rows.Scan(&b.Number, &b.Hash); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks, rows.Err()
+}
+
+// RollbackTo removes every record derived from blocks after block and
+// moves the checkpoint back to it
+func (s *Store) RollbackTo(block uint64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := deleteAfter(tx, block); err != nil {
+		return err
+	}
+	if err := setState(tx, lastBlockKey, block); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ResetIndex removes every indexed record and the checkpoint
+func (s *Store) ResetIndex() error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"polls", "poll_events", "votes", "voting_power", "blocks"} {
+		if _, err := tx.Exec(`DELETE FROM ` + table); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(`DELETE FROM indexer_state WHERE key = ?`, lastBlockKey); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// SetFinalBlock records the newest final block and prunes block hashes
+// that are no longer needed to detect reorgs, keeping one final anchor
+func (s *Store) SetFinalBlock(block uint64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := setState(tx, finalBlockKey, block); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM blocks WHERE number <
+		(SELECT MAX(number) FROM blocks WHERE number <= ?)`, block); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func deleteAfter(tx *sql.Tx, block uint64) error {
+	for _, table := range []string{"polls", "poll_events", "votes", "voting_power"} {
+		if _, err := tx.Exec(`DELETE FROM `+table+` WHERE block_number > ?`, block); err != nil {
+			return err
+		}
+	}
+	_, err := tx.Exec(`DELETE FROM blocks WHERE number > ?`, block)
+	return err
+}
+
+func setState(tx *sql.Tx, key string, value uint64) error {
+	_, err := tx.Exec(`INSERT INTO indexer_state (key, value) VALUES (?, ?)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value`, key, value)
+	return err
+}
+
+// finalBlock returns the newest final block, or false if unknown
+func (s *Store) finalBlock() (uint64, bool, error) {
+	var block uint64
+	err := s.db.QueryRow(`SELECT value FROM indexer_state WHERE key = ?`, finalBlockKey).Scan(&block)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	return block, err == nil, err
+}
+
+// finality classifies a record last touched in block
+func finality(block, final uint64, known bool) string {
+	if known && block <= final {
+		return models.FinalityFinal
+	}
+	return models.FinalityUnconfirmed
+}
+
+// pollSelect derives a poll's current flags and tally from its events,
+// along with the last block that affected it
+const pollSelect = `SELECT p.id, p.title, p.description, p.options, p.start_time, p.end_time, p.creator,
+	COALESCE((SELECT e.kind != 'deactivated' FROM poll_events e
+		WHERE e.poll_id = p.id AND e.kind IN ('activated', 'deactivated')
+		ORDER BY e.block_number DESC, e.log_index DESC LIMIT 1), 1),
+	EXISTS (SELECT 1 FROM poll_events e WHERE e.poll_id = p.id AND e.kind = 'canceled'),
+	COALESCE((SELECT SUM(v.weight) FROM votes v WHERE v.poll_id = p.id), 0),
+	MAX(p.block_number,
+		COALESCE((SELECT MAX(e.block_number) FROM poll_events e WHERE e.poll_id = p.id), 0),
+		COALESCE((SELECT MAX(v.block_number) FROM votes v WHERE v.poll_id = p.id), 0))
+	FROM polls p`
+
+// GetPoll returns an indexed poll
+func (s *Store) GetPoll(pollID uint64) (*models.Poll, error) {
+	final, known, err := s.finalBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	poll, err := scanPoll(s.db.QueryRow(pollSelect+` WHERE p.id = ?`, pollID), final, known)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return poll, err
+}
+
+// ListPolls returns every indexed poll ordered by ID
+func (s *Store) ListPolls() ([]*models.Poll, error) {
+	return s.listPolls(``)
+}
+
+// ListPollsByCreator returns the indexed polls an address created ordered by ID
+func (s *Store) ListPollsByCreator(creator string) ([]*models.Poll, error) {
+	return s.listPolls(`WHERE p.creator = ?`, normalizeAddress(creator))
+}
+
+// listPolls returns the indexed polls matching where ordered by ID
+func (s *Store) listPolls(where string, args ...interface{}) ([]*models.Poll, error) {
+	final, known, err := s.finalBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(pollSelect+` `+where+` ORDER BY p.id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	polls := make([]*models.Poll, 0)
+	for rows.Next() {
+		poll, err := scanPoll(rows, final, known)
+		if err != nil {
+			return nil, err
+		}
+		polls = append(polls, poll)
+	}
+	return polls, rows.Err()
+}
+
+// GetPollResults returns the tally of an indexed poll
+func (s *Store) GetPollResults(pollID uint64) (*models.PollResults, error) {
+	poll, err := s.GetPoll(pollID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.tally(pollID, len(poll.Options), math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PollResults{
+		PollID:     pollID,
+		Options:    poll.Options,
+		VoteCounts: counts,
+		TotalVotes: poll.TotalVotes,
+		Finality:   poll.Finality,
+	}, nil
+}
+
+// GetPollResultsAt replays the indexed votes of a poll mined up to and
+// including block. It returns ErrNotFound if the poll did not exist yet.
+func (s *Store) GetPollResultsAt(pollID, block uint64) (*models.PollResults, error) {
+	poll, err := s.GetPoll(pollID)
+	if err != nil {
+		return nil, err
+	}
+	var created uint64
+	if err := s.db.QueryRow(`SELECT block_number FROM polls WHERE id = ?`, pollID).Scan(&created); err != nil {
+		return nil, err
+	}
+	if created > block {
+		return nil, ErrNotFound
+	}
+
+	counts, err := s.tally(pollID, len(poll.Options), block)
+	if err != nil {
+		return nil, err
+	}
+	var total uint64
+	for _, count := range counts {
+		total += count
+	}
+
+	final, known, err := s.finalBlock()
+	if err != nil {
+		return nil, err
+	}
+	return &models.PollResults{
+		PollID:     pollID,
+		Options:    poll.Options,
+		VoteCounts: counts,
+		TotalVotes: total,
+		Finality:   finality(block, final, known),
+	}, nil
+}
+
+// tally sums the weight of the votes for each of a poll's options mined up
+// to and including block
+func (s *Store) tally(pollID uint64, options int, block uint64) ([]uint64, error) {
+	rows, err := s.db.Query(`SELECT option_index, SUM(weight) FROM votes
+		WHERE poll_id = ? AND block_number <= ? GROUP BY option_index`, pollID, block)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make([]uint64, options)
+	for rows.Next() {
+		var index, count uint64
+		if err := rows.Scan(&index, &count); err != nil {
+			return nil, err
+		}
+		if index < uint64(len(counts)) {
+			counts[index] = count
+		}
+	}
+	return counts, rows.Err()
+}
+
+// GetPollStatus derives a poll's status the same way the contract does,
+// using the current time in place of the block timestamp
+func (s *Store) GetPollStatus(pollID uint64) (string, error) {
+	poll, err := s.GetPoll(pollID)
+	if err != nil {
+		return "", err
+	}
+
+	return poll.StatusAt(time.Now().Unix()), nil
+}
+
+// GetVotingPower returns the most recently assigned voting power of a voter
+func (s *Store) GetVotingPower(voter string) (uint64, error) {
+	var power uint64
+	err := s.db.QueryRow(`SELECT power FROM voting_power WHERE voter = ?
+		ORDER BY block_number DESC, log_index DESC LIMIT 1`, normalizeAddress(voter)).Scan(&power)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return power, err
+}
+
+// GetVoterStatus returns whether and how a voter voted in an indexed poll
+func (s *Store) GetVoterStatus(pollID uint64, voter string) (*models.VoterStatus, error) {
+	if _, err := s.GetPoll(pollID); err != nil {
+		return nil, err
+	}
+
+	status := &models.VoterStatus{}
+	err := s.db.QueryRow(`SELECT option_index FROM votes WHERE poll_id = ? AND voter = ?`,
+		pollID, normalizeAddress(voter)).Scan(&status.OptionIndex)
+	switch {
+	case err == nil:
+		status.HasVoted = true
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	}
+
+	status.VotingPower, err = s.GetVotingPower(voter)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+func scanPoll(row scanner, final uint64, known bool) (*models.Poll, error) {
+	var poll models.Poll
+	var options string
+	var lastBlock uint64
+	err := row.Scan(
+		&poll.ID, &poll.Title, &poll.Description, &options, &poll.StartTime, &poll.EndTime,
+		&poll.Creator, &poll.IsActive, &poll.IsCanceled, &poll.TotalVotes, &lastBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+	poll.Finality = finality(lastBlock, final, known)
+	if err := json.Unmarshal([]byte(options), &poll.Options); err != nil {
+		return nil, err
+	}
+	return &poll, nil
+}
+
+// normalizeAddress stores addresses in checksummed form so lookups are
+// case-insensitive
+func normalizeAddress(address string) string {
+	return common.HexToAddress(address).Hex()
+}
@@ -0,0 +1,137 @@
+package store
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const (
+	testCreator = "0x00000000000000000000000000000000000000aa"
+	testVoter   = "0x00000000000000000000000000000000000000bb"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+// testPoll is a poll with two options created in block
+func testPoll(id, block uint64) PollRecord {
+	return PollRecord{
+		Position: Position{BlockNumber: block, TxHash: "0x01"},
+		ID:       id,
+		Title:    "Poll",
+		Options:  []string{"A", "B"},
+		EndTime:  1 << 40,
+		Creator:  testCreator,
+	}
+}
+
+func testVote(pollID, option, weight, block uint64, logIndex uint) VoteRecord {
+	return VoteRecord{
+		Position:    Position{BlockNumber: block, LogIndex: logIndex, TxHash: "0x02"},
+		PollID:      pollID,
+		Voter:       testVoter,
+		OptionIndex: option,
+		Weight:      weight,
+	}
+}
+
+func expectCounts(t *testing.T, s *Store, pollID uint64, want ...uint64) {
+	t.Helper()
+	results, err := s.GetPollResults(pollID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(results.VoteCounts, want) {
+		t.Errorf("vote counts = %v, want %v", results.VoteCounts, want)
+	}
+}
+
+func TestRollbackTo(t *testing.T) {
+	s := openTestStore(t)
+	if err := s.ApplyIndexBatch(&IndexBatch{
+		Polls:  []PollRecord{testPoll(1, 5)},
+		Votes:  []VoteRecord{testVote(1, 0, 3, 6, 0)},
+		Powers: []PowerRecord{{Position: Position{BlockNumber: 4}, Voter: testVoter, Power: 3}},
+		Blocks: []BlockRecord{{Number: 5, Hash: "0x05"}, {Number: 6, Hash: "0x06"}},
+	}, 6); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ApplyIndexBatch(&IndexBatch{
+		Polls:      []PollRecord{testPoll(2, 8)},
+		PollEvents: []PollEventRecord{{Position: Position{BlockNumber: 8, LogIndex: 1}, PollID: 1, Kind: PollEventCanceled}},
+		Votes:      []VoteRecord{testVote(1, 1, 7, 8, 2)},
+		Powers:     []PowerRecord{{Position: Position{BlockNumber: 8, LogIndex: 3}, Voter: testVoter, Power: 9}},
+		Blocks:     []BlockRecord{{Number: 8, Hash: "0x08"}, {Number: 9, Hash: "0x09"}},
+	}, 9); err != nil {
+		t.Fatal(err)
+	}
+	expectCounts(t, s, 1, 3, 7)
+
+	if err := s.RollbackTo(6); err != nil {
+		t.Fatal(err)
+	}
+
+	expectCounts(t, s, 1, 3, 0)
+	poll, err := s.GetPoll(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if poll.IsCanceled || poll.TotalVotes != 3 {
+		t.Errorf("poll after rollback = %+v, want uncanceled with 3 votes", poll)
+	}
+	if _, err := s.GetPoll(2); err != ErrNotFound {
+		t.Errorf("poll created after the rollback block: %v, want ErrNotFound", err)
+	}
+	if power, err := s.GetVotingPower(testVoter); err != nil || power != 3 {
+		t.Errorf("voting power after rollback = %d, %v, want 3", power, err)
+	}
+
+	last, ok, err := s.LastIndexedBlock()
+	if err != nil || !ok || last != 6 {
+		t.Errorf("checkpoint after rollback = %d, %v, %v, want 6", last, ok, err)
+	}
+	blocks, err := s.RecentBlocks(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []BlockRecord{{Number: 6, Hash: "0x06"}, {Number: 5, Hash: "0x05"}}; !reflect.DeepEqual(blocks, want) {
+		t.Errorf("recorded blocks after rollback = %+v, want %+v", blocks, want)
+	}
+}
+
+func TestApplyIndexBatchTwice(t *testing.T) {
+	s := openTestStore(t)
+	batch := &IndexBatch{
+		Polls:      []PollRecord{testPoll(1, 5)},
+		PollEvents: []PollEventRecord{{Position: Position{BlockNumber: 6}, PollID: 1, Kind: PollEventDeactivated}},
+		Votes:      []VoteRecord{testVote(1, 1, 4, 6, 1)},
+		Powers:     []PowerRecord{{Position: Position{BlockNumber: 5, LogIndex: 1}, Voter: testVoter, Power: 4}},
+		Blocks:     []BlockRecord{{Number: 6, Hash: "0x06"}},
+	}
+	for i := 0; i < 2; i++ {
+		if err := s.ApplyIndexBatch(batch, 6); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expectCounts(t, s, 1, 0, 4)
+	poll, err := s.GetPoll(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if poll.IsActive || poll.TotalVotes != 4 {
+		t.Errorf("poll after applying twice = %+v, want inactive with 4 votes", poll)
+	}
+	blocks, err := s.RecentBlocks(10)
+	if err != nil || len(blocks) != 1 {
+		t.Errorf("recorded blocks = %+v, %v, want one", blocks, err)
+	}
+}
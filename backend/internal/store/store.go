@@ -0,0 +1,153 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "modernc.org/sqlite"
+)
+
+// migrations are applied in order; the index of the last applied migration
+// is recorded in the database's user_version
+var migrations = []string{
+	`CREATE TABLE tx_jobs (
+		id            TEXT PRIMARY KEY,
+		action        TEXT NOT NULL,
+		status        TEXT NOT NULL,
+		tx_hash       TEXT NOT NULL,
+		from_address  TEXT NOT NULL,
+		nonce         INTEGER NOT NULL,
+		block_number  INTEGER NOT NULL DEFAULT 0,
+		gas_used      INTEGER NOT NULL DEFAULT 0,
+		receipt       TEXT,
+		revert_reason TEXT NOT NULL DEFAULT '',
+		poll_id       INTEGER NOT NULL DEFAULT 0,
+		created_at    DATETIME NOT NULL,
+		updated_at    DATETIME NOT NULL
+	);
+	CREATE INDEX tx_jobs_status ON tx_jobs (status);`,
+
+	`CREATE TABLE polls (
+		id           INTEGER PRIMARY KEY,
+		title        TEXT NOT NULL,
+		description  TEXT NOT NULL,
+		options      TEXT NOT NULL,
+		start_time   INTEGER NOT NULL,
+		end_time     INTEGER NOT NULL,
+		creator      TEXT NOT NULL,
+		block_number INTEGER NOT NULL,
+		log_index    INTEGER NOT NULL,
+		tx_hash      TEXT NOT NULL
+	);
+	CREATE INDEX polls_creator ON polls (creator);
+
+	CREATE TABLE poll_events (
+		block_number INTEGER NOT NULL,
+		log_index    INTEGER NOT NULL,
+		tx_hash      TEXT NOT NULL,
+		poll_id      INTEGER NOT NULL,
+		kind         TEXT NOT NULL,
+		PRIMARY KEY (block_number, log_index)
+	);
+	CREATE INDEX poll_events_poll ON poll_events (poll_id);
+
+	CREATE TABLE votes (
+		block_number INTEGER NOT NULL,
+		log_index    INTEGER NOT NULL,
+		tx_hash      TEXT NOT NULL,
+		poll_id      INTEGER NOT NULL,
+		voter        TEXT NOT NULL,
+		option_index INTEGER NOT NULL,
+		weight       INTEGER NOT NULL,
+		timestamp    INTEGER NOT NULL,
+		PRIMARY KEY (block_number, log_index)
+	);
+	CREATE INDEX votes_poll ON votes (poll_id);
+	CREATE INDEX votes_voter ON votes (voter);
+
+	CREATE TABLE voting_power (
+		block_number INTEGER NOT NULL,
+		log_index    INTEGER NOT NULL,
+		tx_hash      TEXT NOT NULL,
+		voter        TEXT NOT NULL,
+		power        INTEGER NOT NULL,
+		PRIMARY KEY (block_number, log_index)
+	);
+	CREATE INDEX voting_power_voter ON voting_power (voter);
+
+	CREATE TABLE indexer_state (
+		key   TEXT PRIMARY KEY,
+		value INTEGER NOT NULL
+	);`,
+
+	`CREATE TABLE blocks (
+		number INTEGER PRIMARY KEY,
+		hash   TEXT NOT NULL
+	);`,
+
+	`ALTER TABLE tx_jobs ADD COLUMN previous_tx_hashes TEXT NOT NULL DEFAULT '';
+	ALTER TABLE tx_jobs ADD COLUMN raw_tx TEXT;
+	ALTER TABLE tx_jobs ADD COLUMN submitted_at DATETIME;
+	UPDATE tx_jobs SET submitted_at = created_at;`,
+
+	`ALTER TABLE tx_jobs ADD COLUMN auto_speedups INTEGER NOT NULL DEFAULT 0;`,
+}
+
+// Store is the backend's local SQLite database
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens the database at path, creating it if needed, and applies any
+// pending migrations
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %v", err)
+	}
+	// SQLite allows a single writer; funnel everything through one connection
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(`PRAGMA journal_mode = WAL; PRAGMA busy_timeout = 5000;`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to configure database: %v", err)
+	}
+
+	s := &Store{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) migrate() error {
+	var version int
+	if err := s.db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %v", err)
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to apply migration %d: %v", i+1, err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, i+1)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
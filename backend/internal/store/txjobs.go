@@ -0,0 +1,111 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"voting-dapp/backend/internal/models"
+)
+
+// ErrNotFound is returned when a requested record does not exist
+var ErrNotFound = errors.New("not found")
+
+const txJobColumns = `id, action, status, tx_hash, previous_tx_hashes, auto_speedups,
+	from_address, nonce, block_number, gas_used, receipt, revert_reason, poll_id, raw_tx,
+	submitted_at, created_at, updated_at`
+
+// SaveTxJob inserts or updates a transaction job
+func (s *Store) SaveTxJob(job *models.TxJob) error {
+	_, err := s.db.Exec(`INSERT INTO tx_jobs (`+txJobColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			status = excluded.status,
+			tx_hash = excluded.tx_hash,
+			previous_tx_hashes = excluded.previous_tx_hashes,
+			auto_speedups = excluded.auto_speedups,
+			block_number = excluded.block_number,
+			gas_used = excluded.gas_used,
+			receipt = excluded.receipt,
+			revert_reason = excluded.revert_reason,
+			poll_id = excluded.poll_id,
+			raw_tx = excluded.raw_tx,
+			submitted_at = excluded.submitted_at,
+			updated_at = excluded.updated_at`,
+		job.ID, job.Action, job.Status, job.TxHash, strings.Join(job.PreviousTxHashes, ","),
+		job.AutoSpeedups, job.From, job.Nonce, job.BlockNumber, job.GasUsed,
+		nullableString(job.Receipt), job.RevertReason, job.PollID, nullableString([]byte(hex.EncodeToString(job.RawTx))),
+		job.SubmittedAt, job.CreatedAt, job.UpdatedAt,
+	)
+	return err
+}
+
+// GetTxJob returns the transaction job with the given ID
+func (s *Store) GetTxJob(id string) (*models.TxJob, error) {
+	row := s.db.QueryRow(`SELECT `+txJobColumns+` FROM tx_jobs WHERE id = ?`, id)
+	job, err := scanTxJob(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return job, err
+}
+
+// PendingTxJobs returns all jobs that have not reached a final status
+func (s *Store) PendingTxJobs() ([]*models.TxJob, error) {
+	rows, err := s.db.Query(`SELECT `+txJobColumns+` FROM tx_jobs WHERE status = ? ORDER BY created_at`,
+		models.TxStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []*models.TxJob
+	for rows.Next() {
+		job, err := scanTxJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTxJob(row scanner) (*models.TxJob, error) {
+	var job models.TxJob
+	var previous string
+	var receipt, rawTx sql.NullString
+	var submittedAt sql.NullTime
+	err := row.Scan(
+		&job.ID, &job.Action, &job.Status, &job.TxHash, &previous, &job.AutoSpeedups, &job.From,
+		&job.Nonce, &job.BlockNumber, &job.GasUsed, &receipt, &job.RevertReason, &job.PollID, &rawTx,
+		&submittedAt, &job.CreatedAt, &job.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if previous != "" {
+		job.PreviousTxHashes = strings.Split(previous, ",")
+	}
+	if receipt.Valid {
+		job.Receipt = []byte(receipt.String)
+	}
+	if rawTx.Valid {
+		if job.RawTx, err = hex.DecodeString(rawTx.String); err != nil {
+			return nil, err
+		}
+	}
+	job.SubmittedAt = submittedAt.Time
+	return &job, nil
+}
+
+func nullableString(b []byte) interface{} {
+	if len(b) == 0 {
+		return nil
+	}
+	return string(b)
+}
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"voting-dapp/backend/internal/models"
+)
+
+func testJob(id string, created time.Time) *models.TxJob {
+	return &models.TxJob{
+		ID:          id,
+		Action:      "createPoll",
+		Status:      models.TxStatusPending,
+		TxHash:      "0x01",
+		From:        testCreator,
+		Nonce:       4,
+		SubmittedAt: created,
+		CreatedAt:   created,
+		UpdatedAt:   created,
+		RawTx:       []byte{0xde, 0xad},
+	}
+}
+
+func TestTxJobRoundTrip(t *testing.T) {
+	s := openTestStore(t)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	job := testJob("a", now)
+	if err := s.SaveTxJob(job); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.GetTxJob("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, job) {
+		t.Errorf("stored %+v, got %+v", job, got)
+	}
+
+	// Updates keep the identity of the job and replace everything else
+	job.Status = models.TxStatusMined
+	job.TxHash = "0x02"
+	job.PreviousTxHashes = []string{"0x01"}
+	job.AutoSpeedups = 1
+	job.BlockNumber, job.GasUsed, job.PollID = 12, 21000, 3
+	job.Receipt = json.RawMessage(`{"status":"0x1"}`)
+	job.RawTx = []byte{0xbe, 0xef}
+	job.UpdatedAt = now.Add(time.Minute)
+	if err := s.SaveTxJob(job); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = s.GetTxJob("a"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, job) {
+		t.Errorf("updated %+v, got %+v", job, got)
+	}
+
+	if _, err := s.GetTxJob("missing"); err != ErrNotFound {
+		t.Errorf("missing job: %v, want ErrNotFound", err)
+	}
+}
+
+func TestPendingTxJobs(t *testing.T) {
+	s := openTestStore(t)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	for i, id := range []string{"c", "a", "b"} {
+		if err := s.SaveTxJob(testJob(id, now.Add(time.Duration(i)*time.Second))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	failed := testJob("a", now.Add(time.Second))
+	failed.Status = models.TxStatusFailed
+	if err := s.SaveTxJob(failed); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := s.PendingTxJobs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	if want := []string{"c", "b"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("pending jobs = %v, want %v in creation order", ids, want)
+	}
+}
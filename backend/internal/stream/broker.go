@@ -0,0 +1,177 @@
+// Package stream fans out contract events and poll status changes to
+// Server-Sent Events subscribers
+package stream
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"voting-dapp/backend/internal/models"
+)
+
+// subscriberBuffer is how many events may queue for a slow subscriber
+// before it is dropped; it can reconnect and resume with Last-Event-ID
+const subscriberBuffer = 64
+
+// Config controls how many events are kept for resuming and how often idle
+// streams are kept alive
+type Config struct {
+	// BufferSize is the number of recent events kept for Last-Event-ID resume
+	BufferSize int
+	// Heartbeat is how often a comment is written to idle streams
+	Heartbeat time.Duration
+}
+
+// Broker keeps a window of recent events and delivers new ones to
+// subscribers
+type Broker struct {
+	cfg Config
+
+	mu     sync.Mutex
+	recent []models.Event
+	subs   map[*Subscription]struct{}
+}
+
+// Subscription receives the events published after it was created. Events
+// is closed when the subscription is closed or falls too far behind.
+type Subscription struct {
+	Events <-chan models.Event
+
+	broker *Broker
+	events chan models.Event
+	pollID uint64
+}
+
+// NewBroker creates a broker
+func NewBroker(cfg Config) *Broker {
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = 1000
+	}
+	if cfg.Heartbeat <= 0 {
+		cfg.Heartbeat = 15 * time.Second
+	}
+	return &Broker{
+		cfg:  cfg,
+		subs: make(map[*Subscription]struct{}),
+	}
+}
+
+// Heartbeat returns how often idle streams should be kept alive
+func (b *Broker) Heartbeat() time.Duration {
+	return b.cfg.Heartbeat
+}
+
+// Publish assigns each event its ID and delivers it to matching subscribers
+func (b *Broker) Publish(events ...models.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, e := range events {
+		e.ID = fmt.Sprintf("%d-%d", e.BlockNumber, e.Index)
+
+		b.recent = append(b.recent, e)
+		if len(b.recent) > b.cfg.BufferSize {
+			b.recent = b.recent[len(b.recent)-b.cfg.BufferSize:]
+		}
+
+		for sub := range b.subs {
+			if !sub.matches(e) {
+				continue
+			}
+			select {
+			case sub.events <- e:
+			default:
+				b.remove(sub)
+			}
+		}
+	}
+}
+
+// Subscribe returns a subscription to the events of pollID, or of every
+// poll when pollID is 0, together with the buffered events published after
+// lastEventID. An empty or unknown lastEventID replays nothing.
+func (b *Broker) Subscribe(pollID uint64, lastEventID string) (*Subscription, []models.Event) {
+	events := make(chan models.Event, subscriberBuffer)
+	sub := &Subscription{
+		Events: events,
+		broker: b,
+		events: events,
+		pollID: pollID,
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replay []models.Event
+	if block, index, ok := parseEventID(lastEventID); ok {
+		for _, e := range b.recent {
+			after := e.BlockNumber > block || (e.BlockNumber == block && e.Index > index)
+			if after && sub.matches(e) {
+				replay = append(replay, e)
+			}
+		}
+	}
+
+	b.subs[sub] = struct{}{}
+	return sub, replay
+}
+
+// Follow calls handle with every event published until ctx is canceled.
+// If handle falls too far behind, it resubscribes and replays the buffered
+// events it missed.
+func (b *Broker) Follow(ctx context.Context, handle func(models.Event)) {
+	var last string
+	for {
+		sub, replay := b.Subscribe(0, last)
+		for _, e := range replay {
+			handle(e)
+			last = e.ID
+		}
+
+	deliver:
+		for {
+			select {
+			case <-ctx.Done():
+				sub.Close()
+				return
+			case e, ok := <-sub.Events:
+				if !ok {
+					break deliver
+				}
+				handle(e)
+				last = e.ID
+			}
+		}
+	}
+}
+
+// Close stops delivery to the subscription
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s)
+}
+
+func (s *Subscription) matches(e models.Event) bool {
+	return s.pollID == 0 || e.PollID == s.pollID
+}
+
+// remove drops a subscriber and closes its channel; b.mu must be held
+func (b *Broker) remove(sub *Subscription) {
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.events)
+	}
+}
+
+func parseEventID(id string) (block uint64, index uint, ok bool) {
+	if id == "" {
+		return 0, 0, false
+	}
+	if _, err := fmt.Sscanf(id, "%d-%d", &block, &index); err != nil {
+		return 0, 0, false
+	}
+	return block, index, true
+}
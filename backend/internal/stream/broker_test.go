@@ -0,0 +1,156 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"voting-dapp/backend/internal/models"
+)
+
+func event(block uint64, index uint, pollID uint64) models.Event {
+	return models.Event{Type: models.EventVoted, BlockNumber: block, Index: index, PollID: pollID}
+}
+
+func ids(events []models.Event) []string {
+	out := make([]string, len(events))
+	for i, e := range events {
+		out[i] = e.ID
+	}
+	return out
+}
+
+func TestSubscribeReplaysEventsAfterLastID(t *testing.T) {
+	b := NewBroker(Config{BufferSize: 10})
+	b.Publish(event(5, 0, 1), event(5, 1, 2), event(6, 0, 1), event(7, 3, 1))
+
+	tests := []struct {
+		pollID      uint64
+		lastEventID string
+		want        []string
+	}{
+		{0, "", nil},
+		{0, "garbage", nil},
+		{0, "5-0", []string{"5-1", "6-0", "7-3"}},
+		{1, "5-0", []string{"6-0", "7-3"}},
+		{2, "5-0", []string{"5-1"}},
+		{0, "7-3", nil},
+		{0, "0-0", []string{"5-0", "5-1", "6-0", "7-3"}},
+	}
+	for _, tt := range tests {
+		sub, replay := b.Subscribe(tt.pollID, tt.lastEventID)
+		sub.Close()
+		got := ids(replay)
+		if len(got) != len(tt.want) {
+			t.Errorf("poll %d after %q: replayed %v, want %v", tt.pollID, tt.lastEventID, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("poll %d after %q: replayed %v, want %v", tt.pollID, tt.lastEventID, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestBufferKeepsMostRecentEvents(t *testing.T) {
+	b := NewBroker(Config{BufferSize: 2})
+	b.Publish(event(1, 0, 1), event(2, 0, 1), event(3, 0, 1))
+
+	sub, replay := b.Subscribe(0, "0-0")
+	defer sub.Close()
+	if got := ids(replay); len(got) != 2 || got[0] != "2-0" || got[1] != "3-0" {
+		t.Fatalf("replayed %v, want [2-0 3-0]", got)
+	}
+}
+
+func TestPublishDeliversToMatchingSubscribers(t *testing.T) {
+	b := NewBroker(Config{})
+	all, _ := b.Subscribe(0, "")
+	defer all.Close()
+	poll2, _ := b.Subscribe(2, "")
+	defer poll2.Close()
+
+	b.Publish(event(1, 0, 1), event(1, 1, 2))
+
+	if e := <-all.Events; e.ID != "1-0" {
+		t.Errorf("all: got %s, want 1-0", e.ID)
+	}
+	if e := <-all.Events; e.ID != "1-1" {
+		t.Errorf("all: got %s, want 1-1", e.ID)
+	}
+	if e := <-poll2.Events; e.ID != "1-1" || e.PollID != 2 {
+		t.Errorf("poll 2: got %s for poll %d", e.ID, e.PollID)
+	}
+	select {
+	case e := <-poll2.Events:
+		t.Errorf("poll 2 received unexpected event %s", e.ID)
+	default:
+	}
+}
+
+func TestSlowSubscriberIsDropped(t *testing.T) {
+	b := NewBroker(Config{})
+	sub, _ := b.Subscribe(0, "")
+	defer sub.Close()
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		b.Publish(event(uint64(i), 0, 1))
+	}
+
+	n := 0
+	for range sub.Events {
+		n++
+	}
+	if n != subscriberBuffer {
+		t.Fatalf("received %d events before being dropped, want %d", n, subscriberBuffer)
+	}
+}
+
+// subscribers returns the number of open subscriptions
+func (b *Broker) subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
+
+func TestFollowResumesAfterFallingBehind(t *testing.T) {
+	b := NewBroker(Config{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	handled := make(chan string, 2*subscriberBuffer)
+	go b.Follow(ctx, func(e models.Event) {
+		if e.BlockNumber == 0 {
+			close(started)
+			<-release
+		}
+		handled <- e.ID
+	})
+
+	// Wait for the subscription before publishing
+	for b.subscribers() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	b.Publish(event(0, 0, 1))
+	<-started
+	total := subscriberBuffer + 10
+	for i := 1; i < total; i++ {
+		b.Publish(event(uint64(i), 0, 1))
+	}
+	close(release)
+
+	for i := 0; i < total; i++ {
+		select {
+		case id := <-handled:
+			if want := fmt.Sprintf("%d-0", i); id != want {
+				t.Fatalf("event %d = %s, want %s", i, id, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("handled %d of %d events", i, total)
+		}
+	}
+}
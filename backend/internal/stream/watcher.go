@@ -0,0 +1,254 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"voting-dapp/backend/internal/blockchain"
+	"voting-dapp/backend/internal/models"
+	"voting-dapp/backend/internal/service"
+)
+
+// Watcher polls the contract's logs and publishes them to a broker, along
+// with the status changes polls go through as block time passes their start
+// and end
+type Watcher struct {
+	client   *blockchain.Client
+	broker   *Broker
+	interval time.Duration
+
+	polls map[uint64]*pollState
+}
+
+// pollState is what a poll's status is derived from
+type pollState struct {
+	startTime int64
+	endTime   int64
+	active    bool
+	canceled  bool
+	status    string
+}
+
+// NewWatcher creates a watcher that checks for new blocks every interval
+func NewWatcher(client *blockchain.Client, broker *Broker, interval time.Duration) *Watcher {
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	return &Watcher{
+		client:   client,
+		broker:   broker,
+		interval: interval,
+		polls:    make(map[uint64]*pollState),
+	}
+}
+
+// Run publishes the events of every block mined after it starts until ctx
+// is canceled. It reads new blocks when the client reports a new head and
+// every interval in case a notification was missed; each read resumes from
+// the last block read, including after the node connection drops.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	heads, stop := w.client.NotifyHeads()
+	defer stop()
+
+	var poller *blockchain.LogPoller
+	for {
+		var err error
+		if poller == nil {
+			poller, err = w.start(ctx)
+		} else {
+			err = w.poll(ctx, poller)
+		}
+		if err != nil {
+			log.Printf("Event stream: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-heads:
+		}
+	}
+}
+
+// start records the current status of every poll and returns a poller
+// positioned after the current head
+func (w *Watcher) start(ctx context.Context) (*blockchain.LogPoller, error) {
+	number, err := w.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Read the polls at the same head the poller starts after
+	if err := w.loadPolls(ctx, number); err != nil {
+		return nil, err
+	}
+	return w.client.NewLogPoller(number+1, 0), nil
+}
+
+// loadPolls replaces the tracked poll state with that at block number
+func (w *Watcher) loadPolls(ctx context.Context, number uint64) error {
+	header, err := w.client.HeaderByNumber(ctx, number)
+	if err != nil {
+		return err
+	}
+	reader, _, err := service.NewChain(w.client).At(new(big.Int).SetUint64(number))
+	if err != nil {
+		return err
+	}
+	polls, err := reader.ListPolls()
+	if err != nil {
+		return err
+	}
+
+	w.polls = make(map[uint64]*pollState, len(polls))
+	for _, p := range polls {
+		state := &pollState{
+			startTime: p.StartTime,
+			endTime:   p.EndTime,
+			active:    p.IsActive,
+			canceled:  p.IsCanceled,
+		}
+		state.status = state.statusAt(int64(header.Time))
+		w.polls[p.ID] = state
+	}
+	return nil
+}
+
+// poll publishes the events of the blocks mined since the last call
+func (w *Watcher) poll(ctx context.Context, poller *blockchain.LogPoller) error {
+	header, logs, err := poller.Poll(ctx)
+	var reorg *blockchain.ReorgError
+	if errors.As(err, &reorg) {
+		// The state tracked includes the replaced blocks; the next poll
+		// publishes the events of the blocks that replaced them
+		log.Printf("Event stream: chain reorganization detected, re-reading from block %d", reorg.Block+1)
+		return w.loadPolls(ctx, reorg.Block)
+	}
+	if err != nil || header == nil {
+		return err
+	}
+	block := header.Number.Uint64()
+
+	var events []models.Event
+	var next uint
+	for _, l := range logs {
+		if l.Removed {
+			continue
+		}
+		if l.BlockNumber == block {
+			next = l.Index + 1
+		}
+		if e, ok := w.logEvent(l); ok {
+			events = append(events, e)
+		}
+	}
+
+	// Status changes follow the logs of the last block read
+	ids := make([]uint64, 0, len(w.polls))
+	for id := range w.polls {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		p := w.polls[id]
+		status := p.statusAt(int64(header.Time))
+		if status == p.status {
+			continue
+		}
+		p.status = status
+		events = append(events, models.Event{
+			Type:        models.EventStatus,
+			PollID:      id,
+			BlockNumber: block,
+			Index:       next,
+			Data:        map[string]interface{}{"status": status},
+		})
+		next++
+	}
+
+	w.broker.Publish(events...)
+	return nil
+}
+
+// logEvent converts a contract log into a stream event and applies it to
+// the tracked poll state
+func (w *Watcher) logEvent(l types.Log) (models.Event, bool) {
+	parsed, err := w.client.ParseEvent(l)
+	if err != nil {
+		log.Printf("Event stream: skipping log %s/%d: %v", l.TxHash.Hex(), l.Index, err)
+		return models.Event{}, false
+	}
+
+	e := models.Event{
+		BlockNumber: l.BlockNumber,
+		Index:       l.Index,
+		TxHash:      l.TxHash.Hex(),
+	}
+
+	switch ev := parsed.(type) {
+	case *blockchain.VotingPollCreated:
+		e.Type = models.EventPollCreated
+		e.PollID = ev.PollId.Uint64()
+		e.Data = map[string]interface{}{
+			"title":     ev.Title,
+			"creator":   ev.Creator.Hex(),
+			"startTime": ev.StartTime.Int64(),
+			"endTime":   ev.EndTime.Int64(),
+		}
+		w.polls[e.PollID] = &pollState{
+			startTime: ev.StartTime.Int64(),
+			endTime:   ev.EndTime.Int64(),
+			active:    true,
+		}
+
+	case *blockchain.VotingVoted:
+		e.Type = models.EventVoted
+		e.PollID = ev.PollId.Uint64()
+		e.Data = map[string]interface{}{
+			"voter":       ev.Voter.Hex(),
+			"optionIndex": ev.OptionIndex.Uint64(),
+			"weight":      ev.Weight.Uint64(),
+		}
+
+	case *blockchain.VotingPollCanceled:
+		e.Type = models.EventPollCanceled
+		e.PollID = ev.PollId.Uint64()
+		if p, ok := w.polls[e.PollID]; ok {
+			p.canceled = true
+		}
+
+	case *blockchain.VotingPollActivated:
+		e.Type = models.EventPollActivated
+		e.PollID = ev.PollId.Uint64()
+		if p, ok := w.polls[e.PollID]; ok {
+			p.active = true
+		}
+
+	case *blockchain.VotingPollDeactivated:
+		e.Type = models.EventPollDeactivated
+		e.PollID = ev.PollId.Uint64()
+		if p, ok := w.polls[e.PollID]; ok {
+			p.active = false
+		}
+
+	default:
+		return models.Event{}, false
+	}
+	return e, true
+}
+
+// statusAt returns the status the contract reports for the poll at block
+// time ts
+func (p *pollState) statusAt(ts int64) string {
+	poll := models.Poll{StartTime: p.startTime, EndTime: p.endTime, IsActive: p.active, IsCanceled: p.canceled}
+	return poll.StatusAt(ts)
+}
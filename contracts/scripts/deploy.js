@@ -11,12 +11,21 @@ async function main() {
 
   console.log("Voting contract deployed to:", voting.address);
   console.log("Deployer address:", await voting.admin());
+
+  // The backend batches reads through a Multicall3 aggregator
+  const Multicall3 = await hre.ethers.getContractFactory("Multicall3");
+  const multicall = await Multicall3.deploy();
+
+  await multicall.deployed();
+
+  console.log("Multicall3 contract deployed to:", multicall.address);
   
   // Save deployment info
   const fs = require("fs");
   const deploymentInfo = {
     network: hre.network.name,
     address: voting.address,
+    multicall: multicall.address,
     admin: await voting.admin(),
     deployTx: voting.deployTransaction.hash,
     timestamp: new Date().toISOString()
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.19;
+
+/**
+ * @title Multicall3
+ * @dev Aggregates view calls into one, answered at a single block. This is
+ * the subset of Multicall3 (https://github.com/mds1/multicall) the backend
+ * uses, with the same ABI, so the canonical deployment at
+ * 0xcA11bde05977b3631167028862bE2a173976CA11 can be used where it exists.
+ */
+contract Multicall3 {
+    struct Call3 {
+        address target;
+        bool allowFailure;
+        bytes callData;
+    }
+
+    struct Result {
+        bool success;
+        bytes returnData;
+    }
+
+    /**
+     * @dev Performs each call in turn, reverting if one that does not allow
+     * failure reverts
+     * @param calls The calls to make
+     * @return returnData The success and return data of each call
+     */
+    function aggregate3(Call3[] calldata calls) public payable returns (Result[] memory returnData) {
+        returnData = new Result[](calls.length);
+        for (uint256 i = 0; i < calls.length; i++) {
+            Call3 calldata call = calls[i];
+            Result memory result = returnData[i];
+            (result.success, result.returnData) = call.target.call(call.callData);
+            require(call.allowFailure || result.success, "Multicall3: call failed");
+        }
+    }
+
+    /**
+     * @dev Returns the number of the block the calls are answered at
+     */
+    function getBlockNumber() public view returns (uint256 blockNumber) {
+        blockNumber = block.number;
+    }
+}
@@ -49,6 +49,28 @@ contract Voting {
     // voter => votingPower
     mapping(address => uint256) public votingPower;
     
+    // voter => next voteBySig nonce
+    mapping(address => uint256) public nonces;
+    
+    // role => account => has role
+    mapping(bytes32 => mapping(address => bool)) public roles;
+    
+    // ============ Roles ============
+    
+    bytes32 public constant POLL_MANAGER_ROLE = keccak256("POLL_MANAGER_ROLE");
+    
+    bytes32 public constant POWER_ASSIGNER_ROLE = keccak256("POWER_ASSIGNER_ROLE");
+    
+    // ============ EIP-712 ============
+    
+    bytes32 public constant DOMAIN_TYPEHASH = keccak256(
+        "EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"
+    );
+    
+    bytes32 public constant VOTE_TYPEHASH = keccak256(
+        "Vote(uint256 pollId,uint256 optionIndex,uint256 nonce,uint256 deadline)"
+    );
+    
     // ============ Events ============
     
     event PollCreated(
@@ -77,6 +99,12 @@ contract Voting {
     
     event PollDeactivated(uint256 indexed pollId);
     
+    event AdminTransferred(address indexed previousAdmin, address indexed newAdmin);
+    
+    event RoleGranted(bytes32 indexed role, address indexed account);
+    
+    event RoleRevoked(bytes32 indexed role, address indexed account);
+    
     // ============ Modifiers ============
     
     modifier onlyAdmin() {
@@ -84,6 +112,11 @@ contract Voting {
         _;
     }
     
+    modifier onlyRole(bytes32 _role) {
+        require(hasRole(_role, msg.sender), "Caller is missing the required role");
+        _;
+    }
+    
     modifier pollExists(uint256 _pollId) {
         require(_pollId > 0 && _pollId <= pollCount, "Poll does not exist");
         _;
@@ -116,15 +149,42 @@ contract Voting {
      */
     function transferAdmin(address _newAdmin) external onlyAdmin {
         require(_newAdmin != address(0), "Invalid admin address");
+        emit AdminTransferred(admin, _newAdmin);
         admin = _newAdmin;
     }
     
+    /**
+     * @dev Grant a role to an account
+     * @param _role The role, POLL_MANAGER_ROLE or POWER_ASSIGNER_ROLE
+     * @param _account The account address
+     */
+    function grantRole(bytes32 _role, address _account) external onlyAdmin {
+        require(_role == POLL_MANAGER_ROLE || _role == POWER_ASSIGNER_ROLE, "Unknown role");
+        require(_account != address(0), "Invalid account address");
+        if (!roles[_role][_account]) {
+            roles[_role][_account] = true;
+            emit RoleGranted(_role, _account);
+        }
+    }
+    
+    /**
+     * @dev Revoke a role from an account
+     * @param _role The role
+     * @param _account The account address
+     */
+    function revokeRole(bytes32 _role, address _account) external onlyAdmin {
+        if (roles[_role][_account]) {
+            roles[_role][_account] = false;
+            emit RoleRevoked(_role, _account);
+        }
+    }
+    
     /**
      * @dev Assign voting power to a voter
      * @param _voter The voter address
      * @param _power The voting power amount
      */
-    function assignVotingPower(address _voter, uint256 _power) external onlyAdmin {
+    function assignVotingPower(address _voter, uint256 _power) external onlyRole(POWER_ASSIGNER_ROLE) {
         require(_voter != address(0), "Invalid voter address");
         votingPower[_voter] = _power;
         emit VotingPowerAssigned(_voter, _power);
@@ -138,7 +198,7 @@ contract Voting {
     function batchAssignVotingPower(
         address[] calldata _voters,
         uint256[] calldata _powers
-    ) external onlyAdmin {
+    ) external onlyRole(POWER_ASSIGNER_ROLE) {
         require(_voters.length == _powers.length, "Arrays length mismatch");
         for (uint256 i = 0; i < _voters.length; i++) {
             require(_voters[i] != address(0), "Invalid voter address");
@@ -151,7 +211,7 @@ contract Voting {
      * @dev Cancel a poll
      * @param _pollId The poll ID
      */
-    function cancelPoll(uint256 _pollId) external onlyAdmin pollExists(_pollId) {
+    function cancelPoll(uint256 _pollId) external onlyRole(POLL_MANAGER_ROLE) pollExists(_pollId) {
         polls[_pollId].isCanceled = true;
         emit PollCanceled(_pollId);
     }
@@ -160,7 +220,7 @@ contract Voting {
      * @dev Activate a poll
      * @param _pollId The poll ID
      */
-    function activatePoll(uint256 _pollId) external onlyAdmin pollExists(_pollId) {
+    function activatePoll(uint256 _pollId) external onlyRole(POLL_MANAGER_ROLE) pollExists(_pollId) {
         polls[_pollId].isActive = true;
         emit PollActivated(_pollId);
     }
@@ -169,7 +229,7 @@ contract Voting {
      * @dev Deactivate a poll
      * @param _pollId The poll ID
      */
-    function deactivatePoll(uint256 _pollId) external onlyAdmin pollExists(_pollId) {
+    function deactivatePoll(uint256 _pollId) external onlyRole(POLL_MANAGER_ROLE) pollExists(_pollId) {
         polls[_pollId].isActive = false;
         emit PollDeactivated(_pollId);
     }
@@ -228,28 +288,107 @@ contract Voting {
         pollActive(_pollId) 
         withinTimeFrame(_pollId) 
     {
-        require(!hasVoted[_pollId][msg.sender], "Already voted");
+        _vote(_pollId, _optionIndex, msg.sender);
+    }
+    
+    /**
+     * @dev Cast a vote on behalf of a voter who signed it as EIP-712 typed data
+     * @param _pollId The poll ID
+     * @param _optionIndex The selected option index
+     * @param _nonce The voter's current nonce
+     * @param _deadline Timestamp after which the signature is no longer valid
+     * @param _v Signature recovery id
+     * @param _r Signature r value
+     * @param _s Signature s value
+     */
+    function voteBySig(
+        uint256 _pollId,
+        uint256 _optionIndex,
+        uint256 _nonce,
+        uint256 _deadline,
+        uint8 _v,
+        bytes32 _r,
+        bytes32 _s
+    )
+        external
+        pollExists(_pollId)
+        pollActive(_pollId)
+        withinTimeFrame(_pollId)
+    {
+        require(block.timestamp <= _deadline, "Signature expired");
+        // Reject malleable signatures with s in the upper half of the curve order
+        require(
+            uint256(_s) <= 0x7FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF5D576E7357A4501DDFE92F46681B20A0,
+            "Invalid signature"
+        );
+        
+        bytes32 structHash = keccak256(
+            abi.encode(VOTE_TYPEHASH, _pollId, _optionIndex, _nonce, _deadline)
+        );
+        bytes32 digest = keccak256(
+            abi.encodePacked("\x19\x01", DOMAIN_SEPARATOR(), structHash)
+        );
+        address voter = ecrecover(digest, _v, _r, _s);
+        require(voter != address(0), "Invalid signature");
+        require(_nonce == nonces[voter], "Invalid nonce");
+        
+        nonces[voter]++;
+        _vote(_pollId, _optionIndex, voter);
+    }
+    
+    /**
+     * @dev EIP-712 domain separator for vote signatures
+     */
+    function DOMAIN_SEPARATOR() public view returns (bytes32) {
+        return keccak256(
+            abi.encode(
+                DOMAIN_TYPEHASH,
+                keccak256(bytes("Voting")),
+                keccak256(bytes("1")),
+                block.chainid,
+                address(this)
+            )
+        );
+    }
+    
+    // ============ Internal Functions ============
+    
+    /**
+     * @dev Record a vote for a voter
+     */
+    function _vote(uint256 _pollId, uint256 _optionIndex, address _voter) internal {
+        require(!hasVoted[_pollId][_voter], "Already voted");
         require(_optionIndex < polls[_pollId].options.length, "Invalid option index");
-        require(votingPower[msg.sender] > 0, "No voting power");
+        require(votingPower[_voter] > 0, "No voting power");
         
-        uint256 weight = votingPower[msg.sender];
+        uint256 weight = votingPower[_voter];
         
-        hasVoted[_pollId][msg.sender] = true;
+        hasVoted[_pollId][_voter] = true;
         voteCounts[_pollId][_optionIndex] += weight;
         polls[_pollId].totalVotes += weight;
         
-        votes[_pollId][msg.sender] = Vote({
+        votes[_pollId][_voter] = Vote({
             pollId: _pollId,
             optionIndex: _optionIndex,
-            voter: msg.sender,
+            voter: _voter,
             timestamp: block.timestamp
         });
         
-        emit Voted(_pollId, msg.sender, _optionIndex, weight);
+        emit Voted(_pollId, _voter, _optionIndex, weight);
     }
     
     // ============ View Functions ============
     
+    /**
+     * @dev Check whether an account holds a role; the admin holds every role
+     * @param _role The role
+     * @param _account The account address
+     * @return Whether the account holds the role
+     */
+    function hasRole(bytes32 _role, address _account) public view returns (bool) {
+        return _account == admin || roles[_role][_account];
+    }
+    
     /**
      * @dev Get poll details
      * @param _pollId The poll ID
@@ -44,10 +44,93 @@ describe("Voting", function () {
     it("Should not allow non-admin to assign voting power", async function () {
       await expect(
         voting.connect(addr1).assignVotingPower(addr2.address, 100)
+      ).to.be.revertedWith("Caller is missing the required role");
+    });
+  });
+
+  describe("Roles", function () {
+    it("Should transfer admin rights", async function () {
+      await expect(voting.transferAdmin(addr1.address))
+        .to.emit(voting, "AdminTransferred")
+        .withArgs(owner.address, addr1.address);
+      expect(await voting.admin()).to.equal(addr1.address);
+      await expect(
+        voting.transferAdmin(owner.address)
+      ).to.be.revertedWith("Only admin can call this function");
+    });
+
+    it("Should let a power assigner assign voting power only", async function () {
+      const role = await voting.POWER_ASSIGNER_ROLE();
+      await expect(voting.grantRole(role, addr1.address))
+        .to.emit(voting, "RoleGranted")
+        .withArgs(role, addr1.address);
+
+      await voting.connect(addr1).assignVotingPower(addr2.address, 100);
+      expect(await voting.votingPower(addr2.address)).to.equal(100);
+
+      const startTime = Math.floor(Date.now() / 1000) + 3600;
+      await voting.createPoll("Poll", "", ["A", "B"], startTime, startTime + 3600);
+      await expect(
+        voting.connect(addr1).cancelPoll(1)
+      ).to.be.revertedWith("Caller is missing the required role");
+    });
+
+    it("Should let a poll manager manage polls", async function () {
+      const role = await voting.POLL_MANAGER_ROLE();
+      await voting.grantRole(role, addr1.address);
+
+      const startTime = Math.floor(Date.now() / 1000) + 3600;
+      await voting.createPoll("Poll", "", ["A", "B"], startTime, startTime + 3600);
+      await voting.connect(addr1).deactivatePoll(1);
+      expect((await voting.getPoll(1)).isActive).to.be.false;
+    });
+
+    it("Should stop an account whose role was revoked", async function () {
+      const role = await voting.POWER_ASSIGNER_ROLE();
+      await voting.grantRole(role, addr1.address);
+      await expect(voting.revokeRole(role, addr1.address))
+        .to.emit(voting, "RoleRevoked")
+        .withArgs(role, addr1.address);
+      await expect(
+        voting.connect(addr1).assignVotingPower(addr2.address, 100)
+      ).to.be.revertedWith("Caller is missing the required role");
+    });
+
+    it("Should reject unknown roles and non-admin grants", async function () {
+      await expect(
+        voting.grantRole(ethers.utils.id("OTHER_ROLE"), addr1.address)
+      ).to.be.revertedWith("Unknown role");
+      await expect(
+        voting.connect(addr1).grantRole(await voting.POLL_MANAGER_ROLE(), addr1.address)
       ).to.be.revertedWith("Only admin can call this function");
     });
   });
 
+  describe("Role checks", function () {
+    it("Should count the admin as holding every role", async function () {
+      expect(await voting.hasRole(await voting.POLL_MANAGER_ROLE(), owner.address)).to.be.true;
+      expect(await voting.hasRole(await voting.POWER_ASSIGNER_ROLE(), owner.address)).to.be.true;
+      expect(await voting.hasRole(await voting.POWER_ASSIGNER_ROLE(), addr1.address)).to.be.false;
+    });
+
+    it("Should only let an account act within the roles it holds", async function () {
+      await voting.grantRole(await voting.POLL_MANAGER_ROLE(), addr1.address);
+      expect(await voting.hasRole(await voting.POLL_MANAGER_ROLE(), addr1.address)).to.be.true;
+      await expect(
+        voting.connect(addr1).assignVotingPower(addr2.address, 100)
+      ).to.be.revertedWith("Caller is missing the required role");
+    });
+
+    it("Should stop an account once its role is revoked", async function () {
+      await voting.grantRole(await voting.POWER_ASSIGNER_ROLE(), addr1.address);
+      await voting.connect(addr1).assignVotingPower(addr2.address, 100);
+      await voting.revokeRole(await voting.POWER_ASSIGNER_ROLE(), addr1.address);
+      await expect(
+        voting.connect(addr1).assignVotingPower(addr2.address, 100)
+      ).to.be.revertedWith("Caller is missing the required role");
+    });
+  });
+
   describe("Poll Creation", function () {
     it("Should create a poll successfully", async function () {
       const startTime = Math.floor(Date.now() / 1000) + 3600; // 1 hour from now
@@ -156,6 +239,91 @@ describe("Voting", function () {
     });
   });
 
+  describe("Vote By Signature", function () {
+    let deadline;
+    let domain;
+
+    const types = {
+      Vote: [
+        { name: "pollId", type: "uint256" },
+        { name: "optionIndex", type: "uint256" },
+        { name: "nonce", type: "uint256" },
+        { name: "deadline", type: "uint256" },
+      ],
+    };
+
+    async function signVote(signer, pollId, optionIndex, nonce, voteDeadline) {
+      const signature = await signer._signTypedData(domain, types, {
+        pollId,
+        optionIndex,
+        nonce,
+        deadline: voteDeadline,
+      });
+      return ethers.utils.splitSignature(signature);
+    }
+
+    beforeEach(async function () {
+      await voting.assignVotingPower(addr1.address, 100);
+
+      const currentTime = Math.floor(Date.now() / 1000);
+      await voting.createPoll(
+        "Test Poll",
+        "Description",
+        ["Option A", "Option B"],
+        currentTime - 60,
+        currentTime + 86400
+      );
+
+      deadline = currentTime + 3600;
+      domain = {
+        name: "Voting",
+        version: "1",
+        chainId: (await ethers.provider.getNetwork()).chainId,
+        verifyingContract: voting.address,
+      };
+    });
+
+    it("Should record a relayed vote for the signer", async function () {
+      const { v, r, s } = await signVote(addr1, 1, 1, 0, deadline);
+
+      await expect(voting.connect(addr2).voteBySig(1, 1, 0, deadline, v, r, s))
+        .to.emit(voting, "Voted")
+        .withArgs(1, addr1.address, 1, 100);
+
+      expect(await voting.hasVoted(1, addr1.address)).to.be.true;
+      expect(await voting.hasVoted(1, addr2.address)).to.be.false;
+      expect(await voting.nonces(addr1.address)).to.equal(1);
+    });
+
+    it("Should not allow replaying a signature", async function () {
+      const { v, r, s } = await signVote(addr1, 1, 1, 0, deadline);
+      await voting.voteBySig(1, 1, 0, deadline, v, r, s);
+
+      await expect(
+        voting.voteBySig(1, 1, 0, deadline, v, r, s)
+      ).to.be.revertedWith("Invalid nonce");
+    });
+
+    it("Should not accept an expired signature", async function () {
+      const expired = Math.floor(Date.now() / 1000) - 3600;
+      const { v, r, s } = await signVote(addr1, 1, 1, 0, expired);
+
+      await expect(
+        voting.voteBySig(1, 1, 0, expired, v, r, s)
+      ).to.be.revertedWith("Signature expired");
+    });
+
+    it("Should not attribute a tampered vote to the signer", async function () {
+      const { v, r, s } = await signVote(addr1, 1, 1, 0, deadline);
+
+      // Changing the option recovers a different address with no voting power
+      await expect(
+        voting.voteBySig(1, 0, 0, deadline, v, r, s)
+      ).to.be.reverted;
+      expect(await voting.hasVoted(1, addr1.address)).to.be.false;
+    });
+  });
+
   describe("Poll Management", function () {
     it("Should cancel a poll", async function () {
       const startTime = Math.floor(Date.now() / 1000) + 3600;
@@ -1,14 +1,14 @@
-import { useState, useEffect, useCallback } from 'react'
+import { useState, useEffect, useCallback, useRef } from 'react'
 
 const API_BASE = '/api'
 
-async function apiRequest(endpoint, options = {}) {
+export async function apiRequest(endpoint, options = {}) {
   const response = await fetch(`${API_BASE}${endpoint}`, {
+    ...options,
     headers: {
       'Content-Type': 'application/json',
       ...options.headers,
     },
-    ...options,
   })
 
   const data = await response.json()
@@ -50,8 +50,16 @@ export function usePolls() {
   const fetchPolls = useCallback(async () => {
     try {
       setLoading(true)
-      const data = await apiRequest('/polls')
-      setPolls(data)
+      // Follow the cursor until every page is loaded
+      const all = []
+      let cursor = ''
+      do {
+        const query = cursor ? `?cursor=${encodeURIComponent(cursor)}` : ''
+        const page = await apiRequest(`/polls${query}`)
+        all.push(...page.polls)
+        cursor = page.nextCursor
+      } while (cursor)
+      setPolls(all)
     } catch (err) {
       setError(err.message)
     } finally {
@@ -73,11 +81,11 @@ export function usePoll(pollId) {
   const [loading, setLoading] = useState(true)
   const [error, setError] = useState(null)
 
-  const fetchPoll = useCallback(async () => {
+  const fetchPoll = useCallback(async ({ silent = false } = {}) => {
     if (!pollId) return
 
     try {
-      setLoading(true)
+      if (!silent) setLoading(true)
       const [pollData, resultsData, statusData] = await Promise.all([
         apiRequest(`/polls/${pollId}`),
         apiRequest(`/polls/${pollId}/results`),
@@ -100,6 +108,28 @@ export function usePoll(pollId) {
   return { poll, results, status, loading, error, refetch: fetchPoll }
 }
 
+const POLL_STREAM_EVENTS = ['voted', 'pollCanceled', 'pollActivated', 'pollDeactivated', 'status']
+
+// usePollStream calls onEvent for every event pushed on a poll's stream.
+// EventSource reconnects on its own and resumes with Last-Event-ID.
+export function usePollStream(pollId, onEvent) {
+  const onEventRef = useRef(onEvent)
+
+  useEffect(() => {
+    onEventRef.current = onEvent
+  }, [onEvent])
+
+  useEffect(() => {
+    if (!pollId) return
+
+    const source = new EventSource(`${API_BASE}/polls/${pollId}/stream`)
+    const handleEvent = (e) => onEventRef.current(JSON.parse(e.data))
+    POLL_STREAM_EVENTS.forEach((type) => source.addEventListener(type, handleEvent))
+
+    return () => source.close()
+  }, [pollId])
+}
+
 export function useVoterStatus(pollId, address) {
   const [voterStatus, setVoterStatus] = useState(null)
   const [loading, setLoading] = useState(true)
@@ -1,14 +1,50 @@
 import { createContext, useContext, useState, useEffect } from 'react'
 import { ethers } from 'ethers'
+import { apiRequest } from './useApi'
 
 const WalletContext = createContext()
 
+const SESSION_KEY = 'voting-session'
+const SIGN_IN_STATEMENT = 'Sign in to the voting API.'
+
+// loadSession returns the stored session if it has not expired
+function loadSession() {
+  try {
+    const session = JSON.parse(sessionStorage.getItem(SESSION_KEY))
+    if (session && new Date(session.expiresAt) > new Date()) {
+      return session
+    }
+  } catch {
+    // Ignore a malformed entry
+  }
+  sessionStorage.removeItem(SESSION_KEY)
+  return null
+}
+
+// signInMessage builds the EIP-4361 message the API verifies
+function signInMessage({ address, chainId, nonce }) {
+  return [
+    `${window.location.host} wants you to sign in with your Ethereum account:`,
+    address,
+    '',
+    SIGN_IN_STATEMENT,
+    '',
+    `URI: ${window.location.origin}`,
+    'Version: 1',
+    `Chain ID: ${chainId}`,
+    `Nonce: ${nonce}`,
+    `Issued At: ${new Date().toISOString()}`,
+  ].join('\n')
+}
+
 export function WalletProvider({ children }) {
   const [account, setAccount] = useState(null)
   const [provider, setProvider] = useState(null)
   const [signer, setSigner] = useState(null)
   const [isConnecting, setIsConnecting] = useState(false)
   const [error, setError] = useState(null)
+  const [session, setSession] = useState(loadSession)
+  const [isSigningIn, setIsSigningIn] = useState(false)
 
   const connectWallet = async () => {
     if (typeof window.ethereum === 'undefined') {
@@ -35,12 +71,63 @@ export function WalletProvider({ children }) {
     }
   }
 
+  const saveSession = (next) => {
+    if (next) {
+      sessionStorage.setItem(SESSION_KEY, JSON.stringify(next))
+    } else {
+      sessionStorage.removeItem(SESSION_KEY)
+    }
+    setSession(next)
+  }
+
+  // signIn signs a SIWE message for the connected account and stores the
+  // session token the API returns
+  const signIn = async () => {
+    if (!provider || !account) {
+      throw new Error('Connect your wallet first')
+    }
+
+    setIsSigningIn(true)
+    try {
+      const [{ nonce }, contract] = await Promise.all([
+        apiRequest('/auth/nonce'),
+        apiRequest('/contract'),
+      ])
+      const message = signInMessage({
+        address: ethers.getAddress(account),
+        chainId: contract.chainId,
+        nonce,
+      })
+      // Ask for the signer again in case the account changed since connecting
+      const accountSigner = await provider.getSigner(account)
+      const signature = await accountSigner.signMessage(message)
+      const next = await apiRequest('/auth/verify', {
+        method: 'POST',
+        body: JSON.stringify({ message, signature }),
+      })
+      saveSession(next)
+      return next
+    } finally {
+      setIsSigningIn(false)
+    }
+  }
+
+  const signOut = () => saveSession(null)
+
   const disconnectWallet = () => {
     setAccount(null)
     setProvider(null)
     setSigner(null)
+    signOut()
   }
 
+  // A session only counts for the account that signed it, until it expires
+  const isSignedIn =
+    !!session &&
+    !!account &&
+    session.address.toLowerCase() === account.toLowerCase() &&
+    new Date(session.expiresAt) > new Date()
+
   useEffect(() => {
     if (window.ethereum) {
       window.ethereum.on('accountsChanged', (accounts) => {
@@ -73,6 +160,12 @@ export function WalletProvider({ children }) {
     connectWallet,
     disconnectWallet,
     isConnected: !!account,
+    session: isSignedIn ? session : null,
+    isSignedIn,
+    isSigningIn,
+    signIn,
+    signOut,
+    authHeaders: isSignedIn ? { Authorization: `Bearer ${session.token}` } : {},
   }
 
   return (
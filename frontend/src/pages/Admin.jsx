@@ -3,7 +3,8 @@ import { useApi } from '../hooks/useApi'
 import { useWallet } from '../hooks/useWallet'
 
 export default function Admin() {
-  const { account, isConnected, connectWallet } = useWallet()
+  const { account, isConnected, connectWallet, isSignedIn, isSigningIn, signIn, signOut, authHeaders } =
+    useWallet()
   const { loading, request } = useApi()
 
   const [voterAddress, setVoterAddress] = useState('')
@@ -16,6 +17,14 @@ export default function Admin() {
     setTimeout(() => setMessage({ type: '', text: '' }), 5000)
   }
 
+  const handleSignIn = async () => {
+    try {
+      await signIn()
+    } catch (err) {
+      showMessage('error', err.message)
+    }
+  }
+
   const handleAssignPower = async (e) => {
     e.preventDefault()
 
@@ -27,6 +36,7 @@ export default function Admin() {
     try {
       await request('/voting-power/assign', {
         method: 'POST',
+        headers: authHeaders,
         body: JSON.stringify({
           voter: voterAddress,
           power: parseInt(votingPower),
@@ -68,6 +78,7 @@ export default function Admin() {
 
       await request('/voting-power/assign-batch', {
         method: 'POST',
+        headers: authHeaders,
         body: JSON.stringify({ voters, powers }),
       })
 
@@ -94,9 +105,33 @@ export default function Admin() {
     )
   }
 
+  if (!isSignedIn) {
+    return (
+      <div className="max-w-2xl mx-auto">
+        <div className="card text-center">
+          <h1 className="text-2xl font-bold text-gray-800 mb-4">Admin Panel</h1>
+          <p className="text-gray-600 mb-6">
+            Sign a message with {account.slice(0, 6)}...{account.slice(-4)} to start an admin session
+          </p>
+          {message.text && (
+            <p className="text-red-600 mb-4">{message.text}</p>
+          )}
+          <button onClick={handleSignIn} disabled={isSigningIn} className="btn-primary">
+            {isSigningIn ? 'Signing In...' : 'Sign In with Ethereum'}
+          </button>
+        </div>
+      </div>
+    )
+  }
+
   return (
     <div className="max-w-2xl mx-auto">
-      <h1 className="text-3xl font-bold text-gray-800 mb-8">Admin Panel</h1>
+      <div className="flex justify-between items-center mb-8">
+        <h1 className="text-3xl font-bold text-gray-800">Admin Panel</h1>
+        <button onClick={signOut} className="btn-secondary">
+          Sign Out
+        </button>
+      </div>
 
       {message.text && (
         <div
@@ -177,8 +212,8 @@ export default function Admin() {
       {/* Info */}
       <div className="mt-6 bg-yellow-50 border border-yellow-200 rounded-lg p-4">
         <p className="text-yellow-800">
-          <strong>Note:</strong> Only the contract admin can assign voting power.
-          Make sure your connected wallet is the admin address.
+          <strong>Note:</strong> Only accounts with the power-assigner role can assign
+          voting power. Make sure you signed in with such an account.
         </p>
       </div>
     </div>
@@ -5,7 +5,7 @@ import { useApi } from '../hooks/useApi'
 
 export default function CreatePoll() {
   const navigate = useNavigate()
-  const { isConnected, connectWallet } = useWallet()
+  const { isConnected, connectWallet, isSignedIn, isSigningIn, signIn, authHeaders } = useWallet()
   const { loading, request } = useApi()
 
   const [formData, setFormData] = useState({
@@ -85,6 +85,16 @@ export default function CreatePoll() {
       return
     }
 
+    // Creating polls needs a session for a poll manager
+    if (!isSignedIn) {
+      try {
+        await signIn()
+      } catch (err) {
+        setErrors((prev) => ({ ...prev, submit: err.message }))
+      }
+      return
+    }
+
     if (!validate()) return
 
     try {
@@ -93,6 +103,7 @@ export default function CreatePoll() {
 
       const result = await request('/polls', {
         method: 'POST',
+        headers: authHeaders,
         body: JSON.stringify({
           title: formData.title,
           description: formData.description,
@@ -105,6 +116,7 @@ export default function CreatePoll() {
       navigate(`/poll/${result.pollId}`)
     } catch (err) {
       console.error('Failed to create poll:', err)
+      setErrors((prev) => ({ ...prev, submit: err.message }))
     }
   }
 
@@ -218,13 +230,24 @@ export default function CreatePoll() {
         </div>
 
         {/* Submit */}
+        {errors.submit && (
+          <p className="text-red-500 text-sm mb-4">{errors.submit}</p>
+        )}
         <div className="flex gap-4">
           <button
             type="submit"
-            disabled={loading}
+            disabled={loading || isSigningIn}
             className="btn-primary flex-1"
           >
-            {loading ? 'Creating...' : isConnected ? 'Create Poll' : 'Connect Wallet'}
+            {loading
+              ? 'Creating...'
+              : isSigningIn
+                ? 'Signing In...'
+                : !isConnected
+                  ? 'Connect Wallet'
+                  : isSignedIn
+                    ? 'Create Poll'
+                    : 'Sign In to Create'}
           </button>
         </div>
       </form>
@@ -1,7 +1,26 @@
 import { useParams } from 'react-router-dom'
 import { useState } from 'react'
 import { useWallet } from '../hooks/useWallet'
-import { usePoll, useVoterStatus, useApi } from '../hooks/useApi'
+import { usePoll, usePollStream, useVoterStatus, useApi } from '../hooks/useApi'
+
+// EIP-712 types of a vote the backend relays through voteBySig
+const VOTE_TYPES = {
+  EIP712Domain: [
+    { name: 'name', type: 'string' },
+    { name: 'version', type: 'string' },
+    { name: 'chainId', type: 'uint256' },
+    { name: 'verifyingContract', type: 'address' },
+  ],
+  Vote: [
+    { name: 'pollId', type: 'uint256' },
+    { name: 'optionIndex', type: 'uint256' },
+    { name: 'nonce', type: 'uint256' },
+    { name: 'deadline', type: 'uint256' },
+  ],
+}
+
+// How long a signed vote stays valid for the relayer to submit
+const VOTE_DEADLINE_SECONDS = 3600
 
 function ResultsChart({ results }) {
   const maxVotes = Math.max(...results.voteCounts, 1)
@@ -35,10 +54,16 @@ function ResultsChart({ results }) {
 
 export default function PollDetail() {
   const { id } = useParams()
-  const { account, isConnected, connectWallet } = useWallet()
+  const { account, provider, isConnected, connectWallet } = useWallet()
   const { poll, results, status, loading, error, refetch } = usePoll(id)
   const { voterStatus, refetch: refetchVoterStatus } = useVoterStatus(id, account)
-  const { loading: votingLoading, request } = useApi()
+  const { loading: votingLoading, error: voteError, request } = useApi()
+
+  // Refresh in place as votes and status changes are pushed
+  usePollStream(id, () => {
+    refetch({ silent: true })
+    refetchVoterStatus()
+  })
 
   const [selectedOption, setSelectedOption] = useState(null)
 
@@ -60,12 +85,37 @@ export default function PollDetail() {
     if (selectedOption === null) return
 
     try {
+      const [contract, { nonce }] = await Promise.all([
+        request('/contract'),
+        request(`/votes/nonce/${account}`),
+      ])
+      const vote = {
+        pollId: parseInt(id),
+        optionIndex: selectedOption,
+        nonce,
+        deadline: Math.floor(Date.now() / 1000) + VOTE_DEADLINE_SECONDS,
+      }
+
+      // The wallet signs the vote; the backend relays it and pays the gas
+      const typedData = {
+        types: VOTE_TYPES,
+        primaryType: 'Vote',
+        domain: {
+          name: 'Voting',
+          version: '1',
+          chainId: contract.chainId,
+          verifyingContract: contract.address,
+        },
+        message: vote,
+      }
+      const signature = await provider.send('eth_signTypedData_v4', [
+        account,
+        JSON.stringify(typedData),
+      ])
+
       await request('/votes', {
         method: 'POST',
-        body: JSON.stringify({
-          pollId: parseInt(id),
-          optionIndex: selectedOption,
-        }),
+        body: JSON.stringify({ ...vote, voter: account, signature }),
       })
 
       refetch()
@@ -196,6 +246,7 @@ export default function PollDetail() {
           >
             {votingLoading ? 'Voting...' : 'Submit Vote'}
           </button>
+          {voteError && <p className="text-red-600 text-sm mt-2">{voteError}</p>}
         </div>
       )}
 